@@ -0,0 +1,57 @@
+// Command plugin-scaffold is a template for a custom search source loaded
+// as a Go plugin. Copy this directory, implement Search, and build it from
+// within this module with the same Go version and dependencies as the
+// server:
+//
+//	go build -buildmode=plugin -o plugins/example.so ./cmd/plugin-scaffold
+//
+// Then set search_sources.plugin_dir to the plugins directory.
+package main
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/factchecker/verity/internal/search"
+)
+
+// ExampleClient searches a hypothetical internal repository.
+type ExampleClient struct {
+	endpoint string
+}
+
+// NewSearchClient is the symbol the server looks up. Its signature must be
+// exactly func() search.SearchClient.
+func NewSearchClient() search.SearchClient {
+	return &ExampleClient{endpoint: os.Getenv("EXAMPLE_SEARCH_URL")}
+}
+
+// Search returns evidence for query. Replace the body with a call to your
+// data source; returned errors are reported as search warnings. Evidence
+// left without an ID is assigned one by the server.
+func (c *ExampleClient) Search(ctx context.Context, query string, maxResults int, opts search.SearchOptions) ([]models.Evidence, error) {
+	return []models.Evidence{{
+		SourceName:     "Example repository",
+		SourceURL:      c.endpoint + "/documents/1",
+		SourceType:     "web",
+		Snippet:        "Replace with a passage relevant to: " + query,
+		RelevanceScore: 0.5,
+		RetrievedAt:    time.Now(),
+	}}, nil
+}
+
+// Name returns the source name shown in evidence and logs.
+func (c *ExampleClient) Name() string {
+	return "Example"
+}
+
+// Available reports whether the client is configured. Unavailable clients
+// are skipped.
+func (c *ExampleClient) Available() bool {
+	return c.endpoint != ""
+}
+
+// main is unused in plugin builds; it lets the package build with go build ./....
+func main() {}
@@ -0,0 +1,129 @@
+// Command verity runs the fact-checking HTTP server. Given an admin
+// subcommand (keys, migrate, export or stats) it runs that against the
+// configured store instead and exits:
+//
+//	verity [--config verity.yaml]
+//	verity [--config verity.yaml] keys list
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/factchecker/verity/internal/api"
+	"github.com/factchecker/verity/internal/cli"
+	"github.com/factchecker/verity/internal/config"
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/verify"
+	"github.com/factchecker/verity/web"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// shutdownTimeout bounds how long in-flight requests may take to finish
+// once the server is asked to stop.
+const shutdownTimeout = 30 * time.Second
+
+func main() {
+	configPath := flag.String("config", "verity.yaml", "Path to the configuration file")
+	generateConfig := flag.Bool("generate-config", false, "Write a sample configuration to --config and exit")
+	flag.Parse()
+
+	if *generateConfig {
+		if err := config.GenerateSample(*configPath); err != nil {
+			fmt.Fprintf(os.Stderr, "verity: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote sample configuration to %s\n", *configPath)
+		return
+	}
+
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "verity: %v\n", err)
+		os.Exit(1)
+	}
+	setupLogging(cfg.Logging)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if args := flag.Args(); len(args) > 0 {
+		if !cli.IsCommand(args[0]) {
+			fmt.Fprintf(os.Stderr, "verity: unknown command %q\n", args[0])
+			os.Exit(2)
+		}
+		if err := cli.Run(ctx, cfg, args, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "verity: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := serve(ctx, cfg); err != nil {
+		log.Fatal().Err(err).Msg("Server failed")
+	}
+}
+
+// serve runs the HTTP server until ctx is cancelled, then drains in-flight
+// requests.
+func serve(ctx context.Context, cfg *config.Config) error {
+	store, err := cli.OpenStore(cfg, true)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	provider, err := llm.NewProvider(&cfg.LLM)
+	if err != nil {
+		return err
+	}
+	engine := verify.NewEngine(cfg, provider, store)
+	defer engine.Close()
+
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Server.Port),
+		Handler:           api.NewRouter(cfg, engine, store, web.Static),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Info().Int("port", cfg.Server.Port).Str("provider", provider.Name()).Msg("Verity server starting")
+		errCh <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Info().Msg("Shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// setupLogging applies the configured level and format to the global logger.
+func setupLogging(cfg config.LoggingConfig) {
+	level, err := zerolog.ParseLevel(strings.ToLower(cfg.Level))
+	if err != nil || cfg.Level == "" {
+		level = zerolog.InfoLevel
+	}
+	zerolog.SetGlobalLevel(level)
+	if cfg.Format == "text" {
+		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
+	}
+}
@@ -0,0 +1,108 @@
+// Package api provides an in-memory event broker for asynchronous jobs.
+package api
+
+import (
+	"sync"
+	"time"
+
+	"github.com/factchecker/verity/internal/models"
+)
+
+// Job event types.
+const (
+	EventClaim     = "claim"
+	EventCompleted = "completed"
+	EventFailed    = "failed"
+)
+
+// jobRetention is how long finished jobs remain pollable.
+const jobRetention = 10 * time.Minute
+
+// JobEvent is a progress update for an asynchronous verification job.
+// Event IDs are sequential per job, starting at 1.
+type JobEvent struct {
+	ID         int           `json:"id"`
+	Type       string        `json:"type"`
+	Claim      *models.Claim `json:"claim,omitempty"`
+	AnalysisID string        `json:"analysis_id,omitempty"`
+	Error      string        `json:"error,omitempty"`
+}
+
+type job struct {
+	// owner is the ID of the API key that started the job; only it may
+	// read the job's events.
+	owner  string
+	events []JobEvent
+	done   bool
+	// notify is closed and replaced whenever a new event is published.
+	notify chan struct{}
+}
+
+// Broker keeps a per-job event log that verification goroutines publish to
+// and HTTP handlers subscribe to.
+type Broker struct {
+	mu   sync.Mutex
+	jobs map[string]*job
+}
+
+// NewBroker creates a new broker.
+func NewBroker() *Broker {
+	return &Broker{jobs: make(map[string]*job)}
+}
+
+// Register creates an empty event log for a job owned by the API key
+// ownerKeyID. Jobs without an owner cannot be read.
+func (b *Broker) Register(jobID, ownerKeyID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.jobs[jobID] = &job{owner: ownerKeyID, notify: make(chan struct{})}
+}
+
+// Publish appends an event to a job and wakes up any waiting subscribers.
+// Completed and failed events mark the job as done and schedule its removal.
+func (b *Broker) Publish(jobID string, event JobEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	j, ok := b.jobs[jobID]
+	if !ok || j.done {
+		return
+	}
+
+	event.ID = len(j.events) + 1
+	j.events = append(j.events, event)
+
+	if event.Type == EventCompleted || event.Type == EventFailed {
+		j.done = true
+		time.AfterFunc(jobRetention, func() {
+			b.mu.Lock()
+			delete(b.jobs, jobID)
+			b.mu.Unlock()
+		})
+	}
+
+	close(j.notify)
+	j.notify = make(chan struct{})
+}
+
+// Events returns the events after lastEventID, whether the job is done, and
+// a channel that is closed on the next publish. ok is false for unknown jobs
+// and for jobs not owned by the API key keyID, so that other keys cannot
+// tell them apart.
+func (b *Broker) Events(jobID, keyID string, lastEventID int) (events []JobEvent, done bool, notify <-chan struct{}, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	j, ok := b.jobs[jobID]
+	if !ok || j.owner == "" || j.owner != keyID {
+		return nil, false, nil, false
+	}
+
+	if lastEventID < 0 {
+		lastEventID = 0
+	}
+	if lastEventID < len(j.events) {
+		events = append(events, j.events[lastEventID:]...)
+	}
+	return events, j.done, j.notify, true
+}
@@ -0,0 +1,20 @@
+package api
+
+import "testing"
+
+func TestBrokerEventsScopedToOwner(t *testing.T) {
+	b := NewBroker()
+	b.Register("job", "key-a")
+	b.Register("unowned", "")
+	b.Publish("job", JobEvent{Type: EventClaim})
+
+	if events, _, _, ok := b.Events("job", "key-a", 0); !ok || len(events) != 1 {
+		t.Errorf("owner Events() = %v, %v, want one event", events, ok)
+	}
+	if _, _, _, ok := b.Events("job", "key-b", 0); ok {
+		t.Error("Events() for another key succeeded, want not found")
+	}
+	if _, _, _, ok := b.Events("unowned", "", 0); ok {
+		t.Error("Events() for a job without an owner succeeded, want not found")
+	}
+}
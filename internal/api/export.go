@@ -0,0 +1,169 @@
+// Package api provides CSV and JSON Lines export of analyses.
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/factchecker/verity/internal/config"
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+)
+
+// exportFlushInterval is how many claims are written between flushes.
+const exportFlushInterval = 100
+
+// exportCSVHeader names the columns of a CSV export, one row per claim.
+var exportCSVHeader = []string{
+	"claim_id", "text", "type", "status", "confidence", "source_type",
+	"reasoning", "evidence_count", "first_evidence_url",
+}
+
+// ExportResult streams the claims of an analysis as a download, one per
+// CSV row (format=csv) or one JSON object per line (format=jsonl). The
+// configured response mask applies to each claim, with claims[] paths.
+func (h *Handler) ExportResult(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "ID is required")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	var contentType string
+	switch format {
+	case "csv":
+		contentType = "text/csv; charset=utf-8"
+	case "jsonl":
+		contentType = "application/x-ndjson"
+	default:
+		writeError(w, http.StatusBadRequest, "Invalid format (use csv or jsonl)")
+		return
+	}
+
+	analysis, err := h.store.GetAnalysis(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get analysis")
+		writeError(w, http.StatusInternalServerError, "Failed to get result")
+		return
+	}
+	if analysis == nil {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
+
+	claims, err := h.store.GetClaimsByAnalysis(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get claims")
+		writeError(w, http.StatusInternalServerError, "Failed to get claims")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="analysis_%s.%s"`, analysis.ID, format))
+	w.WriteHeader(http.StatusOK)
+	rc := http.NewResponseController(w)
+
+	var cw *csv.Writer
+	if format == "csv" {
+		cw = csv.NewWriter(w)
+		cw.Write(exportCSVHeader)
+	}
+
+	mask := claimMask(h.responseMask)
+	for i, claim := range claims {
+		// Chain of thought is verbose and left out, as in GetResult by default
+		claim.ChainOfThought = ""
+		data, err := json.Marshal(claim)
+		if err != nil {
+			log.Error().Err(err).Str("claim_id", claim.ID).Msg("Failed to encode exported claim")
+			return
+		}
+		data = bytes.TrimSpace(maskJSON(mask, data))
+
+		if cw != nil {
+			err = cw.Write(claimCSVRecord(data))
+		} else {
+			_, err = w.Write(append(data, '\n'))
+		}
+		if err != nil {
+			return // client went away
+		}
+
+		if (i+1)%exportFlushInterval == 0 {
+			if cw != nil {
+				cw.Flush()
+			}
+			rc.Flush()
+		}
+	}
+	if cw != nil {
+		cw.Flush()
+	}
+	rc.Flush()
+}
+
+// claimMask returns the parts of a response mask that apply within each
+// claim, with paths relative to the claim.
+func claimMask(cfg config.ResponseMaskConfig) config.ResponseMaskConfig {
+	var mask config.ResponseMaskConfig
+	for _, path := range cfg.ExcludeFields {
+		if p, ok := strings.CutPrefix(path, "claims[]."); ok {
+			mask.ExcludeFields = append(mask.ExcludeFields, p)
+		}
+	}
+	for path, replacement := range cfg.MaskFields {
+		if p, ok := strings.CutPrefix(path, "claims[]."); ok {
+			if mask.MaskFields == nil {
+				mask.MaskFields = make(map[string]string)
+			}
+			mask.MaskFields[p] = replacement
+		}
+	}
+	return mask
+}
+
+// claimCSVRecord returns the exportCSVHeader columns of a claim encoded as
+// JSON. It reads the encoded claim so that masked fields stay masked.
+func claimCSVRecord(data []byte) []string {
+	var claim map[string]interface{}
+	json.Unmarshal(data, &claim)
+
+	evidences, _ := claim["evidences"].([]interface{})
+	var firstURL string
+	if len(evidences) > 0 {
+		if e, ok := evidences[0].(map[string]interface{}); ok {
+			firstURL = csvValue(e["source_url"])
+		}
+	}
+
+	return []string{
+		csvValue(claim["id"]), csvValue(claim["text"]), csvValue(claim["type"]), csvValue(claim["status"]),
+		csvValue(claim["confidence"]), csvValue(claim["source_type"]), csvValue(claim["reasoning"]),
+		strconv.Itoa(len(evidences)), firstURL,
+	}
+}
+
+// csvValue formats a decoded JSON value as a CSV field; missing values are empty.
+// Strings that a spreadsheet would evaluate as a formula are prefixed with a
+// quote, since claim text and reasoning come from documents and the model.
+func csvValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+			return "'" + v
+		}
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
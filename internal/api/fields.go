@@ -0,0 +1,95 @@
+// Package api provides response field selection.
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// fieldPathPattern restricts field paths to dot-separated JSON keys, each
+// optionally suffixed with [] to make array traversal explicit.
+var fieldPathPattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\[\])?(\.[A-Za-z0-9_]+(\[\])?)*$`)
+
+// FieldSelector trims responses down to the fields a client asked for.
+type FieldSelector struct{}
+
+// fieldTree is a set of selected paths; a key with an empty subtree keeps
+// its whole value.
+type fieldTree map[string]fieldTree
+
+// Prune returns data reduced to the given dot-separated field paths, such
+// as "analysis.overall_score". Arrays are traversed transparently, so
+// "claims.text" keeps the text of every claim. data is converted through
+// JSON first, so the result uses JSON field names.
+func (FieldSelector) Prune(data interface{}, fields []string) interface{} {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return data
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return data
+	}
+
+	tree := make(fieldTree)
+	for _, f := range fields {
+		node := tree
+		for _, key := range strings.Split(f, ".") {
+			key = strings.TrimSuffix(key, "[]")
+			if node[key] == nil {
+				node[key] = make(fieldTree)
+			}
+			node = node[key]
+		}
+	}
+	return pruneValue(v, tree)
+}
+
+func pruneValue(v interface{}, tree fieldTree) interface{} {
+	if len(tree) == 0 {
+		return v
+	}
+	switch v := v.(type) {
+	case []interface{}:
+		for i, item := range v {
+			v[i] = pruneValue(item, tree)
+		}
+		return v
+	case map[string]interface{}:
+		pruned := make(map[string]interface{}, len(tree))
+		for key, sub := range tree {
+			if child, ok := v[key]; ok {
+				pruned[key] = pruneValue(child, sub)
+			}
+		}
+		return pruned
+	default:
+		return v
+	}
+}
+
+// parseFields reads the comma-separated fields query parameter. It writes
+// a 400 and returns false when a path contains anything but keys, dots
+// and [].
+func parseFields(w http.ResponseWriter, r *http.Request) ([]string, bool) {
+	raw := r.URL.Query().Get("fields")
+	if raw == "" {
+		return nil, true
+	}
+
+	var fields []string
+	for _, f := range strings.Split(raw, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if !fieldPathPattern.MatchString(f) {
+			writeError(w, http.StatusBadRequest, "Invalid field path: "+f)
+			return nil, false
+		}
+		fields = append(fields, f)
+	}
+	return fields, true
+}
@@ -2,16 +2,21 @@
 package api
 
 import (
-	"crypto/rand"
-	"crypto/sha256"
-	"encoding/base64"
-	"encoding/hex"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/factchecker/verity/internal/api/versions"
+	"github.com/factchecker/verity/internal/config"
 	"github.com/factchecker/verity/internal/database"
+	"github.com/factchecker/verity/internal/llm"
 	"github.com/factchecker/verity/internal/models"
 	"github.com/factchecker/verity/internal/verify"
 	"github.com/go-chi/chi/v5"
@@ -21,145 +26,1307 @@ import (
 
 // Handler contains all HTTP handlers.
 type Handler struct {
-	engine *verify.Engine
-	store  database.Store
+	engine     *verify.Engine
+	store      database.Store
+	anonymizer *verify.Anonymizer
+	broker     *Broker
+
+	// responseMask is applied to JSON written outside MaskingMiddleware,
+	// such as streamed results.
+	responseMask config.ResponseMaskConfig
+
+	// trends holds score trends for ranges that lie entirely in past
+	// buckets, which only change when claims are updated.
+	trends *trendCache
+}
+
+// NewHandler creates a new handler.
+func NewHandler(engine *verify.Engine, store database.Store) *Handler {
+	h := &Handler{
+		engine:     engine,
+		store:      store,
+		anonymizer: verify.NewAnonymizer(),
+		broker:     NewBroker(),
+		trends:     newTrendCache(),
+	}
+	engine.SetStaleHandler(func(text string, opts verify.VerifyOptions) {
+		// Stale refreshes are not polled, so they have no owner
+		h.startJob(text, opts, webhookTarget{}, "")
+	})
+	return h
+}
+
+// VerifyText handles text verification requests. The body is a JSON
+// VerifyRequest, or an HTML document sent as text/html, in which case
+// claims are also extracted from its tables and lists.
+func (h *Handler) VerifyText(w http.ResponseWriter, r *http.Request) {
+	req, opts, ok := h.parseVerifyRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if r.URL.Query().Get("async") == "true" {
+		var ownerKeyID string
+		if key := getAPIKey(r.Context()); key != nil {
+			ownerKeyID = key.ID
+		}
+		jobID := h.startJob(req.Text, opts, webhookFor(req), ownerKeyID)
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{
+			"job_id":   jobID,
+			"poll_url": "/api/v1/jobs/" + jobID + "/poll",
+		})
+		return
+	}
+
+	result, err := h.engine.VerifyText(r.Context(), req.Text, opts)
+	if err != nil {
+		log.Error().Err(err).Msg("Verification failed")
+		writeError(w, http.StatusInternalServerError, "Verification failed: "+err.Error())
+		return
+	}
+
+	// Cached analyses may carry chain of thought from an earlier explain request
+	if !req.Explain {
+		for i := range result.Claims {
+			result.Claims[i].ChainOfThought = ""
+		}
+	}
+	if r.URL.Query().Get("sort") == "significance" {
+		sortBySignificance(result.Claims)
+	}
+	if result.Stale {
+		w.Header().Set("X-Cache-Status", "stale-while-revalidate")
+	}
+	h.notifyWebhook(webhookFor(req), result)
+
+	writeJSON(w, http.StatusCreated, result)
 }
 
-// NewHandler creates a new handler.
-func NewHandler(engine *verify.Engine, store database.Store) *Handler {
-	return &Handler{
-		engine: engine,
-		store:  store,
+// parseVerifyRequest decodes and validates a verify request, extracting
+// the text of any image, and returns it with its engine options. On
+// failure it writes the error response and returns false.
+func (h *Handler) parseVerifyRequest(w http.ResponseWriter, r *http.Request) (*models.VerifyRequest, verify.VerifyOptions, bool) {
+	var req models.VerifyRequest
+	var structured *verify.StructuredContent
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "text/html") {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body")
+			return nil, verify.VerifyOptions{}, false
+		}
+		structured, err = verify.NewStructuredContentExtractor().Extract(string(body))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid HTML document")
+			return nil, verify.VerifyOptions{}, false
+		}
+		req.Text = structured.Text
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return nil, verify.VerifyOptions{}, false
+	}
+
+	imageURL, err := verify.ImageURL(&req)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, verify.VerifyOptions{}, false
+	}
+	if req.Text == "" && imageURL == "" {
+		writeError(w, http.StatusBadRequest, "Text is required")
+		return nil, verify.VerifyOptions{}, false
+	}
+	if err := validateVerifyOptions(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return nil, verify.VerifyOptions{}, false
+	}
+
+	if imageURL != "" {
+		if !h.engine.SupportsImages() {
+			writeError(w, http.StatusBadRequest, "The configured LLM provider does not support images")
+			return nil, verify.VerifyOptions{}, false
+		}
+		imageText, err := h.engine.ExtractImageText(r.Context(), imageURL)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to extract image text")
+			writeError(w, http.StatusInternalServerError, "Failed to extract image text")
+			return nil, verify.VerifyOptions{}, false
+		}
+		// Text accompanying the image, such as a caption, comes first
+		req.Text = strings.TrimSpace(req.Text + "\n\n" + imageText)
+		if req.Text == "" {
+			writeError(w, http.StatusBadRequest, "No text found in image")
+			return nil, verify.VerifyOptions{}, false
+		}
+	}
+
+	opts := verifyOptions(&req)
+	opts.Structured = structured
+	if req.ModelSource != "" {
+		setAuditDetail(r.Context(), "model_source", req.ModelSource)
+	}
+	return &req, opts, true
+}
+
+// validateVerifyOptions checks the options of a verify request, other than
+// its text and image.
+func validateVerifyOptions(req *models.VerifyRequest) error {
+	if err := verify.ValidateHints(req.FocusHints, req.IgnoreHints); err != nil {
+		return err
+	}
+	if req.Format != "" && req.Format != verify.FormatAbstract {
+		return errors.New("Invalid format (use abstract)")
+	}
+	if len(req.Jurisdiction) > verify.MaxJurisdictionLength {
+		return errors.New("Jurisdiction is too long")
+	}
+	return validateWebhook(req)
+}
+
+// verifyOptions converts a verify request into engine options.
+func verifyOptions(req *models.VerifyRequest) verify.VerifyOptions {
+	return verify.VerifyOptions{
+		EvidenceLanguages: req.EvidenceLanguages,
+		Explain:           req.Explain,
+		FocusHints:        req.FocusHints,
+		IgnoreHints:       req.IgnoreHints,
+		Format:            req.Format,
+		ModelSource:       req.ModelSource,
+		Jurisdiction:      strings.TrimSpace(req.Jurisdiction),
+	}
+}
+
+// maxBatchDocuments bounds the documents in one batch verification request.
+const maxBatchDocuments = 50
+
+// VerifyBatch verifies up to maxBatchDocuments texts concurrently and
+// returns their results in request order. A document that fails has only
+// its error set; the others are unaffected. Images are not supported.
+func (h *Handler) VerifyBatch(w http.ResponseWriter, r *http.Request) {
+	var req models.BatchVerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.Documents) == 0 {
+		writeError(w, http.StatusBadRequest, "Documents are required")
+		return
+	}
+	if len(req.Documents) > maxBatchDocuments {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("At most %d documents are allowed per batch", maxBatchDocuments))
+		return
+	}
+
+	items := make([]verify.BatchItem, len(req.Documents))
+	for i := range req.Documents {
+		doc := &req.Documents[i]
+		if doc.ImageBase64 != "" || doc.ImageURL != "" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Document %d: images are not supported in batch requests", i))
+			return
+		}
+		if doc.Text == "" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Document %d: text is required", i))
+			return
+		}
+		if err := validateVerifyOptions(doc); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("Document %d: %s", i, err.Error()))
+			return
+		}
+		items[i] = verify.BatchItem{Text: doc.Text, Options: verifyOptions(doc)}
+	}
+
+	results := h.engine.VerifyBatch(r.Context(), items)
+	for i, result := range results {
+		if result.Error != "" {
+			log.Error().Str("error", result.Error).Int("document", i).Msg("Batch document verification failed")
+			continue
+		}
+		// Cached analyses may carry chain of thought from an earlier explain request
+		if !req.Documents[i].Explain {
+			for j := range result.Claims {
+				result.Claims[j].ChainOfThought = ""
+			}
+		}
+		h.notifyWebhook(webhookFor(&req.Documents[i]), result)
+	}
+
+	writeJSON(w, http.StatusOK, results)
+}
+
+// auditVerificationJob is the audit log event recorded when a background
+// verification job finishes.
+const auditVerificationJob = "verification.job_finished"
+
+// startJob runs a verification in the background, publishing per-claim
+// progress to the broker and posting the result to hook, and returns the
+// job ID. Only the API key ownerKeyID can poll the job. The tokens the job
+// spends are audited under ownerKeyID once it finishes.
+func (h *Handler) startJob(text string, opts verify.VerifyOptions, hook webhookTarget, ownerKeyID string) string {
+	jobID := uuid.New().String()
+	h.broker.Register(jobID, ownerKeyID)
+
+	opts.OnClaimVerified = func(claim models.Claim) {
+		h.broker.Publish(jobID, JobEvent{Type: EventClaim, Claim: &claim})
+	}
+
+	go func() {
+		start := time.Now()
+		ctx, usage := llm.WithUsageRecorder(context.Background())
+		result, err := h.engine.VerifyText(ctx, text, opts)
+
+		details := map[string]interface{}{"job_id": jobID}
+		if err != nil {
+			details["error"] = err.Error()
+		} else {
+			details["analysis_id"] = result.ID
+		}
+		tokens := usage.Total()
+		entry := &models.AuditLog{
+			ID:               uuid.New().String(),
+			APIKeyID:         ownerKeyID,
+			Endpoint:         auditVerificationJob,
+			DurationMs:       time.Since(start).Milliseconds(),
+			Timestamp:        start,
+			Details:          details,
+			PromptTokens:     tokens.PromptTokens,
+			CompletionTokens: tokens.CompletionTokens,
+		}
+		if err := h.store.LogRequest(context.Background(), entry); err != nil {
+			log.Error().Err(err).Msg("Failed to log audit entry")
+		}
+
+		if err != nil {
+			log.Error().Err(err).Str("job_id", jobID).Msg("Verification job failed")
+			h.broker.Publish(jobID, JobEvent{Type: EventFailed, Error: err.Error()})
+			return
+		}
+		h.broker.Publish(jobID, JobEvent{Type: EventCompleted, AnalysisID: result.ID})
+
+		if hook.URL != "" {
+			// Cached analyses may carry chain of thought from an earlier explain request
+			if !opts.Explain {
+				for i := range result.Claims {
+					result.Claims[i].ChainOfThought = ""
+				}
+			}
+			h.notifyWebhook(hook, result)
+		}
+	}()
+
+	return jobID
+}
+
+// PollJob long-polls for new events on an asynchronous verification job.
+// It returns as soon as events newer than last_event_id exist, or 204 when
+// timeout_secs elapses without any. Jobs started by another API key are
+// reported as not found.
+func (h *Handler) PollJob(w http.ResponseWriter, r *http.Request) {
+	jobID := chi.URLParam(r, "id")
+	if jobID == "" {
+		writeError(w, http.StatusBadRequest, "ID is required")
+		return
+	}
+
+	timeoutSecs, _ := strconv.Atoi(r.URL.Query().Get("timeout_secs"))
+	if timeoutSecs <= 0 || timeoutSecs > 60 {
+		timeoutSecs = 30
+	}
+	lastEventID, _ := strconv.Atoi(r.URL.Query().Get("last_event_id"))
+	var keyID string
+	if key := getAPIKey(r.Context()); key != nil {
+		keyID = key.ID
+	}
+
+	timeout := time.NewTimer(time.Duration(timeoutSecs) * time.Second)
+	defer timeout.Stop()
+
+	for {
+		events, done, notify, ok := h.broker.Events(jobID, keyID, lastEventID)
+		if !ok {
+			writeError(w, http.StatusNotFound, "Job not found")
+			return
+		}
+
+		if len(events) > 0 || done {
+			last := lastEventID
+			if len(events) > 0 {
+				last = events[len(events)-1].ID
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"job_id":        jobID,
+				"events":        events,
+				"last_event_id": last,
+				"done":          done,
+			})
+			return
+		}
+
+		select {
+		case <-notify:
+		case <-timeout.C:
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// GetResult returns a verification result by ID.
+func (h *Handler) GetResult(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "ID is required")
+		return
+	}
+
+	fields, ok := parseFields(w, r)
+	if !ok {
+		return
+	}
+
+	if r.URL.Query().Get("anonymized") == "true" {
+		anonymized, err := h.store.GetAnonymizedResult(r.Context(), id)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to get anonymized result")
+			writeError(w, http.StatusInternalServerError, "Failed to get result")
+			return
+		}
+		if anonymized == nil {
+			writeError(w, http.StatusNotFound, "Anonymized result not found")
+			return
+		}
+		if len(fields) > 0 {
+			w.Header().Set("X-Fields-Filtered", "true")
+			writeJSON(w, http.StatusOK, FieldSelector{}.Prune(anonymized, fields))
+			return
+		}
+		writeJSON(w, http.StatusOK, anonymized)
+		return
+	}
+
+	analysis, err := h.store.GetAnalysis(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get analysis")
+		writeError(w, http.StatusInternalServerError, "Failed to get result")
+		return
+	}
+	if analysis == nil {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
+
+	claims, err := h.store.GetClaimsByAnalysis(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get claims")
+		writeError(w, http.StatusInternalServerError, "Failed to get claims")
+		return
+	}
+
+	// Chain of thought is verbose; only include it on request
+	if r.URL.Query().Get("explain") != "true" {
+		for i := range claims {
+			claims[i].ChainOfThought = ""
+		}
+	}
+	if r.URL.Query().Get("sort") == "significance" {
+		sortBySignificance(claims)
+	}
+
+	contradictions, err := h.store.GetContradictionsByAnalysis(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get contradictions")
+		writeError(w, http.StatusInternalServerError, "Failed to get contradictions")
+		return
+	}
+
+	var response interface{} = models.VerificationResponse{
+		ID:             analysis.ID,
+		DocumentHash:   analysis.DocumentHash,
+		Analysis:       *analysis,
+		Claims:         claims,
+		Contradictions: contradictions,
+	}
+	if len(fields) > 0 {
+		response = FieldSelector{}.Prune(response, fields)
+		w.Header().Set("X-Fields-Filtered", "true")
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// maxReVerifyIntervalHours bounds revery_interval_hours to a year.
+const maxReVerifyIntervalHours = 365 * 24
+
+// UpdateResult changes the settings of an analysis. Currently only
+// revery_interval_hours, which schedules periodic re-verification of its
+// claims; 0 disables it.
+func (h *Handler) UpdateResult(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "ID is required")
+		return
+	}
+
+	var req struct {
+		ReVerifyIntervalHours *int `json:"revery_interval_hours"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.ReVerifyIntervalHours == nil {
+		writeError(w, http.StatusBadRequest, "revery_interval_hours is required")
+		return
+	}
+	if *req.ReVerifyIntervalHours < 0 || *req.ReVerifyIntervalHours > maxReVerifyIntervalHours {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("revery_interval_hours must be between 0 and %d", maxReVerifyIntervalHours))
+		return
+	}
+
+	analysis, err := h.store.GetAnalysis(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get analysis")
+		writeError(w, http.StatusInternalServerError, "Failed to get result")
+		return
+	}
+	if analysis == nil {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
+
+	var every *time.Duration
+	if *req.ReVerifyIntervalHours > 0 {
+		d := time.Duration(*req.ReVerifyIntervalHours) * time.Hour
+		every = &d
+	}
+	if err := h.store.SetReVerifyInterval(r.Context(), id, every); err != nil {
+		log.Error().Err(err).Msg("Failed to update result")
+		writeError(w, http.StatusInternalServerError, "Failed to update result")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":                    id,
+		"revery_interval_hours": *req.ReVerifyIntervalHours,
+		"last_reverified_at":    analysis.LastReVerifiedAt,
+	})
+}
+
+// GetClaimProvenance returns the evidence -> claim -> analysis chain for a claim.
+func (h *Handler) GetClaimProvenance(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "ID is required")
+		return
+	}
+
+	claim, analysisID, err := h.store.GetClaim(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get claim")
+		writeError(w, http.StatusInternalServerError, "Failed to get claim")
+		return
+	}
+	if claim == nil {
+		writeError(w, http.StatusNotFound, "Claim not found")
+		return
+	}
+
+	analysis, err := h.store.GetAnalysis(r.Context(), analysisID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get analysis")
+		writeError(w, http.StatusInternalServerError, "Failed to get analysis")
+		return
+	}
+	if analysis == nil {
+		writeError(w, http.StatusNotFound, "Analysis not found")
+		return
+	}
+
+	provenance := models.ClaimProvenance{
+		ProvenanceVersion: models.ProvenanceVersion,
+		Claim: models.ProvenanceClaim{
+			ID:         claim.ID,
+			Text:       claim.Text,
+			AnalysisID: analysisID,
+		},
+		Evidences: make([]models.ProvenanceEvidence, 0, len(claim.Evidences)),
+		Analysis: models.ProvenanceAnalysis{
+			ID:           analysis.ID,
+			DocumentHash: analysis.DocumentHash,
+			CreatedAt:    analysis.CreatedAt,
+		},
+	}
+	for _, e := range claim.Evidences {
+		// Evidence stored before fetchers were recorded only has its source name
+		fetcher := e.Fetcher
+		if fetcher == "" {
+			fetcher = e.SourceName
+		}
+		provenance.Evidences = append(provenance.Evidences, models.ProvenanceEvidence{
+			URL:           e.SourceURL,
+			FetchedAt:     e.RetrievedAt,
+			Fetcher:       fetcher,
+			SnippetLength: len(e.Snippet),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, provenance)
+}
+
+// sortBySignificance orders claims from most to least significant.
+func sortBySignificance(claims []models.Claim) {
+	sort.SliceStable(claims, func(i, j int) bool {
+		return claims[i].Significance > claims[j].Significance
+	})
+}
+
+// AnonymizeResult creates and stores an anonymized copy of a verification result.
+func (h *Handler) AnonymizeResult(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "ID is required")
+		return
+	}
+
+	analysis, err := h.store.GetAnalysis(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get analysis")
+		writeError(w, http.StatusInternalServerError, "Failed to get result")
+		return
+	}
+	if analysis == nil {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
+
+	claims, err := h.store.GetClaimsByAnalysis(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get claims")
+		writeError(w, http.StatusInternalServerError, "Failed to get claims")
+		return
+	}
+
+	anonymized := h.anonymizer.Anonymize(&models.VerificationResponse{
+		ID:           analysis.ID,
+		DocumentHash: analysis.DocumentHash,
+		Analysis:     *analysis,
+		Claims:       claims,
+	})
+
+	if err := h.store.SaveAnonymizedResult(r.Context(), id, anonymized); err != nil {
+		log.Error().Err(err).Msg("Failed to save anonymized result")
+		writeError(w, http.StatusInternalServerError, "Failed to save anonymized result")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, anonymized)
+}
+
+// RerunResult runs the full pipeline again on the original text of an
+// analysis, with its model source and jurisdiction, bypassing the document
+// hash cache. The new analysis is saved alongside the old one, which later
+// cache lookups no longer return.
+func (h *Handler) RerunResult(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "ID is required")
+		return
+	}
+
+	analysis, err := h.store.GetAnalysis(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get analysis")
+		writeError(w, http.StatusInternalServerError, "Failed to get result")
+		return
+	}
+	if analysis == nil {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
+
+	text, err := h.store.GetDocumentByHash(r.Context(), analysis.DocumentHash)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get document")
+		writeError(w, http.StatusInternalServerError, "Failed to get document")
+		return
+	}
+	if text == "" {
+		writeError(w, http.StatusConflict, "Original document text is not stored for this result")
+		return
+	}
+
+	opts := verify.AnalysisOptions(analysis)
+	opts.ForceRefresh = true
+	result, err := h.engine.VerifyText(r.Context(), text, opts)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Rerun failed")
+		writeError(w, http.StatusInternalServerError, "Verification failed: "+err.Error())
+		return
+	}
+
+	log.Info().Str("id", id).Str("new_id", result.ID).Msg("Analysis rerun")
+	writeJSON(w, http.StatusCreated, result)
+}
+
+// HighlightResult returns the original document with the sentence each
+// claim was extracted from marked up by verification status.
+func (h *Handler) HighlightResult(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "ID is required")
+		return
+	}
+
+	var req struct {
+		Format string `json:"format"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Format != "html" {
+		writeError(w, http.StatusBadRequest, "Unsupported format, must be html")
+		return
+	}
+
+	analysis, err := h.store.GetAnalysis(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get analysis")
+		writeError(w, http.StatusInternalServerError, "Failed to get result")
+		return
+	}
+	if analysis == nil {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
+
+	text, err := h.store.GetDocumentByHash(r.Context(), analysis.DocumentHash)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get document")
+		writeError(w, http.StatusInternalServerError, "Failed to get document")
+		return
+	}
+	if text == "" {
+		writeError(w, http.StatusConflict, "Original document text is not stored for this result")
+		return
+	}
+
+	claims, err := h.store.GetClaimsByAnalysis(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get claims")
+		writeError(w, http.StatusInternalServerError, "Failed to get claims")
+		return
+	}
+
+	highlighted := verify.HighlightHTML(text, claims)
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":        id,
+		"format":    req.Format,
+		"content":   highlighted.HTML,
+		"matched":   highlighted.Matched,
+		"unmatched": highlighted.Unmatched,
+	})
+}
+
+// ListResults returns paginated verification results, optionally only those
+// of text disclosed as generated by model_source. Pass the returned
+// next_cursor as cursor to fetch the following page; offset is deprecated.
+func (h *Handler) ListResults(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	page, ok := parsePage(w, r)
+	if !ok {
+		return
+	}
+	fields, ok := parseFields(w, r)
+	if !ok {
+		return
+	}
+
+	filter := database.AnalysisFilter{ModelSource: r.URL.Query().Get("model_source")}
+	results, err := h.store.ListAnalyses(r.Context(), filter, limit, page.offset, page.cursor)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list results")
+		writeError(w, http.StatusInternalServerError, "Failed to list results")
+		return
+	}
+
+	response := map[string]interface{}{
+		"results": results,
+		"limit":   limit,
+	}
+	if len(results) == limit {
+		last := results[len(results)-1]
+		response["next_cursor"] = database.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	page.annotate(response)
+
+	// Fields apply to each result; pagination fields are always returned
+	if len(fields) > 0 {
+		response["results"] = FieldSelector{}.Prune(results, fields)
+		w.Header().Set("X-Fields-Filtered", "true")
 	}
+
+	writeJSON(w, http.StatusOK, response)
 }
 
-// HealthCheck returns the service health status.
-func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	response := map[string]interface{}{
-		"status":    "healthy",
-		"version":   "1.0.0",
-		"timestamp": time.Now().UTC().Format(time.RFC3339),
+// pageParams holds the pagination mode requested by a list endpoint.
+type pageParams struct {
+	cursor  *database.Cursor
+	offset  int
+	legacy  bool // offset was given
+	ignored bool // cursor was given alongside offset and ignored
+}
+
+// parsePage reads cursor and offset query parameters. When both are present
+// offset wins for backward compatibility. It writes a 400 and returns false
+// for a malformed cursor.
+func parsePage(w http.ResponseWriter, r *http.Request) (pageParams, bool) {
+	var p pageParams
+	cursor := r.URL.Query().Get("cursor")
+	if v := r.URL.Query().Get("offset"); v != "" {
+		p.legacy = true
+		p.offset, _ = strconv.Atoi(v)
+		if p.offset < 0 {
+			p.offset = 0
+		}
+		p.ignored = cursor != ""
+		return p, true
 	}
-	writeJSON(w, http.StatusOK, response)
+	if cursor != "" {
+		c, err := database.DecodeCursor(cursor)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid cursor")
+			return p, false
+		}
+		p.cursor = c
+	}
+	return p, true
 }
 
-// VerifyText handles text verification requests.
-func (h *Handler) VerifyText(w http.ResponseWriter, r *http.Request) {
-	var req models.VerifyRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "Invalid request body")
+// annotate adds offset and deprecation details to a list response.
+func (p pageParams) annotate(response map[string]interface{}) {
+	if !p.legacy {
 		return
 	}
+	response["offset"] = p.offset
+	warning := "offset pagination is deprecated; use cursor with next_cursor instead"
+	if p.ignored {
+		warning = "cursor ignored because offset was provided; " + warning
+	}
+	response["warning"] = warning
+}
 
-	if req.Text == "" {
-		writeError(w, http.StatusBadRequest, "Text is required")
+// GetScoreTrend returns analysis score statistics bucketed by hour, day,
+// week or month. since and until are inclusive dates (YYYY-MM-DD) and
+// default to the last 30 days.
+func (h *Handler) GetScoreTrend(w http.ResponseWriter, r *http.Request) {
+	granularity := r.URL.Query().Get("granularity")
+	if granularity == "" {
+		granularity = "day"
+	}
+	switch granularity {
+	case "hour", "day", "week", "month":
+	default:
+		writeError(w, http.StatusBadRequest, "Invalid granularity (use hour, day, week or month)")
 		return
 	}
 
-	result, err := h.engine.VerifyText(r.Context(), req.Text)
+	now := time.Now().UTC()
+	since := now.AddDate(0, 0, -30).Truncate(24 * time.Hour)
+	until := now
+	if s := r.URL.Query().Get("since"); s != "" {
+		t, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid since parameter")
+			return
+		}
+		since = t
+	}
+	if s := r.URL.Query().Get("until"); s != "" {
+		t, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid until parameter")
+			return
+		}
+		until = t.AddDate(0, 0, 1)
+	}
+
+	cacheKey := granularity + "|" + since.Format(time.RFC3339) + "|" + until.Format(time.RFC3339)
+	cacheable := !until.After(bucketStart(now, granularity))
+	if cacheable {
+		if cached, ok := h.trends.get(cacheKey); ok {
+			writeJSON(w, http.StatusOK, cached)
+			return
+		}
+	}
+
+	points, err := h.store.GetScoreTrend(r.Context(), granularity, since, until)
 	if err != nil {
-		log.Error().Err(err).Msg("Verification failed")
-		writeError(w, http.StatusInternalServerError, "Verification failed: "+err.Error())
+		log.Error().Err(err).Msg("Failed to get score trend")
+		writeError(w, http.StatusInternalServerError, "Failed to get score trend")
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, result)
+	var total int
+	var scoreSum float64
+	for _, p := range points {
+		total += p.Count
+		scoreSum += p.AvgScore * float64(p.Count)
+	}
+	var overallAvg float64
+	if total > 0 {
+		overallAvg = scoreSum / float64(total)
+	}
+	if points == nil {
+		points = []*models.ScoreTrendPoint{}
+	}
+
+	response := map[string]interface{}{
+		"points":         points,
+		"total_analyses": total,
+		"overall_avg":    overallAvg,
+	}
+	if cacheable {
+		h.trends.add(cacheKey, response)
+	}
+
+	writeJSON(w, http.StatusOK, response)
 }
 
-// GetResult returns a verification result by ID.
-func (h *Handler) GetResult(w http.ResponseWriter, r *http.Request) {
-	id := chi.URLParam(r, "id")
-	if id == "" {
-		writeError(w, http.StatusBadRequest, "ID is required")
-		return
+// bucketStart returns the start of the UTC bucket containing t.
+func bucketStart(t time.Time, granularity string) time.Time {
+	t = t.UTC()
+	switch granularity {
+	case "hour":
+		return t.Truncate(time.Hour)
+	case "week":
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
 	}
+}
 
-	analysis, err := h.store.GetAnalysis(r.Context(), id)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to get analysis")
-		writeError(w, http.StatusInternalServerError, "Failed to get result")
+// maxAuditRange is the longest time range an audit log search may cover.
+const maxAuditRange = 90 * 24 * time.Hour
+
+// GetAuditLogs returns paginated audit logs. Pass the returned next_cursor
+// as cursor to fetch the following page; offset is deprecated. Logs can be
+// filtered by api_key_id, endpoint, method, status_code, min_duration_ms
+// and a since/until range (YYYY-MM-DD or RFC 3339) of at most 90 days,
+// by default the last 90 days; total_count is the number of matching logs.
+func (h *Handler) GetAuditLogs(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	page, ok := parsePage(w, r)
+	if !ok {
 		return
 	}
-	if analysis == nil {
-		writeError(w, http.StatusNotFound, "Result not found")
+	filter, ok := parseAuditFilter(w, r)
+	if !ok {
 		return
 	}
 
-	claims, err := h.store.GetClaimsByAnalysis(r.Context(), id)
+	logs, total, err := h.store.GetAuditLogs(r.Context(), filter, limit, page.offset, page.cursor)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to get claims")
-		writeError(w, http.StatusInternalServerError, "Failed to get claims")
+		log.Error().Err(err).Msg("Failed to get audit logs")
+		writeError(w, http.StatusInternalServerError, "Failed to get audit logs")
 		return
 	}
 
-	response := models.VerificationResponse{
-		ID:           analysis.ID,
-		DocumentHash: analysis.DocumentHash,
-		Analysis:     *analysis,
-		Claims:       claims,
+	response := map[string]interface{}{
+		"logs":        logs,
+		"limit":       limit,
+		"total_count": total,
+	}
+	if len(logs) == limit {
+		last := logs[len(logs)-1]
+		response["next_cursor"] = database.Cursor{CreatedAt: last.Timestamp, ID: last.ID}.Encode()
 	}
+	page.annotate(response)
 
 	writeJSON(w, http.StatusOK, response)
 }
 
-// ListResults returns paginated verification results.
-func (h *Handler) ListResults(w http.ResponseWriter, r *http.Request) {
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
-	if limit <= 0 || limit > 100 {
-		limit = 20
+// parseAuditFilter reads audit log filters from the query string. Without
+// since or until the range is the last 90 days; a range with only since ends
+// now, and one with only until starts 90 days earlier.
+// It writes a 400 and returns false for invalid values or a range longer
+// than maxAuditRange.
+func parseAuditFilter(w http.ResponseWriter, r *http.Request) (database.AuditFilter, bool) {
+	q := r.URL.Query()
+	filter := database.AuditFilter{
+		APIKeyID: q.Get("api_key_id"),
+		Endpoint: q.Get("endpoint"),
+		Method:   strings.ToUpper(q.Get("method")),
+	}
+	if v := q.Get("status_code"); v != "" {
+		code, err := strconv.Atoi(v)
+		if err != nil || code < 100 || code > 599 {
+			writeError(w, http.StatusBadRequest, "Invalid status_code parameter")
+			return filter, false
+		}
+		filter.StatusCode = code
+	}
+	if v := q.Get("min_duration_ms"); v != "" {
+		ms, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || ms < 0 {
+			writeError(w, http.StatusBadRequest, "Invalid min_duration_ms parameter")
+			return filter, false
+		}
+		filter.MinDurationMs = ms
 	}
 
-	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-	if offset < 0 {
-		offset = 0
+	since, until := q.Get("since"), q.Get("until")
+	filter.Until = time.Now()
+	if until != "" {
+		t, err := time.Parse(time.RFC3339, until)
+		if err != nil {
+			// A date includes the whole day
+			t, err = time.Parse("2006-01-02", until)
+			t = t.AddDate(0, 0, 1)
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid until parameter")
+			return filter, false
+		}
+		filter.Until = t
+	}
+	filter.Since = filter.Until.Add(-maxAuditRange)
+	if since != "" {
+		t, err := time.Parse("2006-01-02", since)
+		if err != nil {
+			t, err = time.Parse(time.RFC3339, since)
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid since parameter")
+			return filter, false
+		}
+		filter.Since = t
+	}
+	if !filter.Since.Before(filter.Until) {
+		writeError(w, http.StatusBadRequest, "since must be before until")
+		return filter, false
+	}
+	if filter.Until.Sub(filter.Since) > maxAuditRange {
+		writeError(w, http.StatusBadRequest, "Date range must not exceed 90 days")
+		return filter, false
+	}
+	return filter, true
+}
+
+// VerifyAuditChain checks the integrity of the audit log hash chain.
+func (h *Handler) VerifyAuditChain(w http.ResponseWriter, r *http.Request) {
+	var since time.Time
+	if v := r.URL.Query().Get("since"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			t, err = time.Parse(time.RFC3339, v)
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid since parameter")
+			return
+		}
+		since = t
 	}
 
-	results, err := h.store.ListAnalyses(r.Context(), limit, offset)
+	valid, brokenID, err := h.store.VerifyAuditChain(r.Context(), since)
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to list results")
-		writeError(w, http.StatusInternalServerError, "Failed to list results")
+		log.Error().Err(err).Msg("Failed to verify audit chain")
+		writeError(w, http.StatusInternalServerError, "Failed to verify audit chain")
+		return
+	}
+
+	if !valid {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"valid":     false,
+			"broken_at": brokenID,
+		})
 		return
 	}
 
 	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"results": results,
-		"limit":   limit,
-		"offset":  offset,
+		"valid": true,
 	})
 }
 
-// GetAuditLogs returns paginated audit logs.
-func (h *Handler) GetAuditLogs(w http.ResponseWriter, r *http.Request) {
+// SearchClaims finds stored claims whose text or reasoning matches q, best
+// match first. Each claim includes its evidence and the ID of its analysis.
+func (h *Handler) SearchClaims(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		writeError(w, http.StatusBadRequest, "Query is required")
+		return
+	}
 	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
 	if limit <= 0 || limit > 100 {
-		limit = 50
+		limit = 20
 	}
-
 	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
 	if offset < 0 {
 		offset = 0
 	}
 
-	logs, err := h.store.GetAuditLogs(r.Context(), limit, offset)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to get audit logs")
-		writeError(w, http.StatusInternalServerError, "Failed to get audit logs")
+	claims, err := h.store.SearchClaims(r.Context(), query, limit, offset)
+	switch {
+	case errors.Is(err, database.ErrInvalidSearchQuery):
+		writeError(w, http.StatusBadRequest, "Invalid search query")
+		return
+	case errors.Is(err, database.ErrSearchUnavailable):
+		writeError(w, http.StatusNotImplemented, "Claim search is not available: SQLite was built without FTS5")
+		return
+	case err != nil:
+		log.Error().Err(err).Msg("Failed to search claims")
+		writeError(w, http.StatusInternalServerError, "Failed to search claims")
 		return
 	}
 
 	writeJSON(w, http.StatusOK, map[string]interface{}{
-		"logs":   logs,
+		"claims": claims,
 		"limit":  limit,
 		"offset": offset,
 	})
 }
 
+// GetEvidenceQuality returns evidence usefulness statistics by domain and source type.
+func (h *Handler) GetEvidenceQuality(w http.ResponseWriter, r *http.Request) {
+	domain := r.URL.Query().Get("domain")
+
+	stats, err := h.store.GetEvidenceQualityStats(r.Context(), domain)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get evidence quality stats")
+		writeError(w, http.StatusInternalServerError, "Failed to get evidence quality stats")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"stats": stats,
+	})
+}
+
+// BulkUpdateClaims applies reviewer corrections to several claims at once.
+func (h *Handler) BulkUpdateClaims(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Updates []models.ClaimUpdate `json:"updates"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.Updates) == 0 {
+		writeError(w, http.StatusBadRequest, "Updates are required")
+		return
+	}
+
+	for _, u := range req.Updates {
+		if u.ID == "" {
+			writeError(w, http.StatusBadRequest, "Claim ID is required")
+			return
+		}
+		switch u.Status {
+		case models.StatusVerified, models.StatusMixed, models.StatusUnsupported:
+		default:
+			writeError(w, http.StatusBadRequest, "Invalid status for claim "+u.ID)
+			return
+		}
+		if u.Confidence != nil && (*u.Confidence < 0 || *u.Confidence > 1) {
+			writeError(w, http.StatusBadRequest, "Confidence must be between 0 and 1 for claim "+u.ID)
+			return
+		}
+	}
+
+	updated, recomputed, err := h.store.BulkUpdateClaims(r.Context(), req.Updates)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to update claims")
+		writeError(w, http.StatusInternalServerError, "Failed to update claims")
+		return
+	}
+	if recomputed > 0 {
+		h.trends.clear()
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"updated":             updated,
+		"analyses_recomputed": recomputed,
+	})
+}
+
+// MigrateClaimType renames a claim type across stored claims, for example
+// when a custom type replaces a built-in one. With ?dry_run=true it only
+// reports how many claims would change.
+func (h *Handler) MigrateClaimType(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		FromType models.ClaimType `json:"from_type"`
+		ToType   models.ClaimType `json:"to_type"`
+		Filter   struct {
+			AnalysisIDs []string `json:"analysis_ids"`
+		} `json:"filter"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.FromType == "" || req.ToType == "" {
+		writeError(w, http.StatusBadRequest, "from_type and to_type are required")
+		return
+	}
+	if req.FromType == req.ToType {
+		writeError(w, http.StatusBadRequest, "from_type and to_type must differ")
+		return
+	}
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+
+	start := time.Now()
+	count, err := h.store.MigrateClaimType(r.Context(), req.FromType, req.ToType, req.Filter.AnalysisIDs, dryRun)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to migrate claim type")
+		writeError(w, http.StatusInternalServerError, "Failed to migrate claim type")
+		return
+	}
+
+	if !dryRun {
+		apiKeyID := ""
+		if key := getAPIKey(r.Context()); key != nil {
+			apiKeyID = key.ID
+		}
+		entry := &models.AuditLog{
+			ID:           uuid.New().String(),
+			APIKeyID:     apiKeyID,
+			Endpoint:     r.URL.Path,
+			Method:       r.Method,
+			RequestSize:  r.ContentLength,
+			ResponseCode: http.StatusOK,
+			DurationMs:   time.Since(start).Milliseconds(),
+			Timestamp:    start,
+			Details: map[string]interface{}{
+				"from_type":      req.FromType,
+				"to_type":        req.ToType,
+				"analysis_ids":   req.Filter.AnalysisIDs,
+				"migrated_count": count,
+			},
+		}
+		if err := h.store.LogRequest(r.Context(), entry); err != nil {
+			log.Error().Err(err).Msg("Failed to log audit entry")
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"from_type":      req.FromType,
+		"to_type":        req.ToType,
+		"dry_run":        dryRun,
+		"migrated_count": count,
+	})
+}
+
+// ReExtractClaims re-runs claim extraction on the original text of an
+// analysis with the current prompts and returns a summary of the changes.
+func (h *Handler) ReExtractClaims(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "ID is required")
+		return
+	}
+
+	analysis, err := h.store.GetAnalysis(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get analysis")
+		writeError(w, http.StatusInternalServerError, "Failed to get analysis")
+		return
+	}
+	if analysis == nil {
+		writeError(w, http.StatusNotFound, "Analysis not found")
+		return
+	}
+
+	text, err := h.store.GetDocumentByHash(r.Context(), analysis.DocumentHash)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get document")
+		writeError(w, http.StatusInternalServerError, "Failed to get document")
+		return
+	}
+	if text == "" {
+		writeError(w, http.StatusConflict, "Original document text is not stored for this analysis")
+		return
+	}
+
+	summary, err := h.engine.ReExtract(r.Context(), analysis, text)
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to re-extract claims")
+		writeError(w, http.StatusInternalServerError, "Failed to re-extract claims")
+		return
+	}
+	h.trends.clear()
+
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// CompareProviders re-verifies a claim with every configured consensus
+// provider, using its stored evidence, and records whether they agree.
+func (h *Handler) CompareProviders(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "ID is required")
+		return
+	}
+
+	claim, analysisID, err := h.store.GetClaim(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get claim")
+		writeError(w, http.StatusInternalServerError, "Failed to get claim")
+		return
+	}
+	if claim == nil {
+		writeError(w, http.StatusNotFound, "Claim not found")
+		return
+	}
+
+	jurisdiction := ""
+	analysis, err := h.store.GetAnalysis(r.Context(), analysisID)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get analysis")
+		writeError(w, http.StatusInternalServerError, "Failed to get analysis")
+		return
+	}
+	if analysis != nil {
+		jurisdiction = analysis.Jurisdiction
+	}
+
+	comparison, err := h.engine.CompareProviders(r.Context(), *claim, jurisdiction)
+	if errors.Is(err, verify.ErrNoConsensusProviders) {
+		writeError(w, http.StatusConflict, "No consensus providers are configured")
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Str("id", id).Msg("Failed to compare providers")
+		writeError(w, http.StatusInternalServerError, "Failed to compare providers")
+		return
+	}
+
+	if err := h.store.SaveProviderComparison(r.Context(), comparison); err != nil {
+		log.Error().Err(err).Msg("Failed to save provider comparison")
+		writeError(w, http.StatusInternalServerError, "Failed to save provider comparison")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, comparison)
+}
+
 // CreateAPIKey creates a new API key.
 func (h *Handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		Name              string `json:"name"`
 		RequestsPerMinute int    `json:"requests_per_minute"`
 		TokensPerDay      int    `json:"tokens_per_day"`
+		ExpiresInDays     int    `json:"expires_in_days"` // 0 never expires
+		Role              string `json:"role"`            // defaults to reader
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "Invalid request body")
@@ -170,35 +1337,29 @@ func (h *Handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "Name is required")
 		return
 	}
-
-	// Generate random API key
-	keyBytes := make([]byte, 32)
-	if _, err := rand.Read(keyBytes); err != nil {
-		writeError(w, http.StatusInternalServerError, "Failed to generate key")
+	if req.ExpiresInDays < 0 {
+		writeError(w, http.StatusBadRequest, "expires_in_days must not be negative")
 		return
 	}
-	rawKey := "vrt_" + base64.URLEncoding.EncodeToString(keyBytes)
-
-	// Hash for storage
-	hash := sha256.Sum256([]byte(rawKey))
-	keyHash := hex.EncodeToString(hash[:])
-
-	// Set defaults
-	if req.RequestsPerMinute <= 0 {
-		req.RequestsPerMinute = 60
-	}
-	if req.TokensPerDay <= 0 {
-		req.TokensPerDay = 100000
+	switch req.Role {
+	case "":
+		req.Role = models.RoleReader
+	case models.RoleAdmin, models.RoleReader:
+	default:
+		writeError(w, http.StatusBadRequest, "role must be admin or reader")
+		return
 	}
 
-	apiKey := &models.APIKey{
-		ID:                uuid.New().String(),
-		KeyHash:           keyHash,
-		Name:              req.Name,
-		RequestsPerMinute: req.RequestsPerMinute,
-		TokensPerDay:      req.TokensPerDay,
-		CreatedAt:         time.Now(),
+	apiKey, rawKey, err := database.NewAPIKey(req.Name, req.RequestsPerMinute, req.TokensPerDay)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to generate key")
+		return
 	}
+	if req.ExpiresInDays > 0 {
+		expiresAt := apiKey.CreatedAt.AddDate(0, 0, req.ExpiresInDays)
+		apiKey.ExpiresAt = &expiresAt
+	}
+	apiKey.Role = req.Role
 
 	if err := h.store.CreateAPIKey(r.Context(), apiKey); err != nil {
 		log.Error().Err(err).Msg("Failed to create API key")
@@ -214,6 +1375,8 @@ func (h *Handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
 		"requests_per_minute": apiKey.RequestsPerMinute,
 		"tokens_per_day":      apiKey.TokensPerDay,
 		"created_at":          apiKey.CreatedAt,
+		"expires_at":          apiKey.ExpiresAt,
+		"role":                apiKey.Role,
 	})
 }
 
@@ -248,9 +1411,92 @@ func (h *Handler) DeleteAPIKey(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ListConfigOverrides lists the settings overridden at runtime.
+func (h *Handler) ListConfigOverrides(w http.ResponseWriter, r *http.Request) {
+	overrides, err := h.store.ListConfigOverrides(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list config overrides")
+		writeError(w, http.StatusInternalServerError, "Failed to list config overrides")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"overrides": overrides,
+	})
+}
+
+// GetConfigOverride returns the runtime override of a setting.
+func (h *Handler) GetConfigOverride(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "key")
+	value, ok, err := h.store.GetConfigOverride(r.Context(), key)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get config override")
+		writeError(w, http.StatusInternalServerError, "Failed to get config override")
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "Config override not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"key": key, "value": value})
+}
+
+// SetConfigOverride overrides a setting at runtime. Verifications pick up
+// the new value within 30 seconds, without a restart.
+func (h *Handler) SetConfigOverride(w http.ResponseWriter, r *http.Request) {
+	key := chi.URLParam(r, "key")
+	var req struct {
+		Value string `json:"value"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := verify.ValidateOverride(key, req.Value); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	apiKeyID := ""
+	if k := getAPIKey(r.Context()); k != nil {
+		apiKeyID = k.ID
+	}
+	if err := h.store.SetConfigOverride(r.Context(), key, req.Value, apiKeyID); err != nil {
+		log.Error().Err(err).Msg("Failed to set config override")
+		writeError(w, http.StatusInternalServerError, "Failed to set config override")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"key": key, "value": req.Value})
+}
+
+// DeleteConfigOverride removes the runtime override of a setting, restoring
+// the configured value.
+func (h *Handler) DeleteConfigOverride(w http.ResponseWriter, r *http.Request) {
+	if err := h.store.DeleteConfigOverride(r.Context(), chi.URLParam(r, "key")); err != nil {
+		log.Error().Err(err).Msg("Failed to delete config override")
+		writeError(w, http.StatusInternalServerError, "Failed to delete config override")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // Helper functions
 func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
+	if adapter := responseAdapter(w); adapter != nil && adapter != versions.Latest {
+		body, err := adapter.Marshal(data)
+		if err != nil {
+			log.Error().Err(err).Str("version", adapter.Version).Msg("Failed to encode versioned response")
+			writeError(w, http.StatusInternalServerError, "Failed to encode response")
+			return
+		}
+		w.WriteHeader(status)
+		w.Write(append(body, '\n'))
+		return
+	}
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(data)
 }
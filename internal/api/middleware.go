@@ -2,16 +2,26 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/factchecker/verity/internal/api/versions"
+	"github.com/factchecker/verity/internal/config"
 	"github.com/factchecker/verity/internal/database"
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/metrics"
 	"github.com/factchecker/verity/internal/models"
-	"github.com/go-chi/httprate"
+	"github.com/factchecker/verity/internal/ratelimit"
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 )
@@ -21,18 +31,13 @@ type contextKey string
 const (
 	apiKeyContextKey contextKey = "apiKey"
 	requestIDKey     contextKey = "requestID"
+	auditDetailsKey  contextKey = "auditDetails"
 )
 
 // AuthMiddleware validates API keys.
 func AuthMiddleware(store database.Store) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Skip auth for health endpoint
-			if r.URL.Path == "/api/v1/health" {
-				next.ServeHTTP(w, r)
-				return
-			}
-
 			// Get API key from header
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
@@ -64,6 +69,14 @@ func AuthMiddleware(store database.Store) func(http.Handler) http.Handler {
 				http.Error(w, `{"error": "Invalid API key"}`, http.StatusUnauthorized)
 				return
 			}
+			if key.ExpiresAt != nil && time.Now().After(*key.ExpiresAt) {
+				http.Error(w, `{"error": "API key expired"}`, http.StatusUnauthorized)
+				return
+			}
+			if key.Disabled {
+				http.Error(w, `{"error": "API key disabled"}`, http.StatusUnauthorized)
+				return
+			}
 
 			// Update last used
 			go func() {
@@ -77,6 +90,58 @@ func AuthMiddleware(store database.Store) func(http.Handler) http.Handler {
 	}
 }
 
+// RBACMiddleware rejects requests whose API key lacks role with 403. Admin
+// keys are allowed everywhere. It must run after AuthMiddleware.
+func RBACMiddleware(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := getAPIKey(r.Context())
+			if key == nil {
+				http.Error(w, `{"error": "Missing API key"}`, http.StatusUnauthorized)
+				return
+			}
+			if key.Role != models.RoleAdmin && key.Role != role {
+				http.Error(w, fmt.Sprintf(`{"error": "API key role %q may not access this endpoint"}`, key.Role), http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// disableExpiredAPIKeys disables every API key past its expiry. Expired
+// keys are rejected by AuthMiddleware regardless; disabling them also
+// shows their state in key listings.
+func disableExpiredAPIKeys(ctx context.Context, store database.Store) {
+	keys, err := store.GetExpiredAPIKeys(ctx, time.Now())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list expired API keys")
+		return
+	}
+	for _, key := range keys {
+		if err := store.DisableAPIKey(ctx, key.ID); err != nil {
+			log.Error().Err(err).Str("id", key.ID).Msg("Failed to disable expired API key")
+			continue
+		}
+		log.Info().Str("id", key.ID).Str("name", key.Name).Time("expired_at", *key.ExpiresAt).Msg("Disabled expired API key")
+	}
+}
+
+// ProviderHealthMiddleware rejects requests with 503 while the LLM provider
+// is unhealthy, rather than starting work that is bound to fail.
+func ProviderHealthMiddleware(monitor *llm.ProviderHealthMonitor) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !monitor.Healthy() {
+				w.Header().Set("Retry-After", strconv.Itoa(int(llm.DefaultProbeInterval.Seconds())))
+				http.Error(w, `{"error": "LLM provider is unavailable, try again later"}`, http.StatusServiceUnavailable)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // RequestIDMiddleware adds a unique request ID to each request.
 func RequestIDMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -90,6 +155,53 @@ func RequestIDMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// ResponseVersionMiddleware selects the response schema from the
+// Accept-Version header (YYYY-MM-DD or YYYY-MM), defaulting to the latest
+// version. The version travels with the response writer, since writeJSON
+// has no request, and writeJSON encodes responses with its adapter.
+// Unknown versions are rejected.
+func ResponseVersionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		adapter := versions.Latest
+		if v := r.Header.Get("Accept-Version"); v != "" {
+			a, ok := versions.Lookup(v)
+			if !ok {
+				writeError(w, http.StatusBadRequest,
+					"Unsupported Accept-Version (use one of "+strings.Join(versions.Supported(), ", ")+")")
+				return
+			}
+			adapter = a
+		}
+		w.Header().Set("API-Version", adapter.Version)
+		next.ServeHTTP(&versionWriter{ResponseWriter: w, adapter: adapter}, r)
+	})
+}
+
+// versionWriter carries the response version to writeJSON.
+type versionWriter struct {
+	http.ResponseWriter
+	adapter *versions.Adapter
+}
+
+func (vw *versionWriter) Unwrap() http.ResponseWriter {
+	return vw.ResponseWriter
+}
+
+// responseAdapter returns the version adapter of w, looking through
+// wrapping writers, or nil outside ResponseVersionMiddleware.
+func responseAdapter(w http.ResponseWriter) *versions.Adapter {
+	for {
+		switch rw := w.(type) {
+		case *versionWriter:
+			return rw.adapter
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = rw.Unwrap()
+		default:
+			return nil
+		}
+	}
+}
+
 // LoggingMiddleware logs all requests.
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -112,12 +224,36 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// MetricsMiddleware records request counts and latency. Requests are
+// labelled with their route pattern rather than the raw path, so IDs in
+// URLs do not create a series each.
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		wrapped := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(wrapped, r)
+
+		path := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			path = rctx.RoutePattern()
+		}
+		metrics.HTTPRequests.WithLabelValues(r.Method, path, strconv.Itoa(wrapped.status)).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(r.Method, path).Observe(time.Since(start).Seconds())
+	})
+}
+
 // AuditMiddleware logs API requests to the database.
 func AuditMiddleware(store database.Store) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			start := time.Now()
 
+			// Handlers add request details with setAuditDetail
+			details := make(map[string]interface{})
+			ctx, usage := llm.WithUsageRecorder(context.WithValue(r.Context(), auditDetailsKey, details))
+			r = r.WithContext(ctx)
+
 			wrapped := &responseWriter{ResponseWriter: w, status: http.StatusOK}
 			next.ServeHTTP(wrapped, r)
 
@@ -141,6 +277,12 @@ func AuditMiddleware(store database.Store) func(http.Handler) http.Handler {
 					DurationMs:   duration.Milliseconds(),
 					Timestamp:    start,
 				}
+				if len(details) > 0 {
+					auditLog.Details = details
+				}
+				tokens := usage.Total()
+				auditLog.PromptTokens = tokens.PromptTokens
+				auditLog.CompletionTokens = tokens.CompletionTokens
 				if err := store.LogRequest(context.Background(), auditLog); err != nil {
 					log.Error().Err(err).Msg("Failed to log audit entry")
 				}
@@ -149,38 +291,238 @@ func AuditMiddleware(store database.Store) func(http.Handler) http.Handler {
 	}
 }
 
-// RateLimitMiddleware applies per-key rate limiting.
+// RateLimitInfo describes the caller's quota after the current request.
+type RateLimitInfo struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+	Throttled bool // Limit reduced because the server is under load
+}
+
+// setHeaders writes the standard X-RateLimit-* headers, and
+// X-Verity-Throttled when the limit was reduced under load.
+func (info *RateLimitInfo) setHeaders(h http.Header) {
+	h.Set("X-RateLimit-Limit", strconv.Itoa(info.Limit))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(info.Remaining))
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(info.Reset.Unix(), 10))
+	if info.Throttled {
+		h.Set("X-Verity-Throttled", "true")
+	}
+}
+
+// RateLimitMiddleware applies per-key token bucket rate limiting. Each key is
+// limited to its own RequestsPerMinute, falling back to defaultLimit, and
+// every response carries X-RateLimit-* headers. All limits are halved while
+// the server is under heavy load.
 func RateLimitMiddleware(defaultLimit int) func(http.Handler) http.Handler {
-	// Use httprate with custom key function
-	limiter := httprate.Limit(
-		defaultLimit,
-		time.Minute,
-		httprate.WithKeyFuncs(func(r *http.Request) (string, error) {
+	limiter := ratelimit.NewLoadAdaptiveRateLimiter(time.Minute)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientKey := r.RemoteAddr
+			limit := defaultLimit
 			if key := getAPIKey(r.Context()); key != nil {
-				return key.ID, nil
+				clientKey = key.ID
+				if key.RequestsPerMinute > 0 {
+					limit = key.RequestsPerMinute
+				}
+			}
+
+			state, allowed, throttled := limiter.Take(clientKey, limit)
+			info := &RateLimitInfo{
+				Limit:     state.Limit,
+				Remaining: state.Remaining,
+				Reset:     state.Reset,
+				Throttled: throttled,
 			}
-			return r.RemoteAddr, nil
-		}),
-		httprate.WithLimitHandler(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			http.Error(w, `{"error": "Rate limit exceeded"}`, http.StatusTooManyRequests)
-		}),
-	)
-	return limiter
+
+			wrapped := &responseWriter{ResponseWriter: w, status: http.StatusOK, rateLimit: info}
+
+			if !allowed {
+				writeError(wrapped, http.StatusTooManyRequests, "Rate limit exceeded")
+				return
+			}
+
+			next.ServeHTTP(wrapped, r)
+		})
+	}
+}
+
+// MaskingMiddleware removes or redacts configured JSON response fields.
+// It runs on the final encoded output so handlers and the engine always
+// work with the full data.
+func MaskingMiddleware(cfg config.ResponseMaskConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if len(cfg.ExcludeFields) == 0 && len(cfg.MaskFields) == 0 {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mw := &maskingWriter{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(mw, r)
+
+			if !mw.buffering {
+				return
+			}
+
+			body := maskJSON(cfg, mw.buf.Bytes())
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			w.WriteHeader(mw.status)
+			w.Write(body)
+		})
+	}
+}
+
+// maskJSON removes or redacts the configured fields of a JSON document.
+// Numbers are kept as written, so integers beyond float64 precision survive.
+// Bodies that are not valid JSON are returned unchanged.
+func maskJSON(cfg config.ResponseMaskConfig, body []byte) []byte {
+	if len(cfg.ExcludeFields) == 0 && len(cfg.MaskFields) == 0 {
+		return body
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var data interface{}
+	if err := dec.Decode(&data); err != nil {
+		return body
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return body
+	}
+	for _, path := range cfg.ExcludeFields {
+		applyFieldMask(data, strings.Split(path, "."), nil)
+	}
+	for path, replacement := range cfg.MaskFields {
+		applyFieldMask(data, strings.Split(path, "."), &replacement)
+	}
+	masked, err := json.Marshal(data)
+	if err != nil {
+		return body
+	}
+	return append(masked, '\n')
+}
+
+// maskingWriter buffers JSON responses so they can be rewritten; other
+// content types are passed through untouched.
+type maskingWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	buffering   bool
+	buf         bytes.Buffer
+}
+
+func (mw *maskingWriter) WriteHeader(code int) {
+	if mw.wroteHeader {
+		return
+	}
+	mw.wroteHeader = true
+	mw.status = code
+	mw.buffering = strings.HasPrefix(mw.Header().Get("Content-Type"), "application/json")
+	if !mw.buffering {
+		mw.ResponseWriter.WriteHeader(code)
+	}
 }
 
-// responseWriter wraps http.ResponseWriter to capture status code.
+func (mw *maskingWriter) Unwrap() http.ResponseWriter {
+	return mw.ResponseWriter
+}
+
+func (mw *maskingWriter) Write(b []byte) (int, error) {
+	if !mw.wroteHeader {
+		mw.WriteHeader(http.StatusOK)
+	}
+	if mw.buffering {
+		return mw.buf.Write(b)
+	}
+	return mw.ResponseWriter.Write(b)
+}
+
+// applyFieldMask walks a decoded JSON value along a dot-separated path and
+// deletes the final field (replacement == nil) or replaces its value.
+// A segment suffixed with [] descends into every element of an array.
+func applyFieldMask(v interface{}, path []string, replacement *string) {
+	if len(path) == 0 {
+		return
+	}
+
+	segment := path[0]
+	isArray := strings.HasSuffix(segment, "[]")
+	name := strings.TrimSuffix(segment, "[]")
+
+	// A leading "[]" segment addresses a top-level array.
+	if name == "" && isArray {
+		if arr, ok := v.([]interface{}); ok {
+			for _, item := range arr {
+				applyFieldMask(item, path[1:], replacement)
+			}
+		}
+		return
+	}
+
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+	child, exists := obj[name]
+	if !exists {
+		return
+	}
+
+	if len(path) == 1 {
+		if replacement == nil {
+			delete(obj, name)
+		} else {
+			obj[name] = *replacement
+		}
+		return
+	}
+
+	if isArray {
+		if arr, ok := child.([]interface{}); ok {
+			for _, item := range arr {
+				applyFieldMask(item, path[1:], replacement)
+			}
+		}
+		return
+	}
+	applyFieldMask(child, path[1:], replacement)
+}
+
+// responseWriter wraps http.ResponseWriter to capture status code and,
+// when rate limit info is attached, emit X-RateLimit-* headers.
 type responseWriter struct {
 	http.ResponseWriter
-	status int
+	status      int
+	wroteHeader bool
+	rateLimit   *RateLimitInfo
 }
 
 func (rw *responseWriter) WriteHeader(code int) {
+	if rw.wroteHeader {
+		return
+	}
+	rw.wroteHeader = true
 	rw.status = code
+	if rw.rateLimit != nil {
+		rw.rateLimit.setHeaders(rw.Header())
+	}
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	if !rw.wroteHeader {
+		rw.WriteHeader(http.StatusOK)
+	}
+	return rw.ResponseWriter.Write(b)
+}
+
 // Helper functions to get context values
+
 func getAPIKey(ctx context.Context) *models.APIKey {
 	if key, ok := ctx.Value(apiKeyContextKey).(*models.APIKey); ok {
 		return key
@@ -188,6 +530,14 @@ func getAPIKey(ctx context.Context) *models.APIKey {
 	return nil
 }
 
+// setAuditDetail records a detail of the current request in its audit log
+// entry. It must be called before the handler returns.
+func setAuditDetail(ctx context.Context, key string, value interface{}) {
+	if details, ok := ctx.Value(auditDetailsKey).(map[string]interface{}); ok {
+		details[key] = value
+	}
+}
+
 func getRequestID(ctx context.Context) string {
 	if id, ok := ctx.Value(requestIDKey).(string); ok {
 		return id
@@ -0,0 +1,40 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/factchecker/verity/internal/config"
+)
+
+func TestMaskJSON(t *testing.T) {
+	cfg := config.ResponseMaskConfig{
+		ExcludeFields: []string{"claims[].reasoning"},
+		MaskFields:    map[string]string{"api_key": "***"},
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "masks fields",
+			body: `{"api_key":"secret","claims":[{"text":"a","reasoning":"r"}]}`,
+			want: `{"api_key":"***","claims":[{"text":"a"}]}` + "\n",
+		},
+		{
+			name: "keeps large integers exact",
+			body: `{"id":9007199254740993,"score":0.1,"api_key":"secret"}`,
+			want: `{"api_key":"***","id":9007199254740993,"score":0.1}` + "\n",
+		},
+		{name: "invalid json", body: `{"api_key":`, want: `{"api_key":`},
+		{name: "trailing data", body: `{"api_key":"secret"} {}`, want: `{"api_key":"secret"} {}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(maskJSON(cfg, []byte(tt.body))); got != tt.want {
+				t.Errorf("maskJSON(%s) = %q, want %q", tt.body, got, tt.want)
+			}
+		})
+	}
+}
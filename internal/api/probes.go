@@ -0,0 +1,148 @@
+// Package api provides Kubernetes liveness, readiness and health probes.
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/factchecker/verity/internal/database"
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// livenessTimeout is how long the heartbeat loop has to answer a ping.
+const livenessTimeout = 100 * time.Millisecond
+
+// dbStatsInterval is how often database statistics are collected for
+// /healthz.
+const dbStatsInterval = 60 * time.Second
+
+// Probes serves /livez, /readyz and /healthz.
+type Probes struct {
+	monitor *llm.ProviderHealthMonitor
+	store   database.Store
+
+	// heartbeat is served by a long-running goroutine; a missed reply
+	// means the process is wedged.
+	heartbeat chan chan struct{}
+
+	// dbStats is refreshed in the background so /healthz never waits on
+	// table scans.
+	dbStats atomic.Pointer[models.DBHealthStats]
+}
+
+// NewProbes creates the probe handlers and starts the heartbeat loop and
+// database statistics collection. LLM health comes from monitor, which must
+// be running.
+func NewProbes(monitor *llm.ProviderHealthMonitor, store database.Store) *Probes {
+	p := &Probes{
+		monitor:   monitor,
+		store:     store,
+		heartbeat: make(chan chan struct{}),
+	}
+
+	go func() {
+		for reply := range p.heartbeat {
+			close(reply)
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(dbStatsInterval)
+		defer ticker.Stop()
+		for {
+			p.collectDBStats()
+			<-ticker.C
+		}
+	}()
+
+	return p
+}
+
+// collectDBStats refreshes the cached database statistics, keeping the
+// previous ones if collection fails.
+func (p *Probes) collectDBStats() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	stats, err := p.store.HealthStats(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to collect database statistics")
+		return
+	}
+	p.dbStats.Store(stats)
+}
+
+// Livez returns 200 unless the heartbeat loop fails to respond in time.
+func (p *Probes) Livez(w http.ResponseWriter, r *http.Request) {
+	reply := make(chan struct{})
+	timeout := time.NewTimer(livenessTimeout)
+	defer timeout.Stop()
+
+	select {
+	case p.heartbeat <- reply:
+	case <-timeout.C:
+		writeError(w, http.StatusServiceUnavailable, "Heartbeat timed out")
+		return
+	}
+	select {
+	case <-reply:
+	case <-timeout.C:
+		writeError(w, http.StatusServiceUnavailable, "Heartbeat timed out")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "alive"})
+}
+
+// Readyz returns 200 once the LLM provider has passed its first health
+// check. The store is migrated before the router is built, so it is always
+// ready by then.
+func (p *Probes) Readyz(w http.ResponseWriter, r *http.Request) {
+	if p.monitor.Status().LastSuccess.IsZero() {
+		writeError(w, http.StatusServiceUnavailable, "Waiting for LLM provider")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+}
+
+// Healthz checks the database and reports the LLM provider health monitor's
+// state, returning 200 only when both are healthy. Database statistics are
+// the latest collected in the background, up to dbStatsInterval old.
+func (p *Probes) Healthz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	checks := map[string]string{"database": "ok", "llm": "ok"}
+	healthy := true
+
+	if err := p.store.Ping(ctx); err != nil {
+		checks["database"] = err.Error()
+		healthy = false
+	}
+	llmHealth := p.monitor.Status()
+	if !llmHealth.Healthy {
+		checks["llm"] = "unhealthy: " + llmHealth.LastError
+		healthy = false
+	}
+
+	status, code := "healthy", http.StatusOK
+	if !healthy {
+		status, code = "unhealthy", http.StatusServiceUnavailable
+	}
+
+	response := map[string]interface{}{
+		"status":     status,
+		"version":    "1.0.0",
+		"checks":     checks,
+		"llm_health": llmHealth,
+		"timestamp":  time.Now().UTC().Format(time.RFC3339),
+	}
+	if stats := p.dbStats.Load(); stats != nil {
+		response["database"] = stats
+	}
+	writeJSON(w, code, response)
+}
@@ -2,12 +2,16 @@
 package api
 
 import (
+	"context"
 	"embed"
 	"io/fs"
 	"net/http"
 
 	"github.com/factchecker/verity/internal/config"
 	"github.com/factchecker/verity/internal/database"
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/metrics"
+	"github.com/factchecker/verity/internal/models"
 	"github.com/factchecker/verity/internal/verify"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -18,18 +22,38 @@ func NewRouter(cfg *config.Config, engine *verify.Engine, store database.Store,
 	r := chi.NewRouter()
 
 	handler := NewHandler(engine, store)
+	handler.responseMask = cfg.Server.ResponseMask
+
+	monitor := llm.NewProviderHealthMonitor(engine.Provider(), llm.DefaultProbeInterval)
+	go monitor.Run(context.Background())
+	probes := NewProbes(monitor, store)
+	requireLLM := ProviderHealthMiddleware(monitor)
+	requireAdmin := RBACMiddleware(models.RoleAdmin)
+
+	scheduler := verify.NewScheduler(engine, store)
+	scheduler.SetReVerifiedHandler(handler.trends.clear)
+	go scheduler.Run(context.Background())
+	go disableExpiredAPIKeys(context.Background(), store)
 
 	// Global middleware
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.RealIP)
 	r.Use(RequestIDMiddleware)
 	r.Use(LoggingMiddleware)
+	r.Use(MetricsMiddleware)
+	r.Use(MaskingMiddleware(cfg.Server.ResponseMask))
+	r.Use(ResponseVersionMiddleware)
+
+	// Kubernetes probes (no auth required)
+	r.Get("/livez", probes.Livez)
+	r.Get("/readyz", probes.Readyz)
+	r.Get("/healthz", probes.Healthz)
+
+	// Prometheus metrics (no auth required)
+	r.Method(http.MethodGet, "/metrics", metrics.Handler())
 
 	// API routes
 	r.Route("/api/v1", func(r chi.Router) {
-		// Health check (no auth required)
-		r.Get("/health", handler.HealthCheck)
-
 		// Protected routes
 		r.Group(func(r chi.Router) {
 			r.Use(AuthMiddleware(store))
@@ -37,22 +61,50 @@ func NewRouter(cfg *config.Config, engine *verify.Engine, store database.Store,
 			r.Use(RateLimitMiddleware(cfg.RateLimits.RequestsPerMinute))
 
 			// Verification endpoints
-			r.Post("/verify/text", handler.VerifyText)
+			r.With(requireAdmin, requireLLM).Post("/verify/text", handler.VerifyText)
+			r.With(requireAdmin, requireLLM).Post("/verify/text/stream", handler.VerifyTextStream)
+			r.With(requireAdmin, requireLLM).Post("/verify/batch", handler.VerifyBatch)
+			r.Get("/jobs/{id}/poll", handler.PollJob)
 
 			// Results
 			r.Get("/results", handler.ListResults)
 			r.Get("/results/{id}", handler.GetResult)
+			r.Get("/results/{id}/export", handler.ExportResult)
+			r.With(requireAdmin).Patch("/results/{id}", handler.UpdateResult)
+			r.Post("/results/{id}/anonymize", handler.AnonymizeResult)
+			r.Post("/results/{id}/highlight", handler.HighlightResult)
+			r.With(requireAdmin, requireLLM).Post("/results/{id}/rerun", handler.RerunResult)
+
+			// Claims
+			r.Get("/claims/search", handler.SearchClaims)
+			r.Get("/claims/{id}/provenance", handler.GetClaimProvenance)
 
 			// Audit logs
-			r.Get("/audit", handler.GetAuditLogs)
+			r.With(requireAdmin).Get("/audit", handler.GetAuditLogs)
 		})
 
 		// Admin routes (API key management)
 		// In production, these should be protected differently
 		r.Route("/admin", func(r chi.Router) {
-			r.Post("/keys", handler.CreateAPIKey)
-			r.Get("/keys", handler.ListAPIKeys)
-			r.Delete("/keys/{id}", handler.DeleteAPIKey)
+			adminOnly := r.With(AuthMiddleware(store), requireAdmin)
+			adminOnly.Post("/keys", handler.CreateAPIKey)
+			adminOnly.Get("/keys", handler.ListAPIKeys)
+			adminOnly.Delete("/keys/{id}", handler.DeleteAPIKey)
+			adminOnly.Get("/audit/verify-chain", handler.VerifyAuditChain)
+			adminOnly.Get("/evidence-quality", handler.GetEvidenceQuality)
+			adminOnly.Patch("/claims/bulk-update", handler.BulkUpdateClaims)
+			adminOnly.Post("/claims/migrate-type", handler.MigrateClaimType)
+			adminOnly.Get("/analytics/score-trend", handler.GetScoreTrend)
+			adminOnly.Get("/audit", handler.GetAuditLogs)
+			adminOnly.Get("/config-overrides", handler.ListConfigOverrides)
+			adminOnly.Get("/config-overrides/{key}", handler.GetConfigOverride)
+			adminOnly.Put("/config-overrides/{key}", handler.SetConfigOverride)
+			adminOnly.Delete("/config-overrides/{key}", handler.DeleteConfigOverride)
+
+			// Routes that spend LLM tokens are audited
+			audited := adminOnly.With(AuditMiddleware(store))
+			audited.With(requireLLM).Post("/analyses/{id}/re-extract", handler.ReExtractClaims)
+			audited.Post("/claims/{id}/compare-providers", handler.CompareProviders)
 		})
 	})
 
@@ -83,16 +135,20 @@ func NewRouter(cfg *config.Config, engine *verify.Engine, store database.Store,
     <p>Fact-checking API is running. Use the API endpoints below:</p>
 
     <h2>Endpoints</h2>
-    <div class="endpoint"><code>GET /api/v1/health</code> - Health check</div>
+    <div class="endpoint"><code>GET /healthz</code> - Health check</div>
+    <div class="endpoint"><code>GET /metrics</code> - Prometheus metrics</div>
     <div class="endpoint"><code>POST /api/v1/verify/text</code> - Verify text content</div>
+    <div class="endpoint"><code>POST /api/v1/verify/text/stream</code> - Verify text, streaming progress as Server-Sent Events</div>
+    <div class="endpoint"><code>POST /api/v1/verify/batch</code> - Verify up to 50 texts</div>
     <div class="endpoint"><code>GET /api/v1/results</code> - List verification results</div>
     <div class="endpoint"><code>GET /api/v1/results/{id}</code> - Get specific result</div>
+    <div class="endpoint"><code>POST /api/v1/results/{id}/rerun</code> - Verify a result's text again, bypassing the cache</div>
 
     <h2>Authentication</h2>
     <p>Use <code>Authorization: Bearer your-api-key</code> header for all requests except health check.</p>
 
     <h2>Create API Key</h2>
-    <p><code>POST /api/v1/admin/keys</code> with body <code>{"name": "my-key"}</code></p>
+    <p><code>POST /api/v1/admin/keys</code> with an admin key and body <code>{"name": "my-key", "role": "reader"}</code></p>
 </body>
 </html>`))
 			})
@@ -0,0 +1,111 @@
+// Package api provides Server-Sent Events streaming of verifications.
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/factchecker/verity/internal/api/versions"
+	"github.com/factchecker/verity/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// Stream event types.
+const (
+	StreamClaimExtracted = "claim_extracted"
+	StreamClaimVerified  = "claim_verified"
+	StreamComplete       = "complete"
+	StreamError          = "error"
+)
+
+// streamBuffer is how many progress events may be queued while the client
+// is slow to read, before claim verification waits for it.
+const streamBuffer = 64
+
+type streamEvent struct {
+	event string
+	data  interface{}
+}
+
+// VerifyTextStream verifies like VerifyText, but responds with a
+// text/event-stream of progress: a claim_extracted event with the number
+// of claims, a claim_verified event with the ID and status of each claim
+// as it finishes, and finally a complete event with the full
+// VerificationResponse, or an error event. Cached analyses produce only
+// the complete event.
+func (h *Handler) VerifyTextStream(w http.ResponseWriter, r *http.Request) {
+	req, opts, ok := h.parseVerifyRequest(w, r)
+	if !ok {
+		return
+	}
+
+	events := make(chan streamEvent, streamBuffer)
+	opts.OnClaimsExtracted = func(count int) {
+		events <- streamEvent{StreamClaimExtracted, map[string]int{"count": count}}
+	}
+	opts.OnClaimVerified = func(claim models.Claim) {
+		events <- streamEvent{StreamClaimVerified, map[string]interface{}{"id": claim.ID, "status": claim.Status}}
+	}
+
+	go func() {
+		defer close(events)
+		result, err := h.engine.VerifyText(r.Context(), req.Text, opts)
+		if err != nil {
+			log.Error().Err(err).Msg("Verification failed")
+			events <- streamEvent{StreamError, map[string]string{"error": "Verification failed: " + err.Error()}}
+			return
+		}
+		// Cached analyses may carry chain of thought from an earlier explain request
+		if !req.Explain {
+			for i := range result.Claims {
+				result.Claims[i].ChainOfThought = ""
+			}
+		}
+		if r.URL.Query().Get("sort") == "significance" {
+			sortBySignificance(result.Claims)
+		}
+		h.notifyWebhook(webhookFor(req), result)
+		events <- streamEvent{StreamComplete, result}
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // keep reverse proxies from buffering events
+	w.WriteHeader(http.StatusOK)
+	rc := http.NewResponseController(w)
+	rc.Flush()
+
+	// Drain every event even after the client goes away, so that claim
+	// verification never blocks on a full channel.
+	for ev := range events {
+		data, err := h.encodeStreamData(w, ev)
+		if err != nil {
+			log.Error().Err(err).Str("event", ev.event).Msg("Failed to encode stream event")
+			continue
+		}
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", ev.event, data)
+		rc.Flush()
+	}
+}
+
+// encodeStreamData encodes event data as single-line JSON. The complete
+// event's response is versioned and masked like other JSON responses.
+func (h *Handler) encodeStreamData(w http.ResponseWriter, ev streamEvent) ([]byte, error) {
+	if ev.event != StreamComplete {
+		return json.Marshal(ev.data)
+	}
+
+	var data []byte
+	var err error
+	if adapter := responseAdapter(w); adapter != nil && adapter != versions.Latest {
+		data, err = adapter.Marshal(ev.data)
+	} else {
+		data, err = json.Marshal(ev.data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(maskJSON(h.responseMask, data)), nil
+}
@@ -0,0 +1,63 @@
+// Package api provides a bounded cache of score trends.
+package api
+
+import (
+	"container/list"
+	"sync"
+)
+
+// trendCacheSize bounds the score trends cached, since callers choose the
+// ranges and could otherwise grow the cache without limit.
+const trendCacheSize = 256
+
+// trendCache is an LRU cache of score trend responses. Entries cover past
+// buckets only, which change solely when stored claims are corrected or
+// re-verified; clear is called then.
+type trendCache struct {
+	mu      sync.Mutex
+	order   *list.List // front is most recently used
+	entries map[string]*list.Element
+}
+
+type trendEntry struct {
+	key      string
+	response map[string]interface{}
+}
+
+func newTrendCache() *trendCache {
+	return &trendCache{order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (c *trendCache) get(key string) (map[string]interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*trendEntry).response, true
+	}
+	return nil, false
+}
+
+func (c *trendCache) add(key string, response map[string]interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*trendEntry).response = response
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&trendEntry{key: key, response: response})
+	if c.order.Len() > trendCacheSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*trendEntry).key)
+	}
+}
+
+// clear drops every cached trend.
+func (c *trendCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	clear(c.entries)
+}
@@ -0,0 +1,9 @@
+package versions
+
+// V20230101 also predates claim sub-types, translation and significance,
+// and the score confidence interval of analyses.
+var V20230101 = &Adapter{
+	Version: "2023-01-01",
+	adapt: withoutFields("citations", "entities", "chain_of_thought",
+		"sub_type", "translated_text", "significance", "score_confidence_interval"),
+}
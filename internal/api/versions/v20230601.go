@@ -0,0 +1,7 @@
+package versions
+
+// V20230601 predates claim citations, entities and chain of thought.
+var V20230601 = &Adapter{
+	Version: "2023-06-01",
+	adapt:   withoutFields("citations", "entities", "chain_of_thought"),
+}
@@ -0,0 +1,4 @@
+package versions
+
+// V20240101 is the current schema.
+var V20240101 = &Adapter{Version: "2024-01-01"}
@@ -0,0 +1,83 @@
+// Package versions adapts API responses to the schema of earlier API
+// versions, so clients that parse responses strictly keep working when
+// models gain fields.
+package versions
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// Adapter converts a response from the current schema to that of one API
+// version.
+type Adapter struct {
+	// Version is the release date of the schema, YYYY-MM-DD.
+	Version string
+
+	// adapt rewrites a response decoded into generic JSON values; nil keeps
+	// the current schema.
+	adapt func(v interface{}) interface{}
+}
+
+// Latest is the version served when a request does not ask for one.
+var Latest = V20240101
+
+// adapters lists every supported version. Keep at least the two versions
+// before Latest.
+var adapters = []*Adapter{V20240101, V20230601, V20230101}
+
+// Lookup returns the adapter for a version given as YYYY-MM-DD or YYYY-MM.
+func Lookup(version string) (*Adapter, bool) {
+	for _, a := range adapters {
+		if version == a.Version || version == a.Version[:len("YYYY-MM")] {
+			return a, true
+		}
+	}
+	return nil, false
+}
+
+// Supported returns the supported versions, newest first.
+func Supported() []string {
+	var vs []string
+	for _, a := range adapters {
+		vs = append(vs, a.Version)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(vs)))
+	return vs
+}
+
+// Marshal encodes data in the adapter's schema.
+func (a *Adapter) Marshal(data interface{}) ([]byte, error) {
+	body, err := json.Marshal(data)
+	if err != nil || a.adapt == nil {
+		return body, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(a.adapt(v))
+}
+
+// withoutFields returns an adapt function that deletes the given keys from
+// every object in a response, at any depth.
+func withoutFields(keys ...string) func(interface{}) interface{} {
+	var strip func(v interface{}) interface{}
+	strip = func(v interface{}) interface{} {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for _, k := range keys {
+				delete(v, k)
+			}
+			for k, child := range v {
+				v[k] = strip(child)
+			}
+		case []interface{}:
+			for i, item := range v {
+				v[i] = strip(item)
+			}
+		}
+		return v
+	}
+	return strip
+}
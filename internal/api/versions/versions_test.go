@@ -0,0 +1,54 @@
+package versions
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	for _, v := range []string{"2024-01-01", "2024-01", "2023-06", "2023-01-01"} {
+		if _, ok := Lookup(v); !ok {
+			t.Errorf("Lookup(%q) not found", v)
+		}
+	}
+	if _, ok := Lookup("2022-01"); ok {
+		t.Error("Lookup(2022-01) found an unsupported version")
+	}
+	if got, want := Supported(), []string{"2024-01-01", "2023-06-01", "2023-01-01"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Supported() = %v, want %v", got, want)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	response := map[string]interface{}{
+		"score_confidence_interval": map[string]float64{"lower": 1, "upper": 9},
+		"claims": []map[string]interface{}{{
+			"text":             "GDP grew 3%",
+			"sub_type":         "macroeconomic",
+			"significance":     0.8,
+			"chain_of_thought": "...",
+		}},
+	}
+
+	tests := []struct {
+		adapter *Adapter
+		want    string
+	}{
+		{V20240101, `{"claims":[{"chain_of_thought":"...","significance":0.8,"sub_type":"macroeconomic","text":"GDP grew 3%"}],"score_confidence_interval":{"lower":1,"upper":9}}`},
+		{V20230601, `{"claims":[{"significance":0.8,"sub_type":"macroeconomic","text":"GDP grew 3%"}],"score_confidence_interval":{"lower":1,"upper":9}}`},
+		{V20230101, `{"claims":[{"text":"GDP grew 3%"}]}`},
+	}
+	for _, tt := range tests {
+		body, err := tt.adapter.Marshal(response)
+		if err != nil {
+			t.Fatalf("%s: Marshal() error = %v", tt.adapter.Version, err)
+		}
+		var got, want interface{}
+		json.Unmarshal(body, &got)
+		json.Unmarshal([]byte(tt.want), &want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: Marshal() = %s, want %s", tt.adapter.Version, body, tt.want)
+		}
+	}
+}
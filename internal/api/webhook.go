@@ -0,0 +1,226 @@
+// Package api provides webhook notification of completed verifications.
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// webhookAttempts is how many times a webhook is posted before the
+	// delivery is marked failed.
+	webhookAttempts = 3
+
+	// webhookBackoff is the wait before the first retry; it doubles after
+	// each further failure.
+	webhookBackoff = 2 * time.Second
+
+	// webhookTimeout bounds each delivery attempt.
+	webhookTimeout = 10 * time.Second
+)
+
+// webhookClient posts webhooks. Redirects are not followed, so that a
+// signed body is only ever sent to the URL the caller gave. Connections to
+// internal addresses are refused when dialing, after DNS resolution, so
+// that a hostname cannot be rebound to one after validation. No proxy is
+// used, as the check would then apply to the proxy instead.
+var webhookClient = &http.Client{
+	Timeout: webhookTimeout,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: webhookTimeout,
+			Control: refuseInternalAddress,
+		}).DialContext,
+		TLSHandshakeTimeout: webhookTimeout,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// errInternalWebhookAddress is returned when dialing a webhook that
+// resolves to an internal address.
+var errInternalWebhookAddress = errors.New("webhook address is not public")
+
+// refuseInternalAddress is a net.Dialer Control hook rejecting loopback,
+// private, link-local (such as cloud metadata at 169.254.169.254) and other
+// non-public addresses.
+func refuseInternalAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if isInternalAddress(ip) {
+		return fmt.Errorf("%w: %s", errInternalWebhookAddress, ip)
+	}
+	return nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598).
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// isInternalAddress reports whether ip is not a public unicast address.
+func isInternalAddress(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() ||
+		ip.IsUnspecified() || sharedAddressSpace.Contains(ip)
+}
+
+// webhookTarget is where a verification response is posted when done.
+// The zero value sends nothing.
+type webhookTarget struct {
+	URL    string
+	Secret string
+}
+
+// webhookFor returns the webhook target of a verify request.
+func webhookFor(req *models.VerifyRequest) webhookTarget {
+	return webhookTarget{URL: req.WebhookURL, Secret: req.WebhookSecret}
+}
+
+// validateWebhook checks the webhook fields of a verify request.
+func validateWebhook(req *models.VerifyRequest) error {
+	if req.WebhookURL == "" {
+		if req.WebhookSecret != "" {
+			return errors.New("webhook_secret requires webhook_url")
+		}
+		return nil
+	}
+	u, err := url.Parse(req.WebhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("webhook_url must be an http or https URL")
+	}
+	// Catch obvious internal targets early; webhookClient enforces this
+	// for every address a hostname resolves to
+	host := u.Hostname()
+	if ip, err := netip.ParseAddr(host); (err == nil && isInternalAddress(ip)) ||
+		strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return errors.New("webhook_url must not point to an internal address")
+	}
+	return nil
+}
+
+// signWebhook returns the X-Verity-Signature of a webhook body: the hex
+// HMAC-SHA256 of the body keyed with the secret, prefixed with "sha256=".
+func signWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// notifyWebhook posts result to the target in the background, recording
+// the delivery in the store. The body is masked like API responses.
+func (h *Handler) notifyWebhook(target webhookTarget, result *models.VerificationResponse) {
+	if target.URL == "" {
+		return
+	}
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		log.Error().Err(err).Str("analysis_id", result.ID).Msg("Failed to encode webhook body")
+		return
+	}
+	body = maskJSON(h.responseMask, body)
+
+	delivery := &models.WebhookDelivery{
+		ID:         uuid.New().String(),
+		AnalysisID: result.ID,
+		URL:        target.URL,
+		Status:     models.WebhookPending,
+		CreatedAt:  time.Now(),
+	}
+	go h.deliverWebhook(delivery, target.Secret, body)
+}
+
+// deliverWebhook posts body up to webhookAttempts times with exponential
+// backoff, saving the delivery state after each attempt. Client errors
+// other than 408 and 429 are not retried.
+func (h *Handler) deliverWebhook(delivery *models.WebhookDelivery, secret string, body []byte) {
+	ctx := context.Background()
+	h.saveWebhookDelivery(ctx, delivery)
+
+	backoff := webhookBackoff
+	for delivery.Attempts < webhookAttempts {
+		if delivery.Attempts > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		delivery.Attempts++
+
+		status, err := postWebhook(ctx, delivery, secret, body)
+		if err == nil {
+			now := time.Now()
+			delivery.Status = models.WebhookDelivered
+			delivery.LastError = ""
+			delivery.DeliveredAt = &now
+			h.saveWebhookDelivery(ctx, delivery)
+			log.Info().Str("analysis_id", delivery.AnalysisID).Int("attempts", delivery.Attempts).Msg("Webhook delivered")
+			return
+		}
+
+		delivery.LastError = err.Error()
+		retryable := status == 0 || status >= 500 || status == http.StatusRequestTimeout || status == http.StatusTooManyRequests
+		if !retryable || delivery.Attempts == webhookAttempts {
+			delivery.Status = models.WebhookFailed
+		}
+		h.saveWebhookDelivery(ctx, delivery)
+		log.Warn().Err(err).Str("analysis_id", delivery.AnalysisID).Int("attempt", delivery.Attempts).Msg("Webhook delivery failed")
+		if !retryable {
+			return
+		}
+	}
+}
+
+// postWebhook sends one delivery attempt. It returns the response status,
+// or 0 when no response was received, and an error unless it was 2xx.
+func postWebhook(ctx context.Context, delivery *models.WebhookDelivery, secret string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Verity/1.0 (Fact-checking tool)")
+	req.Header.Set("X-Verity-Delivery", delivery.ID)
+	if secret != "" {
+		req.Header.Set("X-Verity-Signature", signWebhook(secret, body))
+	}
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (h *Handler) saveWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) {
+	if err := h.store.SaveWebhookDelivery(ctx, delivery); err != nil {
+		log.Error().Err(err).Str("delivery_id", delivery.ID).Msg("Failed to save webhook delivery")
+	}
+}
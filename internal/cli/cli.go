@@ -0,0 +1,346 @@
+// Package cli provides administrative subcommands that operate on the
+// configured store directly, without starting the HTTP server.
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/factchecker/verity/internal/config"
+	"github.com/factchecker/verity/internal/database"
+	"github.com/factchecker/verity/internal/models"
+)
+
+// commands maps subcommand names to their implementations.
+var commands = map[string]func(ctx context.Context, cfg *config.Config, args []string, out io.Writer) error{
+	"keys":    runKeys,
+	"migrate": runMigrate,
+	"export":  runExport,
+	"stats":   runStats,
+}
+
+// IsCommand reports whether name is an admin subcommand handled by Run.
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok
+}
+
+// Run executes the admin subcommand named by args[0] with the remaining
+// arguments, writing human-readable output to out.
+func Run(ctx context.Context, cfg *config.Config, args []string, out io.Writer) error {
+	if len(args) == 0 || !IsCommand(args[0]) {
+		return errors.New("usage: verity <keys|migrate|export|stats> [flags]")
+	}
+	return commands[args[0]](ctx, cfg, args[1:], out)
+}
+
+// OpenStore opens the configured store. When migrate is false the schema is
+// left untouched, so pending migrations can be inspected.
+func OpenStore(cfg *config.Config, migrate bool) (database.Store, error) {
+	switch cfg.Database.Driver {
+	case "sqlite":
+		if migrate {
+			return database.NewSQLiteStore(cfg.Database.Path)
+		}
+		return database.OpenSQLiteStore(cfg.Database.Path)
+	case "postgres":
+		if migrate {
+			return database.NewPostgresStore(cfg.Database.URL)
+		}
+		return database.OpenPostgresStore(cfg.Database.URL)
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", cfg.Database.Driver)
+	}
+}
+
+func runKeys(ctx context.Context, cfg *config.Config, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: verity keys <create|list|delete> [flags]")
+	}
+
+	switch args[0] {
+	case "create":
+		fs := flag.NewFlagSet("keys create", flag.ContinueOnError)
+		name := fs.String("name", "", "Name of the API key (required)")
+		rpm := fs.Int("rpm", 60, "Requests per minute")
+		tpd := fs.Int("tpd", 100000, "Tokens per day")
+		expiresInDays := fs.Int("expires-in-days", 0, "Days until the key expires (0 never expires)")
+		role := fs.String("role", models.RoleReader, "Role of the key: admin or reader")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *name == "" {
+			return errors.New("--name is required")
+		}
+		if *expiresInDays < 0 {
+			return errors.New("--expires-in-days must not be negative")
+		}
+		if *role != models.RoleAdmin && *role != models.RoleReader {
+			return errors.New("--role must be admin or reader")
+		}
+
+		store, err := OpenStore(cfg, true)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		apiKey, rawKey, err := database.NewAPIKey(*name, *rpm, *tpd)
+		if err != nil {
+			return err
+		}
+		if *expiresInDays > 0 {
+			expiresAt := apiKey.CreatedAt.AddDate(0, 0, *expiresInDays)
+			apiKey.ExpiresAt = &expiresAt
+		}
+		apiKey.Role = *role
+		if err := store.CreateAPIKey(ctx, apiKey); err != nil {
+			return fmt.Errorf("failed to create API key: %w", err)
+		}
+
+		fmt.Fprintf(out, "Created API key %q\n", apiKey.Name)
+		fmt.Fprintf(out, "  ID:                  %s\n", apiKey.ID)
+		fmt.Fprintf(out, "  Key:                 %s\n", rawKey)
+		fmt.Fprintf(out, "  Requests per minute: %d\n", apiKey.RequestsPerMinute)
+		fmt.Fprintf(out, "  Tokens per day:      %d\n", apiKey.TokensPerDay)
+		fmt.Fprintf(out, "  Role:                %s\n", apiKey.Role)
+		if apiKey.ExpiresAt != nil {
+			fmt.Fprintf(out, "  Expires:             %s\n", apiKey.ExpiresAt.Format(time.RFC3339))
+		}
+		fmt.Fprintln(out, "Store the key now; it cannot be shown again.")
+		return nil
+
+	case "list":
+		fs := flag.NewFlagSet("keys list", flag.ContinueOnError)
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		store, err := OpenStore(cfg, true)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		keys, err := store.ListAPIKeys(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list API keys: %w", err)
+		}
+		if len(keys) == 0 {
+			fmt.Fprintln(out, "No API keys")
+			return nil
+		}
+
+		tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME\tROLE\tRPM\tTOKENS/DAY\tCREATED\tLAST USED\tEXPIRES")
+		for _, k := range keys {
+			lastUsed := "never"
+			if k.LastUsedAt != nil {
+				lastUsed = k.LastUsedAt.Format(time.RFC3339)
+			}
+			expires := "never"
+			if k.ExpiresAt != nil {
+				expires = k.ExpiresAt.Format(time.RFC3339)
+			}
+			if k.Disabled {
+				expires += " (disabled)"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s\n", k.ID, k.Name, k.Role, k.RequestsPerMinute,
+				k.TokensPerDay, k.CreatedAt.Format(time.RFC3339), lastUsed, expires)
+		}
+		return tw.Flush()
+
+	case "delete":
+		fs := flag.NewFlagSet("keys delete", flag.ContinueOnError)
+		id := fs.String("id", "", "ID of the API key to delete (required)")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *id == "" {
+			return errors.New("--id is required")
+		}
+
+		store, err := OpenStore(cfg, true)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		keys, err := store.ListAPIKeys(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list API keys: %w", err)
+		}
+		found := false
+		for _, k := range keys {
+			if k.ID == *id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("API key %s not found", *id)
+		}
+
+		if err := store.DeleteAPIKey(ctx, *id); err != nil {
+			return fmt.Errorf("failed to delete API key: %w", err)
+		}
+		fmt.Fprintf(out, "Deleted API key %s\n", *id)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown keys subcommand: %s", args[0])
+	}
+}
+
+func runMigrate(ctx context.Context, cfg *config.Config, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	up := fs.Bool("up", false, "Apply pending migrations")
+	status := fs.Bool("status", false, "List pending migrations without applying them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *up == *status {
+		return errors.New("exactly one of --up or --status is required")
+	}
+
+	store, err := OpenStore(cfg, false)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	pending, err := store.PendingMigrations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to inspect schema: %w", err)
+	}
+	if len(pending) == 0 {
+		fmt.Fprintln(out, "Schema is up to date")
+		return nil
+	}
+
+	if *status {
+		fmt.Fprintf(out, "%d pending migrations:\n", len(pending))
+		for _, p := range pending {
+			fmt.Fprintf(out, "  %s\n", p)
+		}
+		return nil
+	}
+
+	if err := store.Migrate(); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+	for _, p := range pending {
+		fmt.Fprintf(out, "Applied: %s\n", p)
+	}
+	return nil
+}
+
+func runExport(ctx context.Context, cfg *config.Config, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	id := fs.String("id", "", "ID of the analysis to export (required)")
+	format := fs.String("format", "json", "Output format (json)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" {
+		return errors.New("--id is required")
+	}
+	if *format != "json" {
+		return fmt.Errorf("unsupported export format: %s", *format)
+	}
+
+	store, err := OpenStore(cfg, true)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	analysis, err := store.GetAnalysis(ctx, *id)
+	if err != nil {
+		return fmt.Errorf("failed to get analysis: %w", err)
+	}
+	if analysis == nil {
+		return fmt.Errorf("analysis %s not found", *id)
+	}
+	claims, err := store.GetClaimsByAnalysis(ctx, *id)
+	if err != nil {
+		return fmt.Errorf("failed to get claims: %w", err)
+	}
+	contradictions, err := store.GetContradictionsByAnalysis(ctx, *id)
+	if err != nil {
+		return fmt.Errorf("failed to get contradictions: %w", err)
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(&models.VerificationResponse{
+		ID:             analysis.ID,
+		DocumentHash:   analysis.DocumentHash,
+		Analysis:       *analysis,
+		Claims:         claims,
+		Contradictions: contradictions,
+	})
+}
+
+func runStats(ctx context.Context, cfg *config.Config, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	store, err := OpenStore(cfg, true)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	now := time.Now()
+	allTime, err := store.GetScoreTrend(ctx, "month", time.Time{}, now.Add(time.Minute))
+	if err != nil {
+		return fmt.Errorf("failed to get score statistics: %w", err)
+	}
+	lastDay, err := store.GetScoreTrend(ctx, "day", now.Add(-24*time.Hour), now.Add(time.Minute))
+	if err != nil {
+		return fmt.Errorf("failed to get score statistics: %w", err)
+	}
+	keys, err := store.ListAPIKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	total := summarize(allTime)
+	fmt.Fprintf(out, "Analyses:           %d\n", total.Count)
+	if total.Count > 0 {
+		fmt.Fprintf(out, "Average score:      %.2f\n", total.AvgScore)
+		fmt.Fprintf(out, "Score range:        %.2f - %.2f\n", total.MinScore, total.MaxScore)
+	}
+	fmt.Fprintf(out, "Analyses (24h):     %d\n", summarize(lastDay).Count)
+	fmt.Fprintf(out, "API keys:           %d\n", len(keys))
+	return nil
+}
+
+// summarize merges score trend buckets into a single point.
+func summarize(points []*models.ScoreTrendPoint) models.ScoreTrendPoint {
+	var total models.ScoreTrendPoint
+	var sum float64
+	for i, p := range points {
+		if i == 0 || p.MinScore < total.MinScore {
+			total.MinScore = p.MinScore
+		}
+		if i == 0 || p.MaxScore > total.MaxScore {
+			total.MaxScore = p.MaxScore
+		}
+		total.Count += p.Count
+		sum += p.AvgScore * float64(p.Count)
+	}
+	if total.Count > 0 {
+		total.AvgScore = sum / float64(total.Count)
+	}
+	return total
+}
@@ -0,0 +1,79 @@
+// Package compare provides comparison of claims between a base analysis and a re-verified one.
+package compare
+
+import (
+	"github.com/factchecker/verity/internal/models"
+)
+
+// ClaimDiff describes how a claim changed between two analyses.
+type ClaimDiff struct {
+	BaseClaimID   string                    `json:"base_claim_id"`
+	ClaimID       string                    `json:"claim_id"`
+	SentenceIndex int                       `json:"sentence_index"`
+	BaseText      string                    `json:"base_text"`
+	Text          string                    `json:"text"`
+	TextDiff      string                    `json:"text_diff"` // Markdown-style [-old-]{+new+} markers
+	BaseStatus    models.VerificationStatus `json:"base_status"`
+	Status        models.VerificationStatus `json:"status"`
+	Edits         []Edit                    `json:"-"`
+}
+
+// HTML renders the text diff with <del> and <ins> tags.
+func (d ClaimDiff) HTML() string {
+	return RenderHTML(d.Edits)
+}
+
+// Changed reports whether the claim text or status differs.
+func (d ClaimDiff) Changed() bool {
+	return d.BaseText != d.Text || d.BaseStatus != d.Status
+}
+
+// ComparisonResult is the outcome of comparing two sets of claims.
+type ComparisonResult struct {
+	Diffs   []ClaimDiff    `json:"diffs"`
+	Added   []models.Claim `json:"added,omitempty"`
+	Removed []models.Claim `json:"removed,omitempty"`
+}
+
+// Claims pairs base and updated claims by sentence index (in order within a
+// sentence) and computes a word-level diff for each pair. Unpaired claims
+// are reported as added or removed.
+func Claims(base, updated []models.Claim) ComparisonResult {
+	bySentence := make(map[int][]models.Claim)
+	for _, c := range base {
+		bySentence[c.SentenceIndex] = append(bySentence[c.SentenceIndex], c)
+	}
+
+	var result ComparisonResult
+	for _, c := range updated {
+		candidates := bySentence[c.SentenceIndex]
+		if len(candidates) == 0 {
+			result.Added = append(result.Added, c)
+			continue
+		}
+		b := candidates[0]
+		bySentence[c.SentenceIndex] = candidates[1:]
+
+		edits := WordDiff(b.Text, c.Text)
+		result.Diffs = append(result.Diffs, ClaimDiff{
+			BaseClaimID:   b.ID,
+			ClaimID:       c.ID,
+			SentenceIndex: c.SentenceIndex,
+			BaseText:      b.Text,
+			Text:          c.Text,
+			TextDiff:      RenderMarkdown(edits),
+			BaseStatus:    b.Status,
+			Status:        c.Status,
+			Edits:         edits,
+		})
+	}
+
+	for _, c := range base {
+		for _, remaining := range bySentence[c.SentenceIndex] {
+			if remaining.ID == c.ID {
+				result.Removed = append(result.Removed, c)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,166 @@
+// Package compare provides diffing of claims between analyses.
+package compare
+
+import (
+	"html"
+	"strings"
+)
+
+// OpKind is the kind of a diff operation.
+type OpKind int
+
+const (
+	OpEqual OpKind = iota
+	OpDelete
+	OpInsert
+)
+
+// Edit is a run of consecutive tokens sharing the same operation.
+type Edit struct {
+	Kind   OpKind
+	Tokens []string
+}
+
+// Text returns the edit's tokens joined by single spaces.
+func (e Edit) Text() string {
+	return strings.Join(e.Tokens, " ")
+}
+
+// Diff computes the shortest edit script turning a into b using Myers'
+// O(ND) algorithm. Consecutive tokens with the same operation are merged.
+func Diff(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+
+	maxD := n + m
+	offset := maxD
+	v := make([]int, 2*maxD+2)
+	var trace [][]int
+
+	// Forward pass: v[k] holds the furthest x reached on diagonal k.
+	found := false
+	for d := 0; d <= maxD && !found; d++ {
+		snapshot := make([]int, len(v))
+		copy(snapshot, v)
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[k-1+offset] < v[k+1+offset]) {
+				x = v[k+1+offset] // move down (insertion)
+			} else {
+				x = v[k-1+offset] + 1 // move right (deletion)
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[k+offset] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+
+	// Backtrack through the trace to recover the edit path, in reverse.
+	type op struct {
+		kind  OpKind
+		token string
+	}
+	var ops []op
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[k-1+offset] < v[k+1+offset]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[prevK+offset]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			ops = append(ops, op{OpEqual, a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			ops = append(ops, op{OpInsert, b[y-1]})
+			y--
+		} else {
+			ops = append(ops, op{OpDelete, a[x-1]})
+			x--
+		}
+	}
+	for x > 0 && y > 0 {
+		ops = append(ops, op{OpEqual, a[x-1]})
+		x--
+		y--
+	}
+
+	// Reverse and merge runs.
+	var edits []Edit
+	for i := len(ops) - 1; i >= 0; i-- {
+		o := ops[i]
+		if len(edits) > 0 && edits[len(edits)-1].Kind == o.kind {
+			edits[len(edits)-1].Tokens = append(edits[len(edits)-1].Tokens, o.token)
+			continue
+		}
+		edits = append(edits, Edit{Kind: o.kind, Tokens: []string{o.token}})
+	}
+	return edits
+}
+
+// WordDiff computes a word-level diff between two strings.
+func WordDiff(oldText, newText string) []Edit {
+	return Diff(strings.Fields(oldText), strings.Fields(newText))
+}
+
+// RenderMarkdown renders edits using [-deleted-] and {+inserted+} markers.
+func RenderMarkdown(edits []Edit) string {
+	return render(edits, func(e Edit) string {
+		switch e.Kind {
+		case OpDelete:
+			return "[-" + e.Text() + "-]"
+		case OpInsert:
+			return "{+" + e.Text() + "+}"
+		default:
+			return e.Text()
+		}
+	})
+}
+
+// RenderHTML renders edits using <del> and <ins> tags. Text is HTML-escaped.
+func RenderHTML(edits []Edit) string {
+	return render(edits, func(e Edit) string {
+		text := html.EscapeString(e.Text())
+		switch e.Kind {
+		case OpDelete:
+			return "<del>" + text + "</del>"
+		case OpInsert:
+			return "<ins>" + text + "</ins>"
+		default:
+			return text
+		}
+	})
+}
+
+// render joins formatted edits with spaces, except that a deletion directly
+// followed by its replacing insertion is rendered without a gap.
+func render(edits []Edit, format func(Edit) string) string {
+	var sb strings.Builder
+	for i, e := range edits {
+		if i > 0 && !(e.Kind == OpInsert && edits[i-1].Kind == OpDelete) {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(format(e))
+	}
+	return sb.String()
+}
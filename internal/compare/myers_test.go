@@ -0,0 +1,168 @@
+package compare
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWordDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     []Edit
+		markdown string
+		html     string
+	}{
+		{
+			name: "both empty",
+		},
+		{
+			name:     "identical",
+			old:      "GDP grew 3% in 2023",
+			new:      "GDP grew 3% in 2023",
+			want:     []Edit{{OpEqual, []string{"GDP", "grew", "3%", "in", "2023"}}},
+			markdown: "GDP grew 3% in 2023",
+			html:     "GDP grew 3% in 2023",
+		},
+		{
+			name:     "old empty",
+			new:      "new claim",
+			want:     []Edit{{OpInsert, []string{"new", "claim"}}},
+			markdown: "{+new claim+}",
+			html:     "<ins>new claim</ins>",
+		},
+		{
+			name:     "new empty",
+			old:      "old claim",
+			want:     []Edit{{OpDelete, []string{"old", "claim"}}},
+			markdown: "[-old claim-]",
+			html:     "<del>old claim</del>",
+		},
+		{
+			name: "replaced word",
+			old:  "GDP grew 3% in 2023",
+			new:  "GDP grew 4% in 2023",
+			want: []Edit{
+				{OpEqual, []string{"GDP", "grew"}},
+				{OpDelete, []string{"3%"}},
+				{OpInsert, []string{"4%"}},
+				{OpEqual, []string{"in", "2023"}},
+			},
+			markdown: "GDP grew [-3%-]{+4%+} in 2023",
+			html:     "GDP grew <del>3%</del><ins>4%</ins> in 2023",
+		},
+		{
+			name: "inserted words",
+			old:  "the law passed",
+			new:  "the new tax law passed",
+			want: []Edit{
+				{OpEqual, []string{"the"}},
+				{OpInsert, []string{"new", "tax"}},
+				{OpEqual, []string{"law", "passed"}},
+			},
+			markdown: "the {+new tax+} law passed",
+			html:     "the <ins>new tax</ins> law passed",
+		},
+		{
+			name: "deleted words",
+			old:  "unemployment fell sharply last year",
+			new:  "unemployment fell last year",
+			want: []Edit{
+				{OpEqual, []string{"unemployment", "fell"}},
+				{OpDelete, []string{"sharply"}},
+				{OpEqual, []string{"last", "year"}},
+			},
+			markdown: "unemployment fell [-sharply-] last year",
+			html:     "unemployment fell <del>sharply</del> last year",
+		},
+		{
+			name: "html escaped",
+			old:  "a < b",
+			new:  "a > b",
+			want: []Edit{
+				{OpEqual, []string{"a"}},
+				{OpDelete, []string{"<"}},
+				{OpInsert, []string{">"}},
+				{OpEqual, []string{"b"}},
+			},
+			markdown: "a [-<-]{+>+} b",
+			html:     "a <del>&lt;</del><ins>&gt;</ins> b",
+		},
+		{
+			name:     "whitespace only differences",
+			old:      "one  two\tthree",
+			new:      "one two three",
+			want:     []Edit{{OpEqual, []string{"one", "two", "three"}}},
+			markdown: "one two three",
+			html:     "one two three",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits := WordDiff(tt.old, tt.new)
+			if !reflect.DeepEqual(edits, tt.want) {
+				t.Errorf("WordDiff() = %v, want %v", edits, tt.want)
+			}
+			if got := RenderMarkdown(edits); got != tt.markdown {
+				t.Errorf("RenderMarkdown() = %q, want %q", got, tt.markdown)
+			}
+			if got := RenderHTML(edits); got != tt.html {
+				t.Errorf("RenderHTML() = %q, want %q", got, tt.html)
+			}
+		})
+	}
+}
+
+// TestDiffIsShortest checks that edit scripts rebuild both inputs and change
+// no more tokens than their longest common subsequence requires.
+func TestDiffIsShortest(t *testing.T) {
+	pairs := [][2]string{
+		{"a b c a b b a", "c b a b a c"},
+		{"x y z", "a b c"},
+		{"a a a a", "a"},
+		{"a", "a a a a"},
+		{"the cat sat on the mat", "the dog sat on a mat today"},
+	}
+	for _, p := range pairs {
+		a, b := strings.Fields(p[0]), strings.Fields(p[1])
+		edits := Diff(a, b)
+
+		var gotA, gotB []string
+		changed := 0
+		for _, e := range edits {
+			if e.Kind != OpInsert {
+				gotA = append(gotA, e.Tokens...)
+			}
+			if e.Kind != OpDelete {
+				gotB = append(gotB, e.Tokens...)
+			}
+			if e.Kind != OpEqual {
+				changed += len(e.Tokens)
+			}
+		}
+		if !reflect.DeepEqual(gotA, a) || !reflect.DeepEqual(gotB, b) {
+			t.Errorf("Diff(%q, %q) rebuilds %q and %q", p[0], p[1], gotA, gotB)
+		}
+		if want := len(a) + len(b) - 2*lcsLength(a, b); changed != want {
+			t.Errorf("Diff(%q, %q) changes %d tokens, want %d", p[0], p[1], changed, want)
+		}
+	}
+}
+
+func lcsLength(a, b []string) int {
+	prev := make([]int, len(b)+1)
+	for i := range a {
+		cur := make([]int, len(b)+1)
+		for j := range b {
+			if a[i] == b[j] {
+				cur[j+1] = prev[j] + 1
+			} else {
+				cur[j+1] = max(prev[j+1], cur[j])
+			}
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
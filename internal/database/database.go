@@ -3,9 +3,19 @@ package database
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/factchecker/verity/internal/models"
+	"github.com/google/uuid"
 )
 
 // Store defines the interface for data persistence.
@@ -13,12 +23,37 @@ type Store interface {
 	// Analysis results
 	SaveAnalysis(ctx context.Context, result *models.AnalysisResult) error
 	GetAnalysis(ctx context.Context, id string) (*models.AnalysisResult, error)
-	GetAnalysisByHash(ctx context.Context, hash string) (*models.AnalysisResult, error)
-	ListAnalyses(ctx context.Context, limit, offset int) ([]*models.AnalysisResult, error)
+	GetAnalysisByHash(ctx context.Context, hash, cacheKey string) (*models.AnalysisResult, error)
+	ListAnalyses(ctx context.Context, filter AnalysisFilter, limit, offset int, after *Cursor) ([]*models.AnalysisResult, error)
+	GetScoreTrend(ctx context.Context, granularity string, since, until time.Time) ([]*models.ScoreTrendPoint, error)
+	SetReVerifyInterval(ctx context.Context, id string, every *time.Duration) error
+	ListScheduledReVerifications(ctx context.Context) ([]*models.AnalysisResult, error)
+	SaveReVerification(ctx context.Context, analysisID string, claims []models.Claim, at time.Time) error
 
 	// Claims
 	SaveClaims(ctx context.Context, analysisID string, claims []models.Claim) error
 	GetClaimsByAnalysis(ctx context.Context, analysisID string) ([]models.Claim, error)
+	GetClaim(ctx context.Context, id string) (claim *models.Claim, analysisID string, err error)
+	SaveProviderComparison(ctx context.Context, comparison *models.ProviderComparison) error
+	GetEvidenceQualityStats(ctx context.Context, domain string) ([]*models.EvidenceQualityStats, error)
+	BulkUpdateClaims(ctx context.Context, updates []models.ClaimUpdate) (updated, analysesRecomputed int, err error)
+	MigrateClaimType(ctx context.Context, from, to models.ClaimType, analysisIDs []string, dryRun bool) (int, error)
+	ReplaceClaims(ctx context.Context, analysisID string, archiveIDs []string, added []models.Claim, contradictions []models.Contradiction) error
+	GetEvidenceByURL(ctx context.Context, url string) ([]models.Evidence, error)
+	SearchClaims(ctx context.Context, query string, limit, offset int) ([]models.Claim, error)
+	SaveContradictions(ctx context.Context, analysisID string, contradictions []models.Contradiction) error
+	GetContradictionsByAnalysis(ctx context.Context, analysisID string) ([]models.Contradiction, error)
+
+	// Webhooks
+	SaveWebhookDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+
+	// Documents
+	SaveDocument(ctx context.Context, hash, text string) error
+	GetDocumentByHash(ctx context.Context, hash string) (string, error)
+
+	// Anonymized results
+	SaveAnonymizedResult(ctx context.Context, analysisID string, result *models.VerificationResponse) error
+	GetAnonymizedResult(ctx context.Context, analysisID string) (*models.VerificationResponse, error)
 
 	// API Keys
 	CreateAPIKey(ctx context.Context, key *models.APIKey) error
@@ -26,12 +61,184 @@ type Store interface {
 	UpdateAPIKeyLastUsed(ctx context.Context, id string, t time.Time) error
 	DeleteAPIKey(ctx context.Context, id string) error
 	ListAPIKeys(ctx context.Context) ([]*models.APIKey, error)
+	GetExpiredAPIKeys(ctx context.Context, now time.Time) ([]*models.APIKey, error)
+	DisableAPIKey(ctx context.Context, id string) error
+
+	// Config overrides
+	GetConfigOverride(ctx context.Context, key string) (value string, ok bool, err error)
+	SetConfigOverride(ctx context.Context, key, value, updatedByKeyID string) error
+	DeleteConfigOverride(ctx context.Context, key string) error
+	ListConfigOverrides(ctx context.Context) ([]*models.ConfigOverride, error)
 
 	// Audit logs
 	LogRequest(ctx context.Context, log *models.AuditLog) error
-	GetAuditLogs(ctx context.Context, limit, offset int) ([]*models.AuditLog, error)
+	GetAuditLogs(ctx context.Context, filter AuditFilter, limit, offset int, after *Cursor) (logs []*models.AuditLog, total int, err error)
+	VerifyAuditChain(ctx context.Context, since time.Time) (valid bool, firstBrokenID string, err error)
 
 	// Lifecycle
+	Ping(ctx context.Context) error
+	HealthStats(ctx context.Context) (*models.DBHealthStats, error)
 	Close() error
 	Migrate() error
+	PendingMigrations(ctx context.Context) ([]string, error)
+}
+
+// Cursor marks a position in a listing ordered newest first. When passed to
+// a list method, only rows strictly after it are returned and the offset is
+// ignored.
+type Cursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+}
+
+// AnalysisFilter narrows an analysis listing. Zero fields match everything.
+type AnalysisFilter struct {
+	ModelSource string
+}
+
+// AuditFilter narrows an audit log listing. Zero fields match everything.
+type AuditFilter struct {
+	APIKeyID      string
+	Endpoint      string
+	Method        string
+	StatusCode    int
+	Since         time.Time // inclusive
+	Until         time.Time // exclusive
+	MinDurationMs int64
+}
+
+// Encode returns the cursor as an opaque URL-safe token.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token produced by Cursor.Encode.
+func DecodeCursor(token string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return &c, nil
+}
+
+// NewAPIKey generates a random API key and returns the record to store along
+// with the raw key, which is shown to the caller once and never persisted.
+// Non-positive limits fall back to 60 requests per minute and 100000 tokens
+// per day. New keys have the reader role.
+func NewAPIKey(name string, requestsPerMinute, tokensPerDay int) (*models.APIKey, string, error) {
+	keyBytes := make([]byte, 32)
+	if _, err := rand.Read(keyBytes); err != nil {
+		return nil, "", fmt.Errorf("failed to generate key: %w", err)
+	}
+	rawKey := "vrt_" + base64.URLEncoding.EncodeToString(keyBytes)
+
+	// Hash for storage
+	hash := sha256.Sum256([]byte(rawKey))
+
+	if requestsPerMinute <= 0 {
+		requestsPerMinute = 60
+	}
+	if tokensPerDay <= 0 {
+		tokensPerDay = 100000
+	}
+
+	return &models.APIKey{
+		ID:                uuid.New().String(),
+		KeyHash:           hex.EncodeToString(hash[:]),
+		Name:              name,
+		RequestsPerMinute: requestsPerMinute,
+		TokensPerDay:      tokensPerDay,
+		CreatedAt:         time.Now(),
+		Role:              models.RoleReader,
+	}, rawKey, nil
+}
+
+// ErrInvalidSearchQuery is returned by SearchClaims for a query the
+// full-text index cannot parse.
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+
+// ErrSearchUnavailable is returned by SearchClaims when the database has no
+// full-text index, as with SQLite built without FTS5.
+var ErrSearchUnavailable = errors.New("full-text search is not available")
+
+// evidenceColumns are the evidence_items columns read by scanEvidence, for
+// queries aliasing the table as e.
+const evidenceColumns = `e.claim_id, e.id, e.source_name, e.source_url, e.source_type, e.fetcher, e.snippet,
+	e.content_type, e.relevance_score, e.trust_score, e.retrieved_at, e.is_useful, e.usefulness, e.published_at`
+
+// scanEvidence reads a row of evidenceColumns, returning the evidence and
+// the ID of its claim.
+func scanEvidence(rows *sql.Rows) (string, models.Evidence, error) {
+	var claimID string
+	var e models.Evidence
+	err := rows.Scan(&claimID, &e.ID, &e.SourceName, &e.SourceURL, &e.SourceType, &e.Fetcher, &e.Snippet,
+		&e.ContentType, &e.RelevanceScore, &e.TrustScore, &e.RetrievedAt, &e.IsUseful, &e.Usefulness, &e.PublishedAt)
+	return claimID, e, err
+}
+
+// attachEvidence sets the Evidences of claims from rows of evidenceColumns
+// ordered by position. Claims without evidence get an empty list.
+func attachEvidence(rows *sql.Rows, claims []models.Claim) error {
+	defer rows.Close()
+
+	byClaim := make(map[string]*models.Claim, len(claims))
+	for i := range claims {
+		claims[i].Evidences = []models.Evidence{}
+		byClaim[claims[i].ID] = &claims[i]
+	}
+	for rows.Next() {
+		claimID, e, err := scanEvidence(rows)
+		if err != nil {
+			return err
+		}
+		if c := byClaim[claimID]; c != nil {
+			c.Evidences = append(c.Evidences, e)
+		}
+	}
+	return rows.Err()
+}
+
+// overallScore computes an analysis score (0-10) the same way the
+// verification engine does: verified=1.0, mixed=0.5, unsupported=0.0.
+func overallScore(verified, mixed, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return (float64(verified) + float64(mixed)*0.5) / float64(total) * 10
+}
+
+// scoreInterval computes the 95% Wilson score interval around an analysis
+// score the same way the verification engine does: each claim is a trial
+// weighted by its confidence, and the score proportion is successes/total.
+func scoreInterval(successes float64, total int, confidenceSum float64) models.ScoreConfidenceInterval {
+	if total == 0 || confidenceSum <= 0 {
+		return models.ScoreConfidenceInterval{Lower: 0, Upper: 10}
+	}
+	const z = 1.96
+	p, n := successes/float64(total), confidenceSum
+	denom := 1 + z*z/n
+	center := (p + z*z/(2*n)) / denom
+	half := z / denom * math.Sqrt(p*(1-p)/n+z*z/(4*n*n))
+	return models.ScoreConfidenceInterval{
+		Lower: math.Max(0, center-half) * 10,
+		Upper: math.Min(1, center+half) * 10,
+	}
+}
+
+// auditHash computes the chained hash for an audit log entry. Each entry
+// commits to its predecessor's hash so that any modification or deletion
+// breaks the chain from that point forward.
+func auditHash(prevHash string, l *models.AuditLog) string {
+	h := sha256.New()
+	h.Write([]byte(prevHash))
+	h.Write([]byte(l.ID))
+	h.Write([]byte(l.APIKeyID))
+	h.Write([]byte(l.Endpoint))
+	h.Write([]byte(l.Timestamp.UTC().Format(time.RFC3339Nano)))
+	return hex.EncodeToString(h.Sum(nil))
 }
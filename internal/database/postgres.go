@@ -0,0 +1,1485 @@
+// Package database provides PostgreSQL implementation of the Store interface.
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/google/uuid"
+)
+
+// postgresDriver is the database/sql driver name registered by lib/pq,
+// which is linked in by building with the postgres tag.
+const postgresDriver = "postgres"
+
+// auditLockID is the advisory lock key that serializes audit log writes
+// across every process sharing the database, keeping the hash chain linear.
+const auditLockID = 0x7665726974790001
+
+// PostgresStore implements Store using PostgreSQL.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a new PostgreSQL store and applies any pending migrations.
+func NewPostgresStore(dsn string) (*PostgresStore, error) {
+	store, err := OpenPostgresStore(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Migrate(); err != nil {
+		store.Close()
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+	return store, nil
+}
+
+// OpenPostgresStore opens a PostgreSQL store without running migrations, so
+// the schema can be inspected with PendingMigrations first.
+func OpenPostgresStore(dsn string) (*PostgresStore, error) {
+	if dsn == "" {
+		return nil, fmt.Errorf("database URL is required for postgres")
+	}
+	if !slices.Contains(sql.Drivers(), postgresDriver) {
+		return nil, fmt.Errorf("PostgreSQL support is not compiled in; build with -tags postgres")
+	}
+
+	db, err := sql.Open(postgresDriver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Test connection
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return &PostgresStore{db: db}, nil
+}
+
+// postgresMigrations creates the schema, mirroring sqliteMigrations with
+// native types: TIMESTAMPTZ for times, BOOLEAN for flags and JSONB for JSON
+// documents. Every statement is idempotent; columns added later are
+// appended as ALTER TABLE ... ADD COLUMN IF NOT EXISTS.
+var postgresMigrations = []string{
+	`CREATE TABLE IF NOT EXISTS analysis_results (
+		id TEXT PRIMARY KEY,
+		document_hash TEXT NOT NULL,
+		overall_score DOUBLE PRECISION NOT NULL,
+		total_claims INTEGER NOT NULL,
+		verified_claims INTEGER NOT NULL,
+		mixed_claims INTEGER NOT NULL,
+		unsupported_claims INTEGER NOT NULL,
+		processing_time_ms BIGINT NOT NULL,
+		status TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		top_claims JSONB NOT NULL DEFAULT '[]',
+		revery_interval_hours INTEGER NOT NULL DEFAULT 0,
+		last_reverified_at TIMESTAMPTZ,
+		score_ci_lower DOUBLE PRECISION NOT NULL DEFAULT 0,
+		score_ci_upper DOUBLE PRECISION NOT NULL DEFAULT 0,
+		avg_claim_length DOUBLE PRECISION NOT NULL DEFAULT 0,
+		max_claim_length INTEGER NOT NULL DEFAULT 0,
+		min_claim_length INTEGER NOT NULL DEFAULT 0,
+		model_source TEXT NOT NULL DEFAULT '',
+		jurisdiction TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_analysis_hash ON analysis_results(document_hash)`,
+	`CREATE TABLE IF NOT EXISTS claims (
+		id TEXT PRIMARY KEY,
+		analysis_id TEXT NOT NULL REFERENCES analysis_results(id),
+		text TEXT NOT NULL,
+		type TEXT NOT NULL,
+		sentence_index INTEGER NOT NULL,
+		status TEXT NOT NULL,
+		confidence DOUBLE PRECISION NOT NULL,
+		source_type TEXT NOT NULL,
+		evidences JSONB NOT NULL,
+		reasoning TEXT,
+		created_at TIMESTAMPTZ NOT NULL,
+		chain_of_thought TEXT NOT NULL DEFAULT '',
+		significance DOUBLE PRECISION NOT NULL DEFAULT 0,
+		original_sentence TEXT NOT NULL DEFAULT '',
+		sub_type TEXT NOT NULL DEFAULT '',
+		extractability_score DOUBLE PRECISION NOT NULL DEFAULT 1,
+		is_opinion BOOLEAN NOT NULL DEFAULT FALSE,
+		archived BOOLEAN NOT NULL DEFAULT FALSE,
+		detected_language TEXT NOT NULL DEFAULT '',
+		source_format TEXT NOT NULL DEFAULT '',
+		translated_text TEXT NOT NULL DEFAULT '',
+		source_document TEXT NOT NULL DEFAULT '',
+		suggested_searches JSONB NOT NULL DEFAULT '[]'
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_claims_analysis ON claims(analysis_id)`,
+	`CREATE TABLE IF NOT EXISTS api_keys (
+		id TEXT PRIMARY KEY,
+		key_hash TEXT UNIQUE NOT NULL,
+		name TEXT NOT NULL,
+		requests_per_minute INTEGER NOT NULL,
+		tokens_per_day INTEGER NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		last_used_at TIMESTAMPTZ
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_api_keys_hash ON api_keys(key_hash)`,
+	// seq replaces SQLite's rowid as the order the hash chain was built in
+	`CREATE TABLE IF NOT EXISTS audit_logs (
+		seq BIGSERIAL UNIQUE,
+		id TEXT PRIMARY KEY,
+		api_key_id TEXT NOT NULL,
+		endpoint TEXT NOT NULL,
+		method TEXT NOT NULL,
+		request_size BIGINT NOT NULL,
+		response_code INTEGER NOT NULL,
+		duration_ms BIGINT NOT NULL,
+		timestamp TIMESTAMPTZ NOT NULL,
+		prev_hash TEXT NOT NULL DEFAULT '',
+		hash TEXT NOT NULL DEFAULT '',
+		details TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_timestamp ON audit_logs(timestamp)`,
+	`CREATE INDEX IF NOT EXISTS idx_analysis_created ON analysis_results(created_at, id)`,
+	`CREATE TABLE IF NOT EXISTS anonymized_results (
+		analysis_id TEXT PRIMARY KEY REFERENCES analysis_results(id),
+		data JSONB NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS claim_feedback (
+		id TEXT PRIMARY KEY,
+		claim_id TEXT NOT NULL REFERENCES claims(id),
+		analysis_id TEXT NOT NULL,
+		status TEXT NOT NULL,
+		confidence DOUBLE PRECISION NOT NULL,
+		reasoning TEXT NOT NULL,
+		reviewer_note TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_claim_feedback_claim ON claim_feedback(claim_id)`,
+	`CREATE TABLE IF NOT EXISTS documents (
+		hash TEXT PRIMARY KEY,
+		text TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_key_timestamp ON audit_logs(api_key_id, timestamp)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_endpoint_code ON audit_logs(endpoint, response_code)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_duration ON audit_logs(duration_ms)`,
+	`CREATE TABLE IF NOT EXISTS config_overrides (
+		key TEXT PRIMARY KEY,
+		value TEXT NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL,
+		updated_by_key_id TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE TABLE IF NOT EXISTS provider_comparisons (
+		id TEXT PRIMARY KEY,
+		claim_id TEXT NOT NULL,
+		provider_results JSONB NOT NULL,
+		agreement BOOLEAN NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_provider_comparisons_claim ON provider_comparisons(claim_id)`,
+	`CREATE TABLE IF NOT EXISTS webhook_deliveries (
+		id TEXT PRIMARY KEY,
+		analysis_id TEXT NOT NULL,
+		url TEXT NOT NULL,
+		status TEXT NOT NULL,
+		attempts INTEGER NOT NULL,
+		last_error TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMPTZ NOT NULL,
+		delivered_at TIMESTAMPTZ
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_analysis ON webhook_deliveries(analysis_id)`,
+	`CREATE TABLE IF NOT EXISTS contradictions (
+		analysis_id TEXT NOT NULL,
+		claim_id TEXT NOT NULL,
+		conflicting_claim_id TEXT NOT NULL,
+		explanation TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_contradictions_analysis ON contradictions(analysis_id)`,
+	`CREATE TABLE IF NOT EXISTS evidence_items (
+		id TEXT NOT NULL,
+		claim_id TEXT NOT NULL REFERENCES claims(id),
+		position INTEGER NOT NULL,
+		source_name TEXT NOT NULL,
+		source_url TEXT NOT NULL,
+		source_type TEXT NOT NULL,
+		fetcher TEXT NOT NULL DEFAULT '',
+		snippet TEXT NOT NULL,
+		content_type TEXT NOT NULL DEFAULT '',
+		relevance_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+		trust_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+		retrieved_at TIMESTAMPTZ NOT NULL,
+		is_useful BOOLEAN NOT NULL DEFAULT FALSE,
+		usefulness TEXT NOT NULL DEFAULT '',
+		PRIMARY KEY (claim_id, id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_evidence_items_url ON evidence_items(source_url)`,
+	// Full-text index of claim text and reasoning, used by SearchClaims
+	`CREATE INDEX IF NOT EXISTS idx_claims_fts ON claims USING GIN (` + postgresClaimDocument + `)`,
+	// Evidence used to be stored as a JSON array in claims.evidences; move
+	// it to evidence_items. The column is kept, always '[]'.
+	`INSERT INTO evidence_items (id, claim_id, position, source_name, source_url, source_type, fetcher,
+		snippet, content_type, relevance_score, trust_score, retrieved_at, is_useful, usefulness)
+	SELECT COALESCE(e.value->>'id', ''), claims.id, e.position - 1,
+		COALESCE(e.value->>'source_name', ''), COALESCE(e.value->>'source_url', ''),
+		COALESCE(e.value->>'source_type', ''), COALESCE(e.value->>'fetcher', ''),
+		COALESCE(e.value->>'snippet', ''), COALESCE(e.value->>'content_type', ''),
+		COALESCE((e.value->>'relevance_score')::double precision, 0),
+		COALESCE((e.value->>'trust_score')::double precision, 0),
+		COALESCE((e.value->>'retrieved_at')::timestamptz, 'epoch'),
+		COALESCE((e.value->>'is_useful')::boolean, FALSE), COALESCE(e.value->>'usefulness', '')
+	FROM claims, jsonb_array_elements(CASE WHEN jsonb_typeof(claims.evidences) = 'array'
+		THEN claims.evidences ELSE '[]'::jsonb END) WITH ORDINALITY AS e(value, position)
+	ON CONFLICT DO NOTHING`,
+	`UPDATE claims SET evidences = '[]' WHERE evidences <> '[]'`,
+	`ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS prompt_tokens BIGINT NOT NULL DEFAULT 0`,
+	`ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS completion_tokens BIGINT NOT NULL DEFAULT 0`,
+	`ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ`,
+	`ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS disabled BOOLEAN NOT NULL DEFAULT FALSE`,
+	// Keys predating roles keep full access
+	`ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'admin'`,
+	`ALTER TABLE claims ADD COLUMN IF NOT EXISTS sentence_indices JSONB NOT NULL DEFAULT '[]'`,
+	`ALTER TABLE analysis_results ADD COLUMN IF NOT EXISTS cache_key TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE analysis_results ADD COLUMN IF NOT EXISTS options TEXT NOT NULL DEFAULT ''`,
+	`ALTER TABLE evidence_items ADD COLUMN IF NOT EXISTS published_at TIMESTAMPTZ`,
+}
+
+// postgresAddColumn extracts the table and column from an ADD COLUMN migration.
+var postgresAddColumn = regexp.MustCompile(`(?i)ALTER\s+TABLE\s+(\w+)\s+ADD\s+COLUMN\s+IF\s+NOT\s+EXISTS\s+(\w+)`)
+
+// Migrate runs database migrations in a single transaction.
+func (s *PostgresStore) Migrate() error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, m := range postgresMigrations {
+		if _, err := tx.Exec(m); err != nil {
+			return fmt.Errorf("migration failed: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// PendingMigrations lists the tables, indexes and columns Migrate would create.
+func (s *PostgresStore) PendingMigrations(ctx context.Context) ([]string, error) {
+	var pending []string
+	tables := make(map[string]bool)
+
+	for _, m := range postgresMigrations {
+		if match := migrationObject.FindStringSubmatch(m); match != nil {
+			kind, name := strings.ToLower(match[1]), match[2]
+
+			var exists bool
+			if err := s.db.QueryRowContext(ctx, `SELECT to_regclass($1) IS NOT NULL`, name).Scan(&exists); err != nil {
+				return nil, err
+			}
+			if !exists {
+				pending = append(pending, fmt.Sprintf("create %s %s", kind, name))
+				continue
+			}
+			if kind == "table" {
+				tables[name] = true
+			}
+			continue
+		}
+
+		// Columns of tables that do not exist yet come with the CREATE TABLE.
+		if match := postgresAddColumn.FindStringSubmatch(m); match != nil && tables[match[1]] {
+			var exists bool
+			err := s.db.QueryRowContext(ctx, `
+				SELECT EXISTS (SELECT 1 FROM information_schema.columns
+					WHERE table_schema = current_schema() AND table_name = $1 AND column_name = $2)`,
+				match[1], match[2]).Scan(&exists)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				pending = append(pending, fmt.Sprintf("add column %s.%s", match[1], match[2]))
+			}
+		}
+	}
+	return pending, nil
+}
+
+// Ping checks that the database is reachable.
+func (s *PostgresStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// Close closes the database connection.
+func (s *PostgresStore) Close() error {
+	return s.db.Close()
+}
+
+// HealthStats returns table counts and sizes. The WAL size is the total of
+// pg_ls_waldir(), which needs the pg_monitor role; without it the WAL size
+// is reported as zero.
+func (s *PostgresStore) HealthStats(ctx context.Context) (*models.DBHealthStats, error) {
+	stats := &models.DBHealthStats{CollectedAt: time.Now()}
+
+	if err := s.db.QueryRowContext(ctx, `SELECT pg_database_size(current_database())`).Scan(&stats.DBSizeBytes); err != nil {
+		return nil, err
+	}
+	var walSize int64
+	if err := s.db.QueryRowContext(ctx, `SELECT COALESCE(SUM(size), 0) FROM pg_ls_waldir()`).Scan(&walSize); err == nil {
+		stats.WALSizeBytes = walSize
+	}
+
+	counts := []struct {
+		query string
+		dest  *int
+	}{
+		{`SELECT COUNT(*) FROM analysis_results`, &stats.AnalysisCount},
+		{`SELECT COUNT(*) FROM claims WHERE NOT archived`, &stats.ClaimCount},
+		{`SELECT COUNT(*) FROM api_keys`, &stats.APIKeyCount},
+		{`SELECT COUNT(*) FROM audit_logs`, &stats.AuditLogCount},
+	}
+	for _, c := range counts {
+		if err := s.db.QueryRowContext(ctx, c.query).Scan(c.dest); err != nil {
+			return nil, err
+		}
+	}
+	if stats.AnalysisCount == 0 {
+		return stats, nil
+	}
+	stats.AvgClaimsPerAnalysis = float64(stats.ClaimCount) / float64(stats.AnalysisCount)
+
+	var oldest, newest time.Time
+	if err := s.db.QueryRowContext(ctx, `SELECT MIN(created_at), MAX(created_at) FROM analysis_results`).Scan(&oldest, &newest); err != nil {
+		return nil, err
+	}
+	stats.OldestAnalysisCreatedAt = &oldest
+	stats.NewestAnalysisCreatedAt = &newest
+	return stats, nil
+}
+
+// SaveAnalysis stores an analysis result.
+func (s *PostgresStore) SaveAnalysis(ctx context.Context, result *models.AnalysisResult) error {
+	topClaimsJSON, _ := json.Marshal(result.TopClaims)
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO analysis_results (id, document_hash, overall_score, total_claims, verified_claims,
+			mixed_claims, unsupported_claims, processing_time_ms, status, created_at, top_claims,
+			score_ci_lower, score_ci_upper, avg_claim_length, max_claim_length, min_claim_length, model_source,
+			jurisdiction, cache_key, options)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)`,
+		result.ID, result.DocumentHash, result.OverallScore, result.TotalClaims,
+		result.VerifiedClaims, result.MixedClaims, result.UnsupportedClaims,
+		result.ProcessingTimeMs, result.Status, result.CreatedAt, string(topClaimsJSON),
+		result.ScoreInterval.Lower, result.ScoreInterval.Upper,
+		result.AvgClaimLength, result.MaxClaimLength, result.MinClaimLength, result.ModelSource,
+		result.Jurisdiction, result.CacheKey, result.Options,
+	)
+	return err
+}
+
+// GetAnalysis retrieves an analysis by ID.
+func (s *PostgresStore) GetAnalysis(ctx context.Context, id string) (*models.AnalysisResult, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT `+analysisColumns+`
+		FROM analysis_results WHERE id = $1`, id)
+
+	result, err := scanAnalysis(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAnalysisByHash retrieves the latest analysis of a document made with
+// the options identified by cacheKey.
+func (s *PostgresStore) GetAnalysisByHash(ctx context.Context, hash, cacheKey string) (*models.AnalysisResult, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT `+analysisColumns+`
+		FROM analysis_results WHERE document_hash = $1 AND cache_key = $2
+		ORDER BY created_at DESC LIMIT 1`, hash, cacheKey)
+
+	result, err := scanAnalysis(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListAnalyses returns paginated analysis results matching filter, newest
+// first. If after is set, keyset pagination is used instead of offset.
+func (s *PostgresStore) ListAnalyses(ctx context.Context, filter AnalysisFilter, limit, offset int, after *Cursor) ([]*models.AnalysisResult, error) {
+	var where []string
+	var args []interface{}
+	if filter.ModelSource != "" {
+		args = append(args, filter.ModelSource)
+		where = append(where, fmt.Sprintf("model_source = $%d", len(args)))
+	}
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		where = append(where, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+		offset = 0
+	}
+	query := `SELECT ` + analysisColumns + ` FROM analysis_results`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	args = append(args, limit, offset)
+	query += fmt.Sprintf(` ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*models.AnalysisResult
+	for rows.Next() {
+		r, err := scanAnalysis(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// SetReVerifyInterval schedules periodic re-verification of an analysis,
+// rounded to whole hours. A nil or zero interval disables it.
+func (s *PostgresStore) SetReVerifyInterval(ctx context.Context, id string, every *time.Duration) error {
+	hours := 0
+	if every != nil {
+		hours = int(every.Hours())
+	}
+	_, err := s.db.ExecContext(ctx, `UPDATE analysis_results SET revery_interval_hours = $1 WHERE id = $2`, hours, id)
+	return err
+}
+
+// ListScheduledReVerifications returns all analyses with a re-verification
+// interval set.
+func (s *PostgresStore) ListScheduledReVerifications(ctx context.Context) ([]*models.AnalysisResult, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+analysisColumns+`
+		FROM analysis_results WHERE revery_interval_hours > 0`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*models.AnalysisResult
+	for rows.Next() {
+		r, err := scanAnalysis(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// SaveReVerification stores new verdicts for existing claims of an
+// analysis, recomputes its scores and records when it was re-verified, in
+// a single transaction.
+func (s *PostgresStore) SaveReVerification(ctx context.Context, analysisID string, claims []models.Claim, at time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, c := range claims {
+		res, err := tx.ExecContext(ctx, `
+			UPDATE claims SET status = $1, confidence = $2, reasoning = $3, chain_of_thought = $4, source_type = $5
+			WHERE id = $6 AND analysis_id = $7`,
+			c.Status, c.Confidence, c.Reasoning, c.ChainOfThought, c.SourceType,
+			c.ID, analysisID)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM evidence_items WHERE claim_id = $1`, c.ID); err != nil {
+			return err
+		}
+		if err := insertPostgresEvidence(ctx, tx, c.ID, c.Evidences); err != nil {
+			return err
+		}
+	}
+	if err := recomputePostgresAnalysis(ctx, tx, analysisID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE analysis_results SET last_reverified_at = $1 WHERE id = $2`, at, analysisID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// postgresScoreTrendBuckets maps a granularity to the expression that
+// labels each analysis with its bucket, formatted as in scoreTrendBuckets.
+// date_trunc weeks start on Monday.
+var postgresScoreTrendBuckets = map[string]string{
+	"hour":  `to_char(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:00')`,
+	"day":   `to_char(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD')`,
+	"week":  `to_char(date_trunc('week', created_at AT TIME ZONE 'UTC'), 'YYYY-MM-DD')`,
+	"month": `to_char(created_at AT TIME ZONE 'UTC', 'YYYY-MM')`,
+}
+
+// GetScoreTrend aggregates analysis scores per time bucket for analyses
+// created in [since, until). Buckets are computed in UTC.
+func (s *PostgresStore) GetScoreTrend(ctx context.Context, granularity string, since, until time.Time) ([]*models.ScoreTrendPoint, error) {
+	bucket, ok := postgresScoreTrendBuckets[granularity]
+	if !ok {
+		return nil, fmt.Errorf("unsupported granularity: %s", granularity)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+bucket+` AS bucket, COUNT(*), AVG(overall_score), MIN(overall_score), MAX(overall_score)
+		FROM analysis_results
+		WHERE created_at >= $1 AND created_at < $2
+		GROUP BY bucket ORDER BY bucket`,
+		since, until)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []*models.ScoreTrendPoint
+	for rows.Next() {
+		var p models.ScoreTrendPoint
+		if err := rows.Scan(&p.Date, &p.Count, &p.AvgScore, &p.MinScore, &p.MaxScore); err != nil {
+			return nil, err
+		}
+		points = append(points, &p)
+	}
+	return points, rows.Err()
+}
+
+// SaveClaims stores claims for an analysis.
+func (s *PostgresStore) SaveClaims(ctx context.Context, analysisID string, claims []models.Claim) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertPostgresClaims(ctx, tx, analysisID, claims); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertPostgresClaims inserts claims for an analysis within a transaction.
+func insertPostgresClaims(ctx context.Context, tx *sql.Tx, analysisID string, claims []models.Claim) error {
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO claims (id, analysis_id, text, type, sentence_index, status, confidence,
+			source_type, evidences, reasoning, created_at, chain_of_thought, significance, original_sentence,
+			sub_type, extractability_score, is_opinion, detected_language, source_format, translated_text,
+			source_document, suggested_searches, sentence_indices)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, '[]', $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19,
+			$20, $21, $22)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, claim := range claims {
+		suggestedJSON, _ := json.Marshal(claim.SuggestedSearches)
+		indicesJSON, _ := json.Marshal(claim.SentenceIndices)
+		_, err := stmt.ExecContext(ctx, claim.ID, analysisID, claim.Text, claim.Type,
+			claim.SentenceIndex, claim.Status, claim.Confidence, claim.SourceType,
+			claim.Reasoning, claim.CreatedAt, claim.ChainOfThought, claim.Significance,
+			claim.OriginalSentence, claim.SubType, claim.ExtractabilityScore, claim.IsOpinion, claim.DetectedLanguage,
+			claim.SourceFormat, claim.TranslatedText, claim.SourceDocument, string(suggestedJSON), string(indicesJSON))
+		if err != nil {
+			return err
+		}
+		if err := insertPostgresEvidence(ctx, tx, claim.ID, claim.Evidences); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertPostgresEvidence inserts the evidence of a claim within a
+// transaction, keeping its order.
+func insertPostgresEvidence(ctx context.Context, tx *sql.Tx, claimID string, evidences []models.Evidence) error {
+	for i, e := range evidences {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO evidence_items (id, claim_id, position, source_name, source_url, source_type, fetcher,
+			snippet, content_type, relevance_score, trust_score, retrieved_at, is_useful, usefulness, published_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`,
+			e.ID, claimID, i, e.SourceName, e.SourceURL, e.SourceType, e.Fetcher,
+			e.Snippet, e.ContentType, e.RelevanceScore, e.TrustScore, e.RetrievedAt, e.IsUseful, e.Usefulness, e.PublishedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplaceClaims archives claims of an analysis, stores new ones and their
+// contradictions and recomputes the analysis scores in a single
+// transaction. Archived claims are kept for reference but no longer
+// returned or counted, and their contradictions are dropped. Claims
+// involved in one of the new contradictions are marked mixed.
+func (s *PostgresStore) ReplaceClaims(ctx context.Context, analysisID string, archiveIDs []string, added []models.Claim, contradictions []models.Contradiction) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, id := range archiveIDs {
+		if _, err := tx.ExecContext(ctx, `UPDATE claims SET archived = TRUE WHERE id = $1 AND analysis_id = $2`, id, analysisID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			DELETE FROM contradictions WHERE analysis_id = $1 AND (claim_id = $2 OR conflicting_claim_id = $2)`,
+			analysisID, id); err != nil {
+			return err
+		}
+	}
+	if err := insertPostgresClaims(ctx, tx, analysisID, added); err != nil {
+		return err
+	}
+	for _, c := range contradictions {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO contradictions (analysis_id, claim_id, conflicting_claim_id, explanation)
+			VALUES ($1, $2, $3, $4)`,
+			analysisID, c.ClaimID, c.ConflictingClaimID, c.Explanation); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE claims SET status = $1 WHERE analysis_id = $2 AND id IN ($3, $4)`,
+			models.StatusMixed, analysisID, c.ClaimID, c.ConflictingClaimID); err != nil {
+			return err
+		}
+	}
+	if err := recomputePostgresAnalysis(ctx, tx, analysisID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// SaveDocument stores the text of a verified document, keyed by its hash.
+// Documents already stored are left unchanged.
+func (s *PostgresStore) SaveDocument(ctx context.Context, hash, text string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO documents (hash, text, created_at) VALUES ($1, $2, $3)
+		ON CONFLICT (hash) DO NOTHING`,
+		hash, text, time.Now())
+	return err
+}
+
+// GetDocumentByHash retrieves the text of a document by its hash.
+func (s *PostgresStore) GetDocumentByHash(ctx context.Context, hash string) (string, error) {
+	var text string
+	err := s.db.QueryRowContext(ctx, `SELECT text FROM documents WHERE hash = $1`, hash).Scan(&text)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return text, err
+}
+
+// GetClaimsByAnalysis retrieves all claims for an analysis, excluding archived ones.
+func (s *PostgresStore) GetClaimsByAnalysis(ctx context.Context, analysisID string) ([]models.Claim, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, text, type, sentence_index, status, confidence, source_type, reasoning, created_at,
+			chain_of_thought, significance, original_sentence, sub_type,
+			extractability_score, is_opinion, detected_language, source_format, translated_text, source_document,
+			suggested_searches, sentence_indices
+		FROM claims WHERE analysis_id = $1 AND NOT archived ORDER BY sentence_index`, analysisID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var claims []models.Claim
+	for rows.Next() {
+		var c models.Claim
+		var suggestedJSON, indicesJSON string
+		var reasoning sql.NullString
+		if err := rows.Scan(&c.ID, &c.Text, &c.Type, &c.SentenceIndex, &c.Status,
+			&c.Confidence, &c.SourceType, &reasoning, &c.CreatedAt,
+			&c.ChainOfThought, &c.Significance, &c.OriginalSentence, &c.SubType,
+			&c.ExtractabilityScore, &c.IsOpinion, &c.DetectedLanguage, &c.SourceFormat, &c.TranslatedText,
+			&c.SourceDocument, &suggestedJSON, &indicesJSON); err != nil {
+			return nil, err
+		}
+		c.Reasoning = reasoning.String
+		json.Unmarshal([]byte(suggestedJSON), &c.SuggestedSearches)
+		json.Unmarshal([]byte(indicesJSON), &c.SentenceIndices)
+		claims = append(claims, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	evidenceRows, err := s.db.QueryContext(ctx, `
+		SELECT `+evidenceColumns+`
+		FROM evidence_items e JOIN claims c ON c.id = e.claim_id
+		WHERE c.analysis_id = $1 AND NOT c.archived
+		ORDER BY e.claim_id, e.position`, analysisID)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachEvidence(evidenceRows, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// GetClaim retrieves a single claim, archived or not, along with the ID of
+// the analysis it belongs to.
+func (s *PostgresStore) GetClaim(ctx context.Context, id string) (*models.Claim, string, error) {
+	var c models.Claim
+	var analysisID, suggestedJSON, indicesJSON string
+	var reasoning sql.NullString
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, analysis_id, text, type, sentence_index, status, confidence, source_type, reasoning,
+			created_at, chain_of_thought, significance, original_sentence, sub_type,
+			extractability_score, is_opinion, detected_language, source_format, translated_text, source_document,
+			suggested_searches, sentence_indices
+		FROM claims WHERE id = $1`, id).Scan(&c.ID, &analysisID, &c.Text, &c.Type, &c.SentenceIndex, &c.Status,
+		&c.Confidence, &c.SourceType, &reasoning, &c.CreatedAt,
+		&c.ChainOfThought, &c.Significance, &c.OriginalSentence, &c.SubType,
+		&c.ExtractabilityScore, &c.IsOpinion, &c.DetectedLanguage, &c.SourceFormat, &c.TranslatedText,
+		&c.SourceDocument, &suggestedJSON, &indicesJSON)
+	if err == sql.ErrNoRows {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	c.Reasoning = reasoning.String
+	json.Unmarshal([]byte(suggestedJSON), &c.SuggestedSearches)
+	json.Unmarshal([]byte(indicesJSON), &c.SentenceIndices)
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+evidenceColumns+`
+		FROM evidence_items e WHERE e.claim_id = $1 ORDER BY e.position`, id)
+	if err != nil {
+		return nil, "", err
+	}
+	claims := []models.Claim{c}
+	if err := attachEvidence(rows, claims); err != nil {
+		return nil, "", err
+	}
+	return &claims[0], analysisID, nil
+}
+
+// postgresClaimDocument is the text search document of a claim. It must
+// match idx_claims_fts for the index to be used.
+const postgresClaimDocument = `to_tsvector('simple', text || ' ' || COALESCE(reasoning, ''))`
+
+// SearchClaims returns the claims whose text or reasoning matches a web
+// search style query, best match first, with their evidence and the ID of
+// their analysis. Archived claims are excluded.
+func (s *PostgresStore) SearchClaims(ctx context.Context, query string, limit, offset int) ([]models.Claim, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, analysis_id, text, type, sentence_index, status, confidence, source_type, reasoning,
+			created_at, chain_of_thought, significance, original_sentence, sub_type,
+			extractability_score, is_opinion, detected_language, source_format, translated_text, source_document,
+			suggested_searches, sentence_indices
+		FROM claims
+		WHERE `+postgresClaimDocument+` @@ websearch_to_tsquery('simple', $1) AND NOT archived
+		ORDER BY ts_rank(`+postgresClaimDocument+`, websearch_to_tsquery('simple', $1)) DESC, created_at DESC
+		LIMIT $2 OFFSET $3`, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	claims := []models.Claim{}
+	for rows.Next() {
+		var c models.Claim
+		var suggestedJSON, indicesJSON string
+		var reasoning sql.NullString
+		if err := rows.Scan(&c.ID, &c.AnalysisID, &c.Text, &c.Type, &c.SentenceIndex, &c.Status,
+			&c.Confidence, &c.SourceType, &reasoning, &c.CreatedAt,
+			&c.ChainOfThought, &c.Significance, &c.OriginalSentence, &c.SubType,
+			&c.ExtractabilityScore, &c.IsOpinion, &c.DetectedLanguage, &c.SourceFormat, &c.TranslatedText,
+			&c.SourceDocument, &suggestedJSON, &indicesJSON); err != nil {
+			return nil, err
+		}
+		c.Reasoning = reasoning.String
+		json.Unmarshal([]byte(suggestedJSON), &c.SuggestedSearches)
+		json.Unmarshal([]byte(indicesJSON), &c.SentenceIndices)
+		claims = append(claims, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(claims) == 0 {
+		return claims, nil
+	}
+
+	placeholders := make([]string, len(claims))
+	args := make([]interface{}, len(claims))
+	for i, c := range claims {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = c.ID
+	}
+	evidenceRows, err := s.db.QueryContext(ctx, `
+		SELECT `+evidenceColumns+`
+		FROM evidence_items e WHERE e.claim_id IN (`+strings.Join(placeholders, ", ")+`)
+		ORDER BY e.claim_id, e.position`, args...)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachEvidence(evidenceRows, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// GetEvidenceByURL returns every piece of evidence retrieved from url,
+// with the claim it was found for, most recent first.
+func (s *PostgresStore) GetEvidenceByURL(ctx context.Context, url string) ([]models.Evidence, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+evidenceColumns+`
+		FROM evidence_items e WHERE e.source_url = $1 ORDER BY e.retrieved_at DESC`, url)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var evidences []models.Evidence
+	for rows.Next() {
+		claimID, e, err := scanEvidence(rows)
+		if err != nil {
+			return nil, err
+		}
+		e.ClaimID = claimID
+		evidences = append(evidences, e)
+	}
+	return evidences, rows.Err()
+}
+
+// SaveProviderComparison stores the result of re-verifying a claim with
+// several providers.
+func (s *PostgresStore) SaveProviderComparison(ctx context.Context, c *models.ProviderComparison) error {
+	resultsJSON, _ := json.Marshal(c.ProviderResults)
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO provider_comparisons (id, claim_id, provider_results, agreement, created_at)
+		VALUES ($1, $2, $3, $4, $5)`,
+		c.ID, c.ClaimID, string(resultsJSON), c.Agreement, c.CreatedAt)
+	return err
+}
+
+// SaveContradictions stores the contradictions found between claims of an
+// analysis.
+func (s *PostgresStore) SaveContradictions(ctx context.Context, analysisID string, contradictions []models.Contradiction) error {
+	if len(contradictions) == 0 {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, c := range contradictions {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO contradictions (analysis_id, claim_id, conflicting_claim_id, explanation)
+			VALUES ($1, $2, $3, $4)`,
+			analysisID, c.ClaimID, c.ConflictingClaimID, c.Explanation); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetContradictionsByAnalysis retrieves the contradictions between claims
+// of an analysis.
+func (s *PostgresStore) GetContradictionsByAnalysis(ctx context.Context, analysisID string) ([]models.Contradiction, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT claim_id, conflicting_claim_id, explanation
+		FROM contradictions WHERE analysis_id = $1`, analysisID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contradictions []models.Contradiction
+	for rows.Next() {
+		var c models.Contradiction
+		if err := rows.Scan(&c.ClaimID, &c.ConflictingClaimID, &c.Explanation); err != nil {
+			return nil, err
+		}
+		contradictions = append(contradictions, c)
+	}
+	return contradictions, rows.Err()
+}
+
+// SaveWebhookDelivery records a webhook delivery, replacing its earlier
+// state when it is retried.
+func (s *PostgresStore) SaveWebhookDelivery(ctx context.Context, d *models.WebhookDelivery) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (id, analysis_id, url, status, attempts, last_error, created_at, delivered_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status,
+			attempts = EXCLUDED.attempts,
+			last_error = EXCLUDED.last_error,
+			delivered_at = EXCLUDED.delivered_at`,
+		d.ID, d.AnalysisID, d.URL, string(d.Status), d.Attempts, d.LastError, d.CreatedAt, d.DeliveredAt)
+	return err
+}
+
+// BulkUpdateClaims applies reviewer corrections in a single transaction,
+// records each one in claim_feedback and recomputes the scores of every
+// affected analysis. Unknown claim IDs are skipped.
+func (s *PostgresStore) BulkUpdateClaims(ctx context.Context, updates []models.ClaimUpdate) (int, int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	updated := 0
+	affected := make(map[string]bool)
+	for _, u := range updates {
+		var analysisID, reasoning string
+		var confidence float64
+		err := tx.QueryRowContext(ctx, `SELECT analysis_id, confidence, COALESCE(reasoning, '') FROM claims WHERE id = $1`, u.ID).
+			Scan(&analysisID, &confidence, &reasoning)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if u.Confidence != nil {
+			confidence = *u.Confidence
+		}
+		if u.Reasoning != "" {
+			reasoning = u.Reasoning
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE claims SET status = $1, confidence = $2, reasoning = $3 WHERE id = $4`,
+			u.Status, confidence, reasoning, u.ID); err != nil {
+			return 0, 0, err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO claim_feedback (id, claim_id, analysis_id, status, confidence, reasoning, reviewer_note, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			uuid.New().String(), u.ID, analysisID, u.Status, confidence, reasoning, u.ReviewerNote, now); err != nil {
+			return 0, 0, err
+		}
+
+		updated++
+		affected[analysisID] = true
+	}
+
+	for analysisID := range affected {
+		if err := recomputePostgresAnalysis(ctx, tx, analysisID); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+	return updated, len(affected), nil
+}
+
+// MigrateClaimType changes the type of every claim of type from to type to,
+// optionally limited to the given analyses, in a single transaction. Scores
+// are not recomputed since they do not depend on claim type. With dryRun
+// set, only the number of matching claims is returned.
+func (s *PostgresStore) MigrateClaimType(ctx context.Context, from, to models.ClaimType, analysisIDs []string, dryRun bool) (int, error) {
+	// filter returns the WHERE clause and its arguments, numbering
+	// placeholders from first
+	filter := func(first int) (string, []interface{}) {
+		where := fmt.Sprintf(`type = $%d`, first)
+		args := []interface{}{from}
+		if len(analysisIDs) > 0 {
+			placeholders := make([]string, len(analysisIDs))
+			for i, id := range analysisIDs {
+				placeholders[i] = fmt.Sprintf("$%d", first+1+i)
+				args = append(args, id)
+			}
+			where += ` AND analysis_id IN (` + strings.Join(placeholders, ", ") + `)`
+		}
+		return where, args
+	}
+
+	if dryRun {
+		where, args := filter(1)
+		var count int
+		err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM claims WHERE `+where, args...).Scan(&count)
+		return count, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	where, args := filter(2)
+	res, err := tx.ExecContext(ctx, `UPDATE claims SET type = $1 WHERE `+where, append([]interface{}{to}, args...)...)
+	if err != nil {
+		return 0, err
+	}
+	migrated, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(migrated), nil
+}
+
+// recomputePostgresAnalysis refreshes an analysis' claim counts and score from its claims.
+func recomputePostgresAnalysis(ctx context.Context, tx *sql.Tx, analysisID string) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT status, COUNT(*), SUM(confidence) FROM claims
+		WHERE analysis_id = $1 AND NOT archived GROUP BY status`, analysisID)
+	if err != nil {
+		return err
+	}
+	var verified, mixed, unsupported, total int
+	var confidenceSum float64
+	for rows.Next() {
+		var status models.VerificationStatus
+		var count int
+		var confidence float64
+		if err := rows.Scan(&status, &count, &confidence); err != nil {
+			rows.Close()
+			return err
+		}
+		switch status {
+		case models.StatusVerified:
+			verified = count
+		case models.StatusMixed:
+			mixed = count
+		case models.StatusUnsupported:
+			unsupported = count
+		}
+		total += count
+		confidenceSum += confidence
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	interval := scoreInterval(float64(verified)+float64(mixed)*0.5, total, confidenceSum)
+	_, err = tx.ExecContext(ctx, `
+		UPDATE analysis_results SET overall_score = $1, total_claims = $2, verified_claims = $3,
+			mixed_claims = $4, unsupported_claims = $5, score_ci_lower = $6, score_ci_upper = $7
+		WHERE id = $8`,
+		overallScore(verified, mixed, total), total, verified, mixed, unsupported,
+		interval.Lower, interval.Upper, analysisID)
+	return err
+}
+
+// GetEvidenceQualityStats aggregates evidence usefulness by domain and source
+// type. If domain is non-empty only that domain is included.
+func (s *PostgresStore) GetEvidenceQualityStats(ctx context.Context, domain string) ([]*models.EvidenceQualityStats, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT source_url, source_type, snippet, is_useful FROM evidence_items`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type groupKey struct{ domain, sourceType string }
+	groups := make(map[groupKey]*models.EvidenceQualityStats)
+	usefulLength := make(map[groupKey]int)
+
+	for rows.Next() {
+		var e models.Evidence
+		if err := rows.Scan(&e.SourceURL, &e.SourceType, &e.Snippet, &e.IsUseful); err != nil {
+			return nil, err
+		}
+
+		d := evidenceDomain(e.SourceURL)
+		if domain != "" && d != domain {
+			continue
+		}
+		key := groupKey{d, e.SourceType}
+		stats, ok := groups[key]
+		if !ok {
+			stats = &models.EvidenceQualityStats{Domain: d, SourceType: e.SourceType}
+			groups[key] = stats
+		}
+		stats.TotalEvidences++
+		if e.IsUseful {
+			stats.UsefulEvidences++
+			usefulLength[key] += len(e.Snippet)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	results := make([]*models.EvidenceQualityStats, 0, len(groups))
+	for key, stats := range groups {
+		stats.PercentUseful = float64(stats.UsefulEvidences) / float64(stats.TotalEvidences) * 100
+		if stats.UsefulEvidences > 0 {
+			stats.AvgUsefulSnippetLength = float64(usefulLength[key]) / float64(stats.UsefulEvidences)
+		}
+		results = append(results, stats)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].TotalEvidences > results[j].TotalEvidences
+	})
+	return results, nil
+}
+
+// SaveAnonymizedResult stores (or replaces) the anonymized copy of an analysis.
+func (s *PostgresStore) SaveAnonymizedResult(ctx context.Context, analysisID string, result *models.VerificationResponse) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO anonymized_results (analysis_id, data, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (analysis_id) DO UPDATE SET data = excluded.data, created_at = excluded.created_at`,
+		analysisID, string(data), time.Now())
+	return err
+}
+
+// GetAnonymizedResult retrieves the anonymized copy of an analysis.
+func (s *PostgresStore) GetAnonymizedResult(ctx context.Context, analysisID string) (*models.VerificationResponse, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT data FROM anonymized_results WHERE analysis_id = $1`, analysisID).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.VerificationResponse
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateAPIKey stores a new API key.
+func (s *PostgresStore) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO api_keys (id, key_hash, name, requests_per_minute, tokens_per_day, created_at, expires_at, role)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		key.ID, key.KeyHash, key.Name, key.RequestsPerMinute, key.TokensPerDay, key.CreatedAt, key.ExpiresAt, key.Role)
+	return err
+}
+
+// GetAPIKeyByHash retrieves an API key by its hash.
+func (s *PostgresStore) GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, key_hash, name, requests_per_minute, tokens_per_day, created_at, last_used_at,
+			expires_at, disabled, role
+		FROM api_keys WHERE key_hash = $1`, hash)
+
+	var key models.APIKey
+	err := row.Scan(&key.ID, &key.KeyHash, &key.Name, &key.RequestsPerMinute,
+		&key.TokensPerDay, &key.CreatedAt, &key.LastUsedAt, &key.ExpiresAt, &key.Disabled, &key.Role)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// UpdateAPIKeyLastUsed updates the last used timestamp.
+func (s *PostgresStore) UpdateAPIKeyLastUsed(ctx context.Context, id string, t time.Time) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE api_keys SET last_used_at = $1 WHERE id = $2`, t, id)
+	return err
+}
+
+// DeleteAPIKey removes an API key.
+func (s *PostgresStore) DeleteAPIKey(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM api_keys WHERE id = $1`, id)
+	return err
+}
+
+// ListAPIKeys returns all API keys.
+func (s *PostgresStore) ListAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, name, requests_per_minute, tokens_per_day, created_at, last_used_at,
+			expires_at, disabled, role
+		FROM api_keys ORDER BY created_at DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*models.APIKey
+	for rows.Next() {
+		var k models.APIKey
+		if err := rows.Scan(&k.ID, &k.Name, &k.RequestsPerMinute,
+			&k.TokensPerDay, &k.CreatedAt, &k.LastUsedAt, &k.ExpiresAt, &k.Disabled, &k.Role); err != nil {
+			return nil, err
+		}
+		keys = append(keys, &k)
+	}
+	return keys, rows.Err()
+}
+
+// GetExpiredAPIKeys returns the keys that expired before now and are not
+// disabled yet.
+func (s *PostgresStore) GetExpiredAPIKeys(ctx context.Context, now time.Time) ([]*models.APIKey, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, name, requests_per_minute, tokens_per_day, created_at, last_used_at,
+			expires_at, disabled, role
+		FROM api_keys WHERE expires_at IS NOT NULL AND expires_at < $1 AND NOT disabled
+		ORDER BY expires_at`, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*models.APIKey
+	for rows.Next() {
+		var k models.APIKey
+		if err := rows.Scan(&k.ID, &k.Name, &k.RequestsPerMinute,
+			&k.TokensPerDay, &k.CreatedAt, &k.LastUsedAt, &k.ExpiresAt, &k.Disabled, &k.Role); err != nil {
+			return nil, err
+		}
+		keys = append(keys, &k)
+	}
+	return keys, rows.Err()
+}
+
+// DisableAPIKey marks an API key as disabled; it is kept for the audit trail.
+func (s *PostgresStore) DisableAPIKey(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE api_keys SET disabled = TRUE WHERE id = $1`, id)
+	return err
+}
+
+// GetConfigOverride returns the override stored for key; ok is false when
+// there is none.
+func (s *PostgresStore) GetConfigOverride(ctx context.Context, key string) (string, bool, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM config_overrides WHERE key = $1`, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+// SetConfigOverride creates or replaces the override for key.
+func (s *PostgresStore) SetConfigOverride(ctx context.Context, key, value, updatedByKeyID string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO config_overrides (key, value, updated_at, updated_by_key_id) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at,
+			updated_by_key_id = excluded.updated_by_key_id`,
+		key, value, time.Now(), updatedByKeyID)
+	return err
+}
+
+// DeleteConfigOverride removes the override for key.
+func (s *PostgresStore) DeleteConfigOverride(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM config_overrides WHERE key = $1`, key)
+	return err
+}
+
+// ListConfigOverrides returns all overrides ordered by key.
+func (s *PostgresStore) ListConfigOverrides(ctx context.Context) ([]*models.ConfigOverride, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT key, value, updated_at, updated_by_key_id FROM config_overrides ORDER BY key`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var overrides []*models.ConfigOverride
+	for rows.Next() {
+		var o models.ConfigOverride
+		if err := rows.Scan(&o.Key, &o.Value, &o.UpdatedAt, &o.UpdatedByKeyID); err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, &o)
+	}
+	return overrides, rows.Err()
+}
+
+// LogRequest stores an audit log entry, chaining its hash to the previous
+// entry. A transaction-scoped advisory lock serializes writers, including
+// those of other processes sharing the database.
+func (s *PostgresStore) LogRequest(ctx context.Context, log *models.AuditLog) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, int64(auditLockID)); err != nil {
+		return err
+	}
+
+	var prevHash string
+	err = tx.QueryRowContext(ctx, `SELECT hash FROM audit_logs ORDER BY seq DESC LIMIT 1`).Scan(&prevHash)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	// TIMESTAMPTZ keeps microseconds; hash what will be read back
+	log.Timestamp = log.Timestamp.Truncate(time.Microsecond)
+	log.PrevHash = prevHash
+	log.Hash = auditHash(prevHash, log)
+
+	var detailsJSON []byte
+	if len(log.Details) > 0 {
+		detailsJSON, _ = json.Marshal(log.Details)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO audit_logs (id, api_key_id, endpoint, method, request_size, response_code, duration_ms, timestamp,
+			prev_hash, hash, details, prompt_tokens, completion_tokens)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		log.ID, log.APIKeyID, log.Endpoint, log.Method, log.RequestSize,
+		log.ResponseCode, log.DurationMs, log.Timestamp, log.PrevHash, log.Hash, string(detailsJSON),
+		log.PromptTokens, log.CompletionTokens)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetAuditLogs returns paginated audit logs matching filter, newest first,
+// and the total number of matches. If after is set, keyset pagination on
+// (timestamp, id) is used instead of offset.
+func (s *PostgresStore) GetAuditLogs(ctx context.Context, filter AuditFilter, limit, offset int, after *Cursor) ([]*models.AuditLog, int, error) {
+	var where []string
+	var args []interface{}
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	if filter.APIKeyID != "" {
+		add("api_key_id = $%d", filter.APIKeyID)
+	}
+	if filter.Endpoint != "" {
+		add("endpoint = $%d", filter.Endpoint)
+	}
+	if filter.Method != "" {
+		add("method = $%d", filter.Method)
+	}
+	if filter.StatusCode != 0 {
+		add("response_code = $%d", filter.StatusCode)
+	}
+	if !filter.Since.IsZero() {
+		add("timestamp >= $%d", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		add("timestamp < $%d", filter.Until)
+	}
+	if filter.MinDurationMs > 0 {
+		add("duration_ms >= $%d", filter.MinDurationMs)
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM audit_logs`
+	if len(where) > 0 {
+		countQuery += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	if after != nil {
+		args = append(args, after.CreatedAt, after.ID)
+		where = append(where, fmt.Sprintf("(timestamp, id) < ($%d, $%d)", len(args)-1, len(args)))
+		offset = 0
+	}
+	query := `
+		SELECT id, api_key_id, endpoint, method, request_size, response_code, duration_ms, timestamp,
+			prev_hash, hash, details, prompt_tokens, completion_tokens
+		FROM audit_logs`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	args = append(args, limit, offset)
+	query += fmt.Sprintf(` ORDER BY timestamp DESC, id DESC LIMIT $%d OFFSET $%d`, len(args)-1, len(args))
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var logs []*models.AuditLog
+	for rows.Next() {
+		var l models.AuditLog
+		var detailsJSON string
+		if err := rows.Scan(&l.ID, &l.APIKeyID, &l.Endpoint, &l.Method,
+			&l.RequestSize, &l.ResponseCode, &l.DurationMs, &l.Timestamp,
+			&l.PrevHash, &l.Hash, &detailsJSON, &l.PromptTokens, &l.CompletionTokens); err != nil {
+			return nil, 0, err
+		}
+		if detailsJSON != "" {
+			json.Unmarshal([]byte(detailsJSON), &l.Details)
+		}
+		logs = append(logs, &l)
+	}
+	return logs, total, rows.Err()
+}
+
+// VerifyAuditChain recomputes the hash chain for audit logs written since the
+// given time. Entries are walked in insertion order, which is the order the
+// chain was built in, from the first entry inserted with a timestamp at or
+// after since; that entry is trusted as the anchor. Later entries are all
+// walked whatever their timestamp, since a slow request is logged with its
+// start time after faster ones. The PostgreSQL schema has always hashed
+// entries, so every entry must carry a valid hash.
+func (s *PostgresStore) VerifyAuditChain(ctx context.Context, since time.Time) (bool, string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, api_key_id, endpoint, timestamp, prev_hash, hash
+		FROM audit_logs
+		WHERE seq >= (SELECT MIN(seq) FROM audit_logs WHERE timestamp >= $1)
+		ORDER BY seq`, since)
+	if err != nil {
+		return false, "", err
+	}
+	defer rows.Close()
+
+	var prevHash string
+	first := true
+	for rows.Next() {
+		var l models.AuditLog
+		if err := rows.Scan(&l.ID, &l.APIKeyID, &l.Endpoint, &l.Timestamp, &l.PrevHash, &l.Hash); err != nil {
+			return false, "", err
+		}
+		if !first && l.PrevHash != prevHash {
+			return false, l.ID, nil
+		}
+		if auditHash(l.PrevHash, &l) != l.Hash {
+			return false, l.ID, nil
+		}
+		prevHash = l.Hash
+		first = false
+	}
+	if err := rows.Err(); err != nil {
+		return false, "", err
+	}
+	return true, "", nil
+}
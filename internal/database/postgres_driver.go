@@ -0,0 +1,8 @@
+//go:build postgres
+
+package database
+
+// The PostgreSQL driver is opt-in so that SQLite-only builds do not link it:
+//
+//	go build -tags postgres ./...
+import _ "github.com/lib/pq"
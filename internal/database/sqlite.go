@@ -6,21 +6,45 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/factchecker/verity/internal/models"
+	"github.com/google/uuid"
 	_ "github.com/mattn/go-sqlite3"
 )
 
 // SQLiteStore implements Store using SQLite.
 type SQLiteStore struct {
-	db *sql.DB
+	db   *sql.DB
+	path string
+
+	// auditMu serializes audit log writes so the hash chain stays linear.
+	auditMu sync.Mutex
 }
 
-// NewSQLiteStore creates a new SQLite store.
+// NewSQLiteStore creates a new SQLite store and applies any pending migrations.
 func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	store, err := OpenSQLiteStore(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.Migrate(); err != nil {
+		store.Close()
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+	return store, nil
+}
+
+// OpenSQLiteStore opens a SQLite store without running migrations, so the
+// schema can be inspected with PendingMigrations first.
+func OpenSQLiteStore(path string) (*SQLiteStore, error) {
 	// Ensure directory exists
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -34,93 +58,485 @@ func NewSQLiteStore(path string) (*SQLiteStore, error) {
 
 	// Test connection
 	if err := db.Ping(); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	store := &SQLiteStore{db: db}
-	if err := store.Migrate(); err != nil {
-		return nil, fmt.Errorf("failed to run migrations: %w", err)
-	}
+	return &SQLiteStore{db: db, path: path}, nil
+}
 
-	return store, nil
+// sqliteMigrations creates the schema. Every statement is idempotent.
+var sqliteMigrations = []string{
+	`CREATE TABLE IF NOT EXISTS analysis_results (
+		id TEXT PRIMARY KEY,
+		document_hash TEXT NOT NULL,
+		overall_score REAL NOT NULL,
+		total_claims INTEGER NOT NULL,
+		verified_claims INTEGER NOT NULL,
+		mixed_claims INTEGER NOT NULL,
+		unsupported_claims INTEGER NOT NULL,
+		processing_time_ms INTEGER NOT NULL,
+		status TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		top_claims TEXT NOT NULL DEFAULT '[]'
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_analysis_hash ON analysis_results(document_hash)`,
+	`CREATE TABLE IF NOT EXISTS claims (
+		id TEXT PRIMARY KEY,
+		analysis_id TEXT NOT NULL,
+		text TEXT NOT NULL,
+		type TEXT NOT NULL,
+		sentence_index INTEGER NOT NULL,
+		status TEXT NOT NULL,
+		confidence REAL NOT NULL,
+		source_type TEXT NOT NULL,
+		evidences TEXT NOT NULL,
+		reasoning TEXT,
+		created_at DATETIME NOT NULL,
+		chain_of_thought TEXT NOT NULL DEFAULT '',
+		significance REAL NOT NULL DEFAULT 0,
+		original_sentence TEXT NOT NULL DEFAULT '',
+		sub_type TEXT NOT NULL DEFAULT '',
+		extractability_score REAL NOT NULL DEFAULT 1,
+		is_opinion INTEGER NOT NULL DEFAULT 0,
+		archived INTEGER NOT NULL DEFAULT 0,
+		FOREIGN KEY (analysis_id) REFERENCES analysis_results(id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_claims_analysis ON claims(analysis_id)`,
+	`CREATE TABLE IF NOT EXISTS api_keys (
+		id TEXT PRIMARY KEY,
+		key_hash TEXT UNIQUE NOT NULL,
+		name TEXT NOT NULL,
+		requests_per_minute INTEGER NOT NULL,
+		tokens_per_day INTEGER NOT NULL,
+		created_at DATETIME NOT NULL,
+		last_used_at DATETIME
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_api_keys_hash ON api_keys(key_hash)`,
+	`CREATE TABLE IF NOT EXISTS audit_logs (
+		id TEXT PRIMARY KEY,
+		api_key_id TEXT NOT NULL,
+		endpoint TEXT NOT NULL,
+		method TEXT NOT NULL,
+		request_size INTEGER NOT NULL,
+		response_code INTEGER NOT NULL,
+		duration_ms INTEGER NOT NULL,
+		timestamp DATETIME NOT NULL,
+		prev_hash TEXT NOT NULL DEFAULT '',
+		hash TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_timestamp ON audit_logs(timestamp)`,
+	`CREATE INDEX IF NOT EXISTS idx_analysis_created ON analysis_results(created_at, id)`,
+	`CREATE TABLE IF NOT EXISTS anonymized_results (
+		analysis_id TEXT PRIMARY KEY,
+		data TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		FOREIGN KEY (analysis_id) REFERENCES analysis_results(id)
+	)`,
+	`CREATE TABLE IF NOT EXISTS claim_feedback (
+		id TEXT PRIMARY KEY,
+		claim_id TEXT NOT NULL,
+		analysis_id TEXT NOT NULL,
+		status TEXT NOT NULL,
+		confidence REAL NOT NULL,
+		reasoning TEXT NOT NULL,
+		reviewer_note TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		FOREIGN KEY (claim_id) REFERENCES claims(id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_claim_feedback_claim ON claim_feedback(claim_id)`,
+	`CREATE TABLE IF NOT EXISTS documents (
+		hash TEXT PRIMARY KEY,
+		text TEXT NOT NULL,
+		created_at DATETIME NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_key_timestamp ON audit_logs(api_key_id, timestamp)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_endpoint_code ON audit_logs(endpoint, response_code)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_duration ON audit_logs(duration_ms)`,
+	`CREATE TABLE IF NOT EXISTS config_overrides (
+		key TEXT PRIMARY KEY,
+		value TEXT NOT NULL,
+		updated_at DATETIME NOT NULL,
+		updated_by_key_id TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE TABLE IF NOT EXISTS provider_comparisons (
+		id TEXT PRIMARY KEY,
+		claim_id TEXT NOT NULL,
+		provider_results TEXT NOT NULL,
+		agreement INTEGER NOT NULL,
+		created_at DATETIME NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_provider_comparisons_claim ON provider_comparisons(claim_id)`,
+	`CREATE TABLE IF NOT EXISTS webhook_deliveries (
+		id TEXT PRIMARY KEY,
+		analysis_id TEXT NOT NULL,
+		url TEXT NOT NULL,
+		status TEXT NOT NULL,
+		attempts INTEGER NOT NULL,
+		last_error TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL,
+		delivered_at DATETIME
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_analysis ON webhook_deliveries(analysis_id)`,
+	`CREATE TABLE IF NOT EXISTS contradictions (
+		analysis_id TEXT NOT NULL,
+		claim_id TEXT NOT NULL,
+		conflicting_claim_id TEXT NOT NULL,
+		explanation TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_contradictions_analysis ON contradictions(analysis_id)`,
+	// The rowid of the first audit log of the hash chain; earlier logs were
+	// written before hashing was introduced. See recordAuditChainStart.
+	`CREATE TABLE IF NOT EXISTS audit_chain_start (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		first_rowid INTEGER NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS evidence_items (
+		id TEXT NOT NULL,
+		claim_id TEXT NOT NULL,
+		position INTEGER NOT NULL,
+		source_name TEXT NOT NULL,
+		source_url TEXT NOT NULL,
+		source_type TEXT NOT NULL,
+		fetcher TEXT NOT NULL DEFAULT '',
+		snippet TEXT NOT NULL,
+		content_type TEXT NOT NULL DEFAULT '',
+		relevance_score REAL NOT NULL DEFAULT 0,
+		trust_score REAL NOT NULL DEFAULT 0,
+		retrieved_at DATETIME NOT NULL,
+		is_useful INTEGER NOT NULL DEFAULT 0,
+		usefulness TEXT NOT NULL DEFAULT '',
+		PRIMARY KEY (claim_id, id),
+		FOREIGN KEY (claim_id) REFERENCES claims(id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_evidence_items_url ON evidence_items(source_url)`,
+	// Evidence used to be stored as a JSON array in claims.evidences; move
+	// it to evidence_items. The column is kept, always '[]'.
+	`INSERT OR IGNORE INTO evidence_items (id, claim_id, position, source_name, source_url, source_type, fetcher,
+		snippet, content_type, relevance_score, trust_score, retrieved_at, is_useful, usefulness)
+	SELECT COALESCE(json_extract(e.value, '$.id'), ''), claims.id, e.key,
+		COALESCE(json_extract(e.value, '$.source_name'), ''), COALESCE(json_extract(e.value, '$.source_url'), ''),
+		COALESCE(json_extract(e.value, '$.source_type'), ''), COALESCE(json_extract(e.value, '$.fetcher'), ''),
+		COALESCE(json_extract(e.value, '$.snippet'), ''), COALESCE(json_extract(e.value, '$.content_type'), ''),
+		COALESCE(json_extract(e.value, '$.relevance_score'), 0), COALESCE(json_extract(e.value, '$.trust_score'), 0),
+		COALESCE(json_extract(e.value, '$.retrieved_at'), '0001-01-01T00:00:00Z'),
+		COALESCE(json_extract(e.value, '$.is_useful'), 0), COALESCE(json_extract(e.value, '$.usefulness'), '')
+	FROM claims, json_each(CASE WHEN json_valid(claims.evidences) AND json_type(claims.evidences) = 'array'
+		THEN claims.evidences ELSE '[]' END) AS e`,
+	`UPDATE claims SET evidences = '[]' WHERE evidences <> '[]'`,
+}
+
+// sqliteColumns lists columns added after the initial schema; CREATE TABLE
+// IF NOT EXISTS does not touch existing tables, so they are added explicitly.
+var sqliteColumns = []struct {
+	table, name, definition string
+}{
+	{"audit_logs", "prev_hash", "TEXT NOT NULL DEFAULT ''"},
+	{"audit_logs", "hash", "TEXT NOT NULL DEFAULT ''"},
+	{"claims", "chain_of_thought", "TEXT NOT NULL DEFAULT ''"},
+	{"claims", "significance", "REAL NOT NULL DEFAULT 0"},
+	{"analysis_results", "top_claims", "TEXT NOT NULL DEFAULT '[]'"},
+	{"claims", "original_sentence", "TEXT NOT NULL DEFAULT ''"},
+	{"claims", "sub_type", "TEXT NOT NULL DEFAULT ''"},
+	{"claims", "extractability_score", "REAL NOT NULL DEFAULT 1"},
+	{"claims", "is_opinion", "INTEGER NOT NULL DEFAULT 0"},
+	{"claims", "archived", "INTEGER NOT NULL DEFAULT 0"},
+	{"audit_logs", "details", "TEXT NOT NULL DEFAULT ''"},
+	{"analysis_results", "revery_interval_hours", "INTEGER NOT NULL DEFAULT 0"},
+	{"analysis_results", "last_reverified_at", "DATETIME"},
+	{"claims", "detected_language", "TEXT NOT NULL DEFAULT ''"},
+	{"analysis_results", "score_ci_lower", "REAL NOT NULL DEFAULT 0"},
+	{"analysis_results", "score_ci_upper", "REAL NOT NULL DEFAULT 0"},
+	{"claims", "source_format", "TEXT NOT NULL DEFAULT ''"},
+	{"analysis_results", "avg_claim_length", "REAL NOT NULL DEFAULT 0"},
+	{"analysis_results", "max_claim_length", "INTEGER NOT NULL DEFAULT 0"},
+	{"analysis_results", "min_claim_length", "INTEGER NOT NULL DEFAULT 0"},
+	{"claims", "translated_text", "TEXT NOT NULL DEFAULT ''"},
+	{"analysis_results", "model_source", "TEXT NOT NULL DEFAULT ''"},
+	{"claims", "source_document", "TEXT NOT NULL DEFAULT ''"},
+	{"analysis_results", "jurisdiction", "TEXT NOT NULL DEFAULT ''"},
+	{"claims", "suggested_searches", "TEXT NOT NULL DEFAULT '[]'"},
+	{"audit_logs", "prompt_tokens", "INTEGER NOT NULL DEFAULT 0"},
+	{"audit_logs", "completion_tokens", "INTEGER NOT NULL DEFAULT 0"},
+	{"api_keys", "expires_at", "DATETIME"},
+	{"api_keys", "disabled", "INTEGER NOT NULL DEFAULT 0"},
+	{"api_keys", "role", "TEXT NOT NULL DEFAULT 'admin'"}, // keys predating roles keep full access
+	{"claims", "sentence_indices", "TEXT NOT NULL DEFAULT '[]'"},
+	{"analysis_results", "cache_key", "TEXT NOT NULL DEFAULT ''"},
+	{"analysis_results", "options", "TEXT NOT NULL DEFAULT ''"},
+	{"evidence_items", "published_at", "DATETIME"},
+}
+
+// sqliteFTSMigrations create claims_fts, the full-text index of claim text
+// and reasoning, and the triggers keeping it in sync with claims. They need
+// SQLite built with FTS5 (the sqlite_fts5 build tag).
+var sqliteFTSMigrations = []string{
+	`CREATE VIRTUAL TABLE IF NOT EXISTS claims_fts USING fts5(text, reasoning, content='claims', content_rowid='rowid')`,
+	`CREATE TRIGGER IF NOT EXISTS claims_fts_insert AFTER INSERT ON claims BEGIN
+		INSERT INTO claims_fts (rowid, text, reasoning) VALUES (new.rowid, new.text, new.reasoning);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS claims_fts_delete AFTER DELETE ON claims BEGIN
+		INSERT INTO claims_fts (claims_fts, rowid, text, reasoning) VALUES ('delete', old.rowid, old.text, old.reasoning);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS claims_fts_update AFTER UPDATE OF text, reasoning ON claims BEGIN
+		INSERT INTO claims_fts (claims_fts, rowid, text, reasoning) VALUES ('delete', old.rowid, old.text, old.reasoning);
+		INSERT INTO claims_fts (rowid, text, reasoning) VALUES (new.rowid, new.text, new.reasoning);
+	END`,
 }
 
 // Migrate runs database migrations.
 func (s *SQLiteStore) Migrate() error {
-	migrations := []string{
-		`CREATE TABLE IF NOT EXISTS analysis_results (
-			id TEXT PRIMARY KEY,
-			document_hash TEXT NOT NULL,
-			overall_score REAL NOT NULL,
-			total_claims INTEGER NOT NULL,
-			verified_claims INTEGER NOT NULL,
-			mixed_claims INTEGER NOT NULL,
-			unsupported_claims INTEGER NOT NULL,
-			processing_time_ms INTEGER NOT NULL,
-			status TEXT NOT NULL,
-			created_at DATETIME NOT NULL
-		)`,
-		`CREATE INDEX IF NOT EXISTS idx_analysis_hash ON analysis_results(document_hash)`,
-		`CREATE TABLE IF NOT EXISTS claims (
-			id TEXT PRIMARY KEY,
-			analysis_id TEXT NOT NULL,
-			text TEXT NOT NULL,
-			type TEXT NOT NULL,
-			sentence_index INTEGER NOT NULL,
-			status TEXT NOT NULL,
-			confidence REAL NOT NULL,
-			source_type TEXT NOT NULL,
-			evidences TEXT NOT NULL,
-			reasoning TEXT,
-			created_at DATETIME NOT NULL,
-			FOREIGN KEY (analysis_id) REFERENCES analysis_results(id)
-		)`,
-		`CREATE INDEX IF NOT EXISTS idx_claims_analysis ON claims(analysis_id)`,
-		`CREATE TABLE IF NOT EXISTS api_keys (
-			id TEXT PRIMARY KEY,
-			key_hash TEXT UNIQUE NOT NULL,
-			name TEXT NOT NULL,
-			requests_per_minute INTEGER NOT NULL,
-			tokens_per_day INTEGER NOT NULL,
-			created_at DATETIME NOT NULL,
-			last_used_at DATETIME
-		)`,
-		`CREATE INDEX IF NOT EXISTS idx_api_keys_hash ON api_keys(key_hash)`,
-		`CREATE TABLE IF NOT EXISTS audit_logs (
-			id TEXT PRIMARY KEY,
-			api_key_id TEXT NOT NULL,
-			endpoint TEXT NOT NULL,
-			method TEXT NOT NULL,
-			request_size INTEGER NOT NULL,
-			response_code INTEGER NOT NULL,
-			duration_ms INTEGER NOT NULL,
-			timestamp DATETIME NOT NULL
-		)`,
-		`CREATE INDEX IF NOT EXISTS idx_audit_timestamp ON audit_logs(timestamp)`,
-	}
-
-	for _, m := range migrations {
+	for _, m := range sqliteMigrations {
 		if _, err := s.db.Exec(m); err != nil {
 			return fmt.Errorf("migration failed: %w", err)
 		}
 	}
+
+	for _, c := range sqliteColumns {
+		if err := s.addColumnIfMissing(c.table, c.name, c.definition); err != nil {
+			return fmt.Errorf("migration failed: %w", err)
+		}
+	}
+
+	if err := s.recordAuditChainStart(); err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
+
+	if err := s.migrateFTS(); err != nil {
+		return fmt.Errorf("migration failed: %w", err)
+	}
 	return nil
 }
 
+// recordAuditChainStart records, once, the rowid where the audit hash chain
+// starts: the first hashed log, or the next log when none is hashed yet.
+// Unhashed logs before it predate hashing; any after it are tampered with.
+func (s *SQLiteStore) recordAuditChainStart() error {
+	_, err := s.db.Exec(`
+		INSERT OR IGNORE INTO audit_chain_start (id, first_rowid)
+		SELECT 1, COALESCE(
+			(SELECT MIN(rowid) FROM audit_logs WHERE hash <> ''),
+			(SELECT COALESCE(MAX(rowid), 0) + 1 FROM audit_logs))`)
+	return err
+}
+
+// migrateFTS creates the full-text index of claims, filling it from
+// existing claims when it is new. Without FTS5 nothing is created and
+// SearchClaims returns ErrSearchUnavailable.
+func (s *SQLiteStore) migrateFTS() error {
+	var exists int
+	if err := s.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'claims_fts'`).Scan(&exists); err != nil {
+		return err
+	}
+
+	for _, m := range sqliteFTSMigrations {
+		if _, err := s.db.Exec(m); err != nil {
+			if strings.Contains(err.Error(), "no such module: fts5") {
+				return nil
+			}
+			return err
+		}
+	}
+
+	if exists == 0 {
+		_, err := s.db.Exec(`INSERT INTO claims_fts (claims_fts) VALUES ('rebuild')`)
+		return err
+	}
+	return nil
+}
+
+// addColumnIfMissing adds a column to an existing table unless it is already present.
+func (s *SQLiteStore) addColumnIfMissing(table, column, definition string) error {
+	exists, err := s.hasColumn(table, column)
+	if err != nil || exists {
+		return err
+	}
+
+	_, err = s.db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column, definition))
+	return err
+}
+
+// hasColumn reports whether a table has a column. A missing table has none.
+func (s *SQLiteStore) hasColumn(table, column string) (bool, error) {
+	rows, err := s.db.Query(fmt.Sprintf(`PRAGMA table_info(%s)`, table))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid        int
+			name, typ  string
+			notNull    int
+			defaultVal sql.NullString
+			pk         int
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &defaultVal, &pk); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
+
+// migrationObject extracts the table or index name from a CREATE statement.
+var migrationObject = regexp.MustCompile(`(?i)CREATE\s+(TABLE|INDEX)\s+IF\s+NOT\s+EXISTS\s+(\w+)`)
+
+// PendingMigrations lists the tables, indexes and columns Migrate would create.
+func (s *SQLiteStore) PendingMigrations(ctx context.Context) ([]string, error) {
+	var pending []string
+	tables := make(map[string]bool)
+
+	for _, m := range sqliteMigrations {
+		match := migrationObject.FindStringSubmatch(m)
+		if match == nil {
+			continue
+		}
+		kind, name := strings.ToLower(match[1]), match[2]
+
+		var count int
+		err := s.db.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM sqlite_master WHERE type = ? AND name = ?`, kind, name,
+		).Scan(&count)
+		if err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			pending = append(pending, fmt.Sprintf("create %s %s", kind, name))
+			continue
+		}
+		if kind == "table" {
+			tables[name] = true
+		}
+	}
+
+	// Columns of tables that do not exist yet come with the CREATE TABLE.
+	for _, c := range sqliteColumns {
+		if !tables[c.table] {
+			continue
+		}
+		exists, err := s.hasColumn(c.table, c.name)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			pending = append(pending, fmt.Sprintf("add column %s.%s", c.table, c.name))
+		}
+	}
+	return pending, nil
+}
+
+// Ping checks that the database is reachable.
+func (s *SQLiteStore) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
 // Close closes the database connection.
 func (s *SQLiteStore) Close() error {
 	return s.db.Close()
 }
 
+// HealthStats returns table counts and file sizes. The database size is
+// page_count * page_size; the WAL size is that of the -wal file, which
+// SQLite does not report through a pragma.
+func (s *SQLiteStore) HealthStats(ctx context.Context) (*models.DBHealthStats, error) {
+	stats := &models.DBHealthStats{CollectedAt: time.Now()}
+
+	var pageCount, pageSize int64
+	if err := s.db.QueryRowContext(ctx, `PRAGMA page_count`).Scan(&pageCount); err != nil {
+		return nil, err
+	}
+	if err := s.db.QueryRowContext(ctx, `PRAGMA page_size`).Scan(&pageSize); err != nil {
+		return nil, err
+	}
+	stats.DBSizeBytes = pageCount * pageSize
+	if info, err := os.Stat(s.path + "-wal"); err == nil {
+		stats.WALSizeBytes = info.Size()
+	}
+
+	counts := []struct {
+		query string
+		dest  *int
+	}{
+		{`SELECT COUNT(*) FROM analysis_results`, &stats.AnalysisCount},
+		{`SELECT COUNT(*) FROM claims WHERE archived = 0`, &stats.ClaimCount},
+		{`SELECT COUNT(*) FROM api_keys`, &stats.APIKeyCount},
+		{`SELECT COUNT(*) FROM audit_logs`, &stats.AuditLogCount},
+	}
+	for _, c := range counts {
+		if err := s.db.QueryRowContext(ctx, c.query).Scan(c.dest); err != nil {
+			return nil, err
+		}
+	}
+	if stats.AnalysisCount == 0 {
+		return stats, nil
+	}
+	stats.AvgClaimsPerAnalysis = float64(stats.ClaimCount) / float64(stats.AnalysisCount)
+
+	// Selecting the column itself, rather than MIN/MAX, keeps its DATETIME
+	// type so the driver returns a time.Time
+	var oldest, newest time.Time
+	if err := s.db.QueryRowContext(ctx, `SELECT created_at FROM analysis_results ORDER BY created_at ASC LIMIT 1`).Scan(&oldest); err != nil {
+		return nil, err
+	}
+	if err := s.db.QueryRowContext(ctx, `SELECT created_at FROM analysis_results ORDER BY created_at DESC LIMIT 1`).Scan(&newest); err != nil {
+		return nil, err
+	}
+	stats.OldestAnalysisCreatedAt = &oldest
+	stats.NewestAnalysisCreatedAt = &newest
+	return stats, nil
+}
+
+// analysisColumns lists analysis_results columns in the order scanAnalysis expects.
+const analysisColumns = `id, document_hash, overall_score, total_claims, verified_claims, mixed_claims,
+	unsupported_claims, processing_time_ms, status, created_at, top_claims, revery_interval_hours, last_reverified_at,
+	score_ci_lower, score_ci_upper, avg_claim_length, max_claim_length, min_claim_length, model_source,
+	jurisdiction, options`
+
+// rowScanner is implemented by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanAnalysis scans a row selected with analysisColumns.
+func scanAnalysis(row rowScanner) (*models.AnalysisResult, error) {
+	var r models.AnalysisResult
+	var topClaimsJSON string
+	var reVerifyHours int
+	if err := row.Scan(&r.ID, &r.DocumentHash, &r.OverallScore, &r.TotalClaims,
+		&r.VerifiedClaims, &r.MixedClaims, &r.UnsupportedClaims,
+		&r.ProcessingTimeMs, &r.Status, &r.CreatedAt, &topClaimsJSON,
+		&reVerifyHours, &r.LastReVerifiedAt, &r.ScoreInterval.Lower, &r.ScoreInterval.Upper,
+		&r.AvgClaimLength, &r.MaxClaimLength, &r.MinClaimLength, &r.ModelSource,
+		&r.Jurisdiction, &r.Options); err != nil {
+		return nil, err
+	}
+	json.Unmarshal([]byte(topClaimsJSON), &r.TopClaims)
+	if reVerifyHours > 0 {
+		every := time.Duration(reVerifyHours) * time.Hour
+		r.ScheduleReVerifyEvery = &every
+	}
+	return &r, nil
+}
+
 // SaveAnalysis stores an analysis result.
 func (s *SQLiteStore) SaveAnalysis(ctx context.Context, result *models.AnalysisResult) error {
+	topClaimsJSON, _ := json.Marshal(result.TopClaims)
 	_, err := s.db.ExecContext(ctx, `
 		INSERT INTO analysis_results (id, document_hash, overall_score, total_claims, verified_claims,
-			mixed_claims, unsupported_claims, processing_time_ms, status, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			mixed_claims, unsupported_claims, processing_time_ms, status, created_at, top_claims,
+			score_ci_lower, score_ci_upper, avg_claim_length, max_claim_length, min_claim_length, model_source,
+			jurisdiction, cache_key, options)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		result.ID, result.DocumentHash, result.OverallScore, result.TotalClaims,
 		result.VerifiedClaims, result.MixedClaims, result.UnsupportedClaims,
-		result.ProcessingTimeMs, result.Status, result.CreatedAt,
+		result.ProcessingTimeMs, result.Status, result.CreatedAt, string(topClaimsJSON),
+		result.ScoreInterval.Lower, result.ScoreInterval.Upper,
+		result.AvgClaimLength, result.MaxClaimLength, result.MinClaimLength, result.ModelSource,
+		result.Jurisdiction, result.CacheKey, result.Options,
 	)
 	return err
 }
@@ -128,49 +544,92 @@ func (s *SQLiteStore) SaveAnalysis(ctx context.Context, result *models.AnalysisR
 // GetAnalysis retrieves an analysis by ID.
 func (s *SQLiteStore) GetAnalysis(ctx context.Context, id string) (*models.AnalysisResult, error) {
 	row := s.db.QueryRowContext(ctx, `
-		SELECT id, document_hash, overall_score, total_claims, verified_claims, mixed_claims,
-			unsupported_claims, processing_time_ms, status, created_at
+		SELECT `+analysisColumns+`
 		FROM analysis_results WHERE id = ?`, id)
 
-	var result models.AnalysisResult
-	err := row.Scan(&result.ID, &result.DocumentHash, &result.OverallScore, &result.TotalClaims,
-		&result.VerifiedClaims, &result.MixedClaims, &result.UnsupportedClaims,
-		&result.ProcessingTimeMs, &result.Status, &result.CreatedAt)
+	result, err := scanAnalysis(row)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	return result, nil
 }
 
-// GetAnalysisByHash retrieves an analysis by document hash.
-func (s *SQLiteStore) GetAnalysisByHash(ctx context.Context, hash string) (*models.AnalysisResult, error) {
+// GetAnalysisByHash retrieves the latest analysis of a document made with
+// the options identified by cacheKey.
+func (s *SQLiteStore) GetAnalysisByHash(ctx context.Context, hash, cacheKey string) (*models.AnalysisResult, error) {
 	row := s.db.QueryRowContext(ctx, `
-		SELECT id, document_hash, overall_score, total_claims, verified_claims, mixed_claims,
-			unsupported_claims, processing_time_ms, status, created_at
-		FROM analysis_results WHERE document_hash = ? ORDER BY created_at DESC LIMIT 1`, hash)
-
-	var result models.AnalysisResult
-	err := row.Scan(&result.ID, &result.DocumentHash, &result.OverallScore, &result.TotalClaims,
-		&result.VerifiedClaims, &result.MixedClaims, &result.UnsupportedClaims,
-		&result.ProcessingTimeMs, &result.Status, &result.CreatedAt)
+		SELECT `+analysisColumns+`
+		FROM analysis_results WHERE document_hash = ? AND cache_key = ?
+		ORDER BY created_at DESC LIMIT 1`, hash, cacheKey)
+
+	result, err := scanAnalysis(row)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, err
 	}
-	return &result, nil
+	return result, nil
+}
+
+// ListAnalyses returns paginated analysis results matching filter, newest
+// first. If after is set, keyset pagination is used instead of offset.
+func (s *SQLiteStore) ListAnalyses(ctx context.Context, filter AnalysisFilter, limit, offset int, after *Cursor) ([]*models.AnalysisResult, error) {
+	var where []string
+	var args []interface{}
+	if filter.ModelSource != "" {
+		where = append(where, "model_source = ?")
+		args = append(args, filter.ModelSource)
+	}
+	if after != nil {
+		where = append(where, "(created_at < ? OR (created_at = ? AND id < ?))")
+		args = append(args, after.CreatedAt, after.CreatedAt, after.ID)
+		offset = 0
+	}
+	query := `SELECT ` + analysisColumns + ` FROM analysis_results`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	query += ` ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`
+	args = append(args, limit, offset)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*models.AnalysisResult
+	for rows.Next() {
+		r, err := scanAnalysis(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// SetReVerifyInterval schedules periodic re-verification of an analysis,
+// rounded to whole hours. A nil or zero interval disables it.
+func (s *SQLiteStore) SetReVerifyInterval(ctx context.Context, id string, every *time.Duration) error {
+	hours := 0
+	if every != nil {
+		hours = int(every.Hours())
+	}
+	_, err := s.db.ExecContext(ctx, `UPDATE analysis_results SET revery_interval_hours = ? WHERE id = ?`, hours, id)
+	return err
 }
 
-// ListAnalyses returns paginated analysis results.
-func (s *SQLiteStore) ListAnalyses(ctx context.Context, limit, offset int) ([]*models.AnalysisResult, error) {
+// ListScheduledReVerifications returns all analyses with a re-verification
+// interval set.
+func (s *SQLiteStore) ListScheduledReVerifications(ctx context.Context) ([]*models.AnalysisResult, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, document_hash, overall_score, total_claims, verified_claims, mixed_claims,
-			unsupported_claims, processing_time_ms, status, created_at
-		FROM analysis_results ORDER BY created_at DESC LIMIT ? OFFSET ?`, limit, offset)
+		SELECT `+analysisColumns+`
+		FROM analysis_results WHERE revery_interval_hours > 0`)
 	if err != nil {
 		return nil, err
 	}
@@ -178,17 +637,93 @@ func (s *SQLiteStore) ListAnalyses(ctx context.Context, limit, offset int) ([]*m
 
 	var results []*models.AnalysisResult
 	for rows.Next() {
-		var r models.AnalysisResult
-		if err := rows.Scan(&r.ID, &r.DocumentHash, &r.OverallScore, &r.TotalClaims,
-			&r.VerifiedClaims, &r.MixedClaims, &r.UnsupportedClaims,
-			&r.ProcessingTimeMs, &r.Status, &r.CreatedAt); err != nil {
+		r, err := scanAnalysis(rows)
+		if err != nil {
 			return nil, err
 		}
-		results = append(results, &r)
+		results = append(results, r)
 	}
 	return results, rows.Err()
 }
 
+// SaveReVerification stores new verdicts for existing claims of an
+// analysis, recomputes its scores and records when it was re-verified, in
+// a single transaction.
+func (s *SQLiteStore) SaveReVerification(ctx context.Context, analysisID string, claims []models.Claim, at time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, c := range claims {
+		res, err := tx.ExecContext(ctx, `
+			UPDATE claims SET status = ?, confidence = ?, reasoning = ?, chain_of_thought = ?, source_type = ?
+			WHERE id = ? AND analysis_id = ?`,
+			c.Status, c.Confidence, c.Reasoning, c.ChainOfThought, c.SourceType,
+			c.ID, analysisID)
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM evidence_items WHERE claim_id = ?`, c.ID); err != nil {
+			return err
+		}
+		if err := insertEvidence(ctx, tx, c.ID, c.Evidences); err != nil {
+			return err
+		}
+	}
+	if err := recomputeAnalysis(ctx, tx, analysisID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE analysis_results SET last_reverified_at = ? WHERE id = ?`, at, analysisID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// scoreTrendBuckets maps a granularity to the SQLite expression that labels
+// each analysis with its bucket. Weeks are labelled by their Monday.
+var scoreTrendBuckets = map[string]string{
+	"hour":  `strftime('%Y-%m-%dT%H:00', created_at)`,
+	"day":   `date(created_at)`,
+	"week":  `date(created_at, 'weekday 0', '-6 days')`,
+	"month": `strftime('%Y-%m', created_at)`,
+}
+
+// GetScoreTrend aggregates analysis scores per time bucket for analyses
+// created in [since, until). Buckets are computed in UTC.
+func (s *SQLiteStore) GetScoreTrend(ctx context.Context, granularity string, since, until time.Time) ([]*models.ScoreTrendPoint, error) {
+	bucket, ok := scoreTrendBuckets[granularity]
+	if !ok {
+		return nil, fmt.Errorf("unsupported granularity: %s", granularity)
+	}
+
+	const layout = "2006-01-02 15:04:05"
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+bucket+` AS bucket, COUNT(*), AVG(overall_score), MIN(overall_score), MAX(overall_score)
+		FROM analysis_results
+		WHERE datetime(created_at) >= ? AND datetime(created_at) < ?
+		GROUP BY bucket ORDER BY bucket`,
+		since.UTC().Format(layout), until.UTC().Format(layout))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []*models.ScoreTrendPoint
+	for rows.Next() {
+		var p models.ScoreTrendPoint
+		if err := rows.Scan(&p.Date, &p.Count, &p.AvgScore, &p.MinScore, &p.MaxScore); err != nil {
+			return nil, err
+		}
+		points = append(points, &p)
+	}
+	return points, rows.Err()
+}
+
 // SaveClaims stores claims for an analysis.
 func (s *SQLiteStore) SaveClaims(ctx context.Context, analysisID string, claims []models.Claim) error {
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -197,33 +732,131 @@ func (s *SQLiteStore) SaveClaims(ctx context.Context, analysisID string, claims
 	}
 	defer tx.Rollback()
 
+	if err := insertClaims(ctx, tx, analysisID, claims); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertClaims inserts claims for an analysis within a transaction.
+func insertClaims(ctx context.Context, tx *sql.Tx, analysisID string, claims []models.Claim) error {
 	stmt, err := tx.PrepareContext(ctx, `
 		INSERT INTO claims (id, analysis_id, text, type, sentence_index, status, confidence,
-			source_type, evidences, reasoning, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+			source_type, evidences, reasoning, created_at, chain_of_thought, significance, original_sentence,
+			sub_type, extractability_score, is_opinion, detected_language, source_format, translated_text,
+			source_document, suggested_searches, sentence_indices)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, '[]', ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
 	for _, claim := range claims {
-		evidencesJSON, _ := json.Marshal(claim.Evidences)
+		suggestedJSON, _ := json.Marshal(claim.SuggestedSearches)
+		indicesJSON, _ := json.Marshal(claim.SentenceIndices)
 		_, err := stmt.ExecContext(ctx, claim.ID, analysisID, claim.Text, claim.Type,
 			claim.SentenceIndex, claim.Status, claim.Confidence, claim.SourceType,
-			string(evidencesJSON), claim.Reasoning, claim.CreatedAt)
+			claim.Reasoning, claim.CreatedAt, claim.ChainOfThought, claim.Significance,
+			claim.OriginalSentence, claim.SubType, claim.ExtractabilityScore, claim.IsOpinion, claim.DetectedLanguage,
+			claim.SourceFormat, claim.TranslatedText, claim.SourceDocument, string(suggestedJSON), string(indicesJSON))
 		if err != nil {
 			return err
 		}
+		if err := insertEvidence(ctx, tx, claim.ID, claim.Evidences); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
+// insertEvidence inserts the evidence of a claim within a transaction,
+// keeping its order.
+func insertEvidence(ctx context.Context, tx *sql.Tx, claimID string, evidences []models.Evidence) error {
+	for i, e := range evidences {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO evidence_items (id, claim_id, position, source_name, source_url, source_type, fetcher,
+			snippet, content_type, relevance_score, trust_score, retrieved_at, is_useful, usefulness, published_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			e.ID, claimID, i, e.SourceName, e.SourceURL, e.SourceType, e.Fetcher,
+			e.Snippet, e.ContentType, e.RelevanceScore, e.TrustScore, e.RetrievedAt, e.IsUseful, e.Usefulness, e.PublishedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplaceClaims archives claims of an analysis, stores new ones and their
+// contradictions and recomputes the analysis scores in a single
+// transaction. Archived claims are kept for reference but no longer
+// returned or counted, and their contradictions are dropped. Claims
+// involved in one of the new contradictions are marked mixed.
+func (s *SQLiteStore) ReplaceClaims(ctx context.Context, analysisID string, archiveIDs []string, added []models.Claim, contradictions []models.Contradiction) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, id := range archiveIDs {
+		if _, err := tx.ExecContext(ctx, `UPDATE claims SET archived = 1 WHERE id = ? AND analysis_id = ?`, id, analysisID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			DELETE FROM contradictions WHERE analysis_id = ? AND (claim_id = ? OR conflicting_claim_id = ?)`,
+			analysisID, id, id); err != nil {
+			return err
+		}
+	}
+	if err := insertClaims(ctx, tx, analysisID, added); err != nil {
+		return err
+	}
+	for _, c := range contradictions {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO contradictions (analysis_id, claim_id, conflicting_claim_id, explanation)
+			VALUES (?, ?, ?, ?)`,
+			analysisID, c.ClaimID, c.ConflictingClaimID, c.Explanation); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE claims SET status = ? WHERE analysis_id = ? AND id IN (?, ?)`,
+			models.StatusMixed, analysisID, c.ClaimID, c.ConflictingClaimID); err != nil {
+			return err
+		}
+	}
+	if err := recomputeAnalysis(ctx, tx, analysisID); err != nil {
+		return err
+	}
 	return tx.Commit()
 }
 
-// GetClaimsByAnalysis retrieves all claims for an analysis.
+// SaveDocument stores the text of a verified document, keyed by its hash.
+// Documents already stored are left unchanged.
+func (s *SQLiteStore) SaveDocument(ctx context.Context, hash, text string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT OR IGNORE INTO documents (hash, text, created_at) VALUES (?, ?, ?)`,
+		hash, text, time.Now())
+	return err
+}
+
+// GetDocumentByHash retrieves the text of a document by its hash.
+func (s *SQLiteStore) GetDocumentByHash(ctx context.Context, hash string) (string, error) {
+	var text string
+	err := s.db.QueryRowContext(ctx, `SELECT text FROM documents WHERE hash = ?`, hash).Scan(&text)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return text, err
+}
+
+// GetClaimsByAnalysis retrieves all claims for an analysis, excluding archived ones.
 func (s *SQLiteStore) GetClaimsByAnalysis(ctx context.Context, analysisID string) ([]models.Claim, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, text, type, sentence_index, status, confidence, source_type, evidences, reasoning, created_at
-		FROM claims WHERE analysis_id = ? ORDER BY sentence_index`, analysisID)
+		SELECT id, text, type, sentence_index, status, confidence, source_type, reasoning, created_at,
+			chain_of_thought, significance, original_sentence, sub_type,
+			extractability_score, is_opinion, detected_language, source_format, translated_text, source_document,
+			suggested_searches, sentence_indices
+		FROM claims WHERE analysis_id = ? AND archived = 0 ORDER BY sentence_index`, analysisID)
 	if err != nil {
 		return nil, err
 	}
@@ -232,35 +865,497 @@ func (s *SQLiteStore) GetClaimsByAnalysis(ctx context.Context, analysisID string
 	var claims []models.Claim
 	for rows.Next() {
 		var c models.Claim
-		var evidencesJSON string
+		var suggestedJSON, indicesJSON string
 		if err := rows.Scan(&c.ID, &c.Text, &c.Type, &c.SentenceIndex, &c.Status,
-			&c.Confidence, &c.SourceType, &evidencesJSON, &c.Reasoning, &c.CreatedAt); err != nil {
+			&c.Confidence, &c.SourceType, &c.Reasoning, &c.CreatedAt,
+			&c.ChainOfThought, &c.Significance, &c.OriginalSentence, &c.SubType,
+			&c.ExtractabilityScore, &c.IsOpinion, &c.DetectedLanguage, &c.SourceFormat, &c.TranslatedText,
+			&c.SourceDocument, &suggestedJSON, &indicesJSON); err != nil {
 			return nil, err
 		}
-		json.Unmarshal([]byte(evidencesJSON), &c.Evidences)
+		json.Unmarshal([]byte(suggestedJSON), &c.SuggestedSearches)
+		json.Unmarshal([]byte(indicesJSON), &c.SentenceIndices)
 		claims = append(claims, c)
 	}
-	return claims, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	evidenceRows, err := s.db.QueryContext(ctx, `
+		SELECT `+evidenceColumns+`
+		FROM evidence_items e JOIN claims c ON c.id = e.claim_id
+		WHERE c.analysis_id = ? AND c.archived = 0
+		ORDER BY e.claim_id, e.position`, analysisID)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachEvidence(evidenceRows, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// GetClaim retrieves a single claim, archived or not, along with the ID of
+// the analysis it belongs to.
+func (s *SQLiteStore) GetClaim(ctx context.Context, id string) (*models.Claim, string, error) {
+	var c models.Claim
+	var analysisID, suggestedJSON, indicesJSON string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT id, analysis_id, text, type, sentence_index, status, confidence, source_type, reasoning,
+			created_at, chain_of_thought, significance, original_sentence, sub_type,
+			extractability_score, is_opinion, detected_language, source_format, translated_text, source_document,
+			suggested_searches, sentence_indices
+		FROM claims WHERE id = ?`, id).Scan(&c.ID, &analysisID, &c.Text, &c.Type, &c.SentenceIndex, &c.Status,
+		&c.Confidence, &c.SourceType, &c.Reasoning, &c.CreatedAt,
+		&c.ChainOfThought, &c.Significance, &c.OriginalSentence, &c.SubType,
+		&c.ExtractabilityScore, &c.IsOpinion, &c.DetectedLanguage, &c.SourceFormat, &c.TranslatedText,
+		&c.SourceDocument, &suggestedJSON, &indicesJSON)
+	if err == sql.ErrNoRows {
+		return nil, "", nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	json.Unmarshal([]byte(suggestedJSON), &c.SuggestedSearches)
+	json.Unmarshal([]byte(indicesJSON), &c.SentenceIndices)
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+evidenceColumns+`
+		FROM evidence_items e WHERE e.claim_id = ? ORDER BY e.position`, id)
+	if err != nil {
+		return nil, "", err
+	}
+	claims := []models.Claim{c}
+	if err := attachEvidence(rows, claims); err != nil {
+		return nil, "", err
+	}
+	return &claims[0], analysisID, nil
+}
+
+// SearchClaims returns the claims whose text or reasoning matches an FTS5
+// query, best match first, with their evidence and the ID of their analysis.
+// Archived claims are excluded.
+func (s *SQLiteStore) SearchClaims(ctx context.Context, query string, limit, offset int) ([]models.Claim, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT c.id, c.analysis_id, c.text, c.type, c.sentence_index, c.status, c.confidence, c.source_type,
+			c.reasoning, c.created_at, c.chain_of_thought, c.significance, c.original_sentence, c.sub_type,
+			c.extractability_score, c.is_opinion, c.detected_language, c.source_format, c.translated_text,
+			c.source_document, c.suggested_searches, c.sentence_indices
+		FROM claims_fts JOIN claims c ON c.rowid = claims_fts.rowid
+		WHERE claims_fts MATCH ? AND c.archived = 0
+		ORDER BY claims_fts.rank LIMIT ? OFFSET ?`, query, limit, offset)
+	if err != nil {
+		return nil, searchError(err)
+	}
+	defer rows.Close()
+
+	claims := []models.Claim{}
+	for rows.Next() {
+		var c models.Claim
+		var suggestedJSON, indicesJSON string
+		if err := rows.Scan(&c.ID, &c.AnalysisID, &c.Text, &c.Type, &c.SentenceIndex, &c.Status,
+			&c.Confidence, &c.SourceType, &c.Reasoning, &c.CreatedAt,
+			&c.ChainOfThought, &c.Significance, &c.OriginalSentence, &c.SubType,
+			&c.ExtractabilityScore, &c.IsOpinion, &c.DetectedLanguage, &c.SourceFormat, &c.TranslatedText,
+			&c.SourceDocument, &suggestedJSON, &indicesJSON); err != nil {
+			return nil, err
+		}
+		json.Unmarshal([]byte(suggestedJSON), &c.SuggestedSearches)
+		json.Unmarshal([]byte(indicesJSON), &c.SentenceIndices)
+		claims = append(claims, c)
+	}
+	// FTS5 parses the query when the statement first steps, so syntax
+	// errors usually surface here rather than from QueryContext
+	if err := rows.Err(); err != nil {
+		return nil, searchError(err)
+	}
+	if len(claims) == 0 {
+		return claims, nil
+	}
+
+	placeholders := make([]string, len(claims))
+	args := make([]interface{}, len(claims))
+	for i, c := range claims {
+		placeholders[i] = "?"
+		args[i] = c.ID
+	}
+	evidenceRows, err := s.db.QueryContext(ctx, `
+		SELECT `+evidenceColumns+`
+		FROM evidence_items e WHERE e.claim_id IN (`+strings.Join(placeholders, ", ")+`)
+		ORDER BY e.claim_id, e.position`, args...)
+	if err != nil {
+		return nil, err
+	}
+	if err := attachEvidence(evidenceRows, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// searchError maps SQLite errors from a claim search to ErrSearchUnavailable
+// or ErrInvalidSearchQuery.
+func searchError(err error) error {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "no such table: claims_fts"):
+		return ErrSearchUnavailable
+	case strings.Contains(msg, "fts5:"), strings.Contains(msg, "unterminated string"),
+		strings.Contains(msg, "no such column"):
+		return fmt.Errorf("%w: %v", ErrInvalidSearchQuery, err)
+	}
+	return err
+}
+
+// GetEvidenceByURL returns every piece of evidence retrieved from url,
+// with the claim it was found for, most recent first.
+func (s *SQLiteStore) GetEvidenceByURL(ctx context.Context, url string) ([]models.Evidence, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+evidenceColumns+`
+		FROM evidence_items e WHERE e.source_url = ? ORDER BY e.retrieved_at DESC`, url)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var evidences []models.Evidence
+	for rows.Next() {
+		claimID, e, err := scanEvidence(rows)
+		if err != nil {
+			return nil, err
+		}
+		e.ClaimID = claimID
+		evidences = append(evidences, e)
+	}
+	return evidences, rows.Err()
+}
+
+// SaveProviderComparison stores the result of re-verifying a claim with
+// several providers.
+func (s *SQLiteStore) SaveProviderComparison(ctx context.Context, c *models.ProviderComparison) error {
+	resultsJSON, _ := json.Marshal(c.ProviderResults)
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO provider_comparisons (id, claim_id, provider_results, agreement, created_at)
+		VALUES (?, ?, ?, ?, ?)`,
+		c.ID, c.ClaimID, string(resultsJSON), c.Agreement, c.CreatedAt)
+	return err
+}
+
+// SaveContradictions stores the contradictions found between claims of an
+// analysis.
+func (s *SQLiteStore) SaveContradictions(ctx context.Context, analysisID string, contradictions []models.Contradiction) error {
+	if len(contradictions) == 0 {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, c := range contradictions {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO contradictions (analysis_id, claim_id, conflicting_claim_id, explanation)
+			VALUES (?, ?, ?, ?)`,
+			analysisID, c.ClaimID, c.ConflictingClaimID, c.Explanation); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetContradictionsByAnalysis retrieves the contradictions between claims
+// of an analysis.
+func (s *SQLiteStore) GetContradictionsByAnalysis(ctx context.Context, analysisID string) ([]models.Contradiction, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT claim_id, conflicting_claim_id, explanation
+		FROM contradictions WHERE analysis_id = ?`, analysisID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contradictions []models.Contradiction
+	for rows.Next() {
+		var c models.Contradiction
+		if err := rows.Scan(&c.ClaimID, &c.ConflictingClaimID, &c.Explanation); err != nil {
+			return nil, err
+		}
+		contradictions = append(contradictions, c)
+	}
+	return contradictions, rows.Err()
+}
+
+// SaveWebhookDelivery records a webhook delivery, replacing its earlier
+// state when it is retried.
+func (s *SQLiteStore) SaveWebhookDelivery(ctx context.Context, d *models.WebhookDelivery) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (id, analysis_id, url, status, attempts, last_error, created_at, delivered_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			status = excluded.status,
+			attempts = excluded.attempts,
+			last_error = excluded.last_error,
+			delivered_at = excluded.delivered_at`,
+		d.ID, d.AnalysisID, d.URL, string(d.Status), d.Attempts, d.LastError, d.CreatedAt, d.DeliveredAt)
+	return err
+}
+
+// BulkUpdateClaims applies reviewer corrections in a single transaction,
+// records each one in claim_feedback and recomputes the scores of every
+// affected analysis. Unknown claim IDs are skipped.
+func (s *SQLiteStore) BulkUpdateClaims(ctx context.Context, updates []models.ClaimUpdate) (int, int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	updated := 0
+	affected := make(map[string]bool)
+	for _, u := range updates {
+		var analysisID, reasoning string
+		var confidence float64
+		err := tx.QueryRowContext(ctx, `SELECT analysis_id, confidence, COALESCE(reasoning, '') FROM claims WHERE id = ?`, u.ID).
+			Scan(&analysisID, &confidence, &reasoning)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if u.Confidence != nil {
+			confidence = *u.Confidence
+		}
+		if u.Reasoning != "" {
+			reasoning = u.Reasoning
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE claims SET status = ?, confidence = ?, reasoning = ? WHERE id = ?`,
+			u.Status, confidence, reasoning, u.ID); err != nil {
+			return 0, 0, err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO claim_feedback (id, claim_id, analysis_id, status, confidence, reasoning, reviewer_note, created_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			uuid.New().String(), u.ID, analysisID, u.Status, confidence, reasoning, u.ReviewerNote, now); err != nil {
+			return 0, 0, err
+		}
+
+		updated++
+		affected[analysisID] = true
+	}
+
+	for analysisID := range affected {
+		if err := recomputeAnalysis(ctx, tx, analysisID); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+	return updated, len(affected), nil
+}
+
+// MigrateClaimType changes the type of every claim of type from to type to,
+// optionally limited to the given analyses, in a single transaction. Scores
+// are not recomputed since they do not depend on claim type. With dryRun
+// set, only the number of matching claims is returned.
+func (s *SQLiteStore) MigrateClaimType(ctx context.Context, from, to models.ClaimType, analysisIDs []string, dryRun bool) (int, error) {
+	where := `type = ?`
+	args := []interface{}{from}
+	if len(analysisIDs) > 0 {
+		where += ` AND analysis_id IN (?` + strings.Repeat(", ?", len(analysisIDs)-1) + `)`
+		for _, id := range analysisIDs {
+			args = append(args, id)
+		}
+	}
+
+	if dryRun {
+		var count int
+		err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM claims WHERE `+where, args...).Scan(&count)
+		return count, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `UPDATE claims SET type = ? WHERE `+where, append([]interface{}{to}, args...)...)
+	if err != nil {
+		return 0, err
+	}
+	migrated, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(migrated), nil
+}
+
+// recomputeAnalysis refreshes an analysis' claim counts and score from its claims.
+func recomputeAnalysis(ctx context.Context, tx *sql.Tx, analysisID string) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT status, COUNT(*), SUM(confidence) FROM claims
+		WHERE analysis_id = ? AND archived = 0 GROUP BY status`, analysisID)
+	if err != nil {
+		return err
+	}
+	var verified, mixed, unsupported, total int
+	var confidenceSum float64
+	for rows.Next() {
+		var status models.VerificationStatus
+		var count int
+		var confidence float64
+		if err := rows.Scan(&status, &count, &confidence); err != nil {
+			rows.Close()
+			return err
+		}
+		switch status {
+		case models.StatusVerified:
+			verified = count
+		case models.StatusMixed:
+			mixed = count
+		case models.StatusUnsupported:
+			unsupported = count
+		}
+		total += count
+		confidenceSum += confidence
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	interval := scoreInterval(float64(verified)+float64(mixed)*0.5, total, confidenceSum)
+	_, err = tx.ExecContext(ctx, `
+		UPDATE analysis_results SET overall_score = ?, total_claims = ?, verified_claims = ?,
+			mixed_claims = ?, unsupported_claims = ?, score_ci_lower = ?, score_ci_upper = ?
+		WHERE id = ?`,
+		overallScore(verified, mixed, total), total, verified, mixed, unsupported,
+		interval.Lower, interval.Upper, analysisID)
+	return err
+}
+
+// GetEvidenceQualityStats aggregates evidence usefulness by domain and source
+// type. If domain is non-empty only that domain is included.
+func (s *SQLiteStore) GetEvidenceQualityStats(ctx context.Context, domain string) ([]*models.EvidenceQualityStats, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT source_url, source_type, snippet, is_useful FROM evidence_items`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type groupKey struct{ domain, sourceType string }
+	groups := make(map[groupKey]*models.EvidenceQualityStats)
+	usefulLength := make(map[groupKey]int)
+
+	for rows.Next() {
+		var e models.Evidence
+		if err := rows.Scan(&e.SourceURL, &e.SourceType, &e.Snippet, &e.IsUseful); err != nil {
+			return nil, err
+		}
+
+		d := evidenceDomain(e.SourceURL)
+		if domain != "" && d != domain {
+			continue
+		}
+		key := groupKey{d, e.SourceType}
+		stats, ok := groups[key]
+		if !ok {
+			stats = &models.EvidenceQualityStats{Domain: d, SourceType: e.SourceType}
+			groups[key] = stats
+		}
+		stats.TotalEvidences++
+		if e.IsUseful {
+			stats.UsefulEvidences++
+			usefulLength[key] += len(e.Snippet)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	results := make([]*models.EvidenceQualityStats, 0, len(groups))
+	for key, stats := range groups {
+		stats.PercentUseful = float64(stats.UsefulEvidences) / float64(stats.TotalEvidences) * 100
+		if stats.UsefulEvidences > 0 {
+			stats.AvgUsefulSnippetLength = float64(usefulLength[key]) / float64(stats.UsefulEvidences)
+		}
+		results = append(results, stats)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].TotalEvidences > results[j].TotalEvidences
+	})
+	return results, nil
+}
+
+// evidenceDomain returns the host of an evidence URL without any www. prefix.
+func evidenceDomain(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(parsed.Hostname(), "www.")
+}
+
+// SaveAnonymizedResult stores (or replaces) the anonymized copy of an analysis.
+func (s *SQLiteStore) SaveAnonymizedResult(ctx context.Context, analysisID string, result *models.VerificationResponse) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO anonymized_results (analysis_id, data, created_at)
+		VALUES (?, ?, ?)`, analysisID, string(data), time.Now())
+	return err
+}
+
+// GetAnonymizedResult retrieves the anonymized copy of an analysis.
+func (s *SQLiteStore) GetAnonymizedResult(ctx context.Context, analysisID string) (*models.VerificationResponse, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT data FROM anonymized_results WHERE analysis_id = ?`, analysisID).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result models.VerificationResponse
+	if err := json.Unmarshal([]byte(data), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
 // CreateAPIKey stores a new API key.
 func (s *SQLiteStore) CreateAPIKey(ctx context.Context, key *models.APIKey) error {
 	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO api_keys (id, key_hash, name, requests_per_minute, tokens_per_day, created_at)
-		VALUES (?, ?, ?, ?, ?, ?)`,
-		key.ID, key.KeyHash, key.Name, key.RequestsPerMinute, key.TokensPerDay, key.CreatedAt)
+		INSERT INTO api_keys (id, key_hash, name, requests_per_minute, tokens_per_day, created_at, expires_at, role)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		key.ID, key.KeyHash, key.Name, key.RequestsPerMinute, key.TokensPerDay, key.CreatedAt, key.ExpiresAt, key.Role)
 	return err
 }
 
 // GetAPIKeyByHash retrieves an API key by its hash.
 func (s *SQLiteStore) GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error) {
 	row := s.db.QueryRowContext(ctx, `
-		SELECT id, key_hash, name, requests_per_minute, tokens_per_day, created_at, last_used_at
+		SELECT id, key_hash, name, requests_per_minute, tokens_per_day, created_at, last_used_at,
+			expires_at, disabled, role
 		FROM api_keys WHERE key_hash = ?`, hash)
 
 	var key models.APIKey
 	err := row.Scan(&key.ID, &key.KeyHash, &key.Name, &key.RequestsPerMinute,
-		&key.TokensPerDay, &key.CreatedAt, &key.LastUsedAt)
+		&key.TokensPerDay, &key.CreatedAt, &key.LastUsedAt, &key.ExpiresAt, &key.Disabled, &key.Role)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -285,7 +1380,8 @@ func (s *SQLiteStore) DeleteAPIKey(ctx context.Context, id string) error {
 // ListAPIKeys returns all API keys.
 func (s *SQLiteStore) ListAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, name, requests_per_minute, tokens_per_day, created_at, last_used_at
+		SELECT id, name, requests_per_minute, tokens_per_day, created_at, last_used_at,
+			expires_at, disabled, role
 		FROM api_keys ORDER BY created_at DESC`)
 	if err != nil {
 		return nil, err
@@ -296,7 +1392,7 @@ func (s *SQLiteStore) ListAPIKeys(ctx context.Context) ([]*models.APIKey, error)
 	for rows.Next() {
 		var k models.APIKey
 		if err := rows.Scan(&k.ID, &k.Name, &k.RequestsPerMinute,
-			&k.TokensPerDay, &k.CreatedAt, &k.LastUsedAt); err != nil {
+			&k.TokensPerDay, &k.CreatedAt, &k.LastUsedAt, &k.ExpiresAt, &k.Disabled, &k.Role); err != nil {
 			return nil, err
 		}
 		keys = append(keys, &k)
@@ -304,34 +1400,245 @@ func (s *SQLiteStore) ListAPIKeys(ctx context.Context) ([]*models.APIKey, error)
 	return keys, rows.Err()
 }
 
-// LogRequest stores an audit log entry.
-func (s *SQLiteStore) LogRequest(ctx context.Context, log *models.AuditLog) error {
+// GetExpiredAPIKeys returns the keys that expired before now and are not
+// disabled yet.
+func (s *SQLiteStore) GetExpiredAPIKeys(ctx context.Context, now time.Time) ([]*models.APIKey, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, name, requests_per_minute, tokens_per_day, created_at, last_used_at,
+			expires_at, disabled, role
+		FROM api_keys WHERE expires_at IS NOT NULL AND expires_at < ? AND disabled = 0
+		ORDER BY expires_at`, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*models.APIKey
+	for rows.Next() {
+		var k models.APIKey
+		if err := rows.Scan(&k.ID, &k.Name, &k.RequestsPerMinute,
+			&k.TokensPerDay, &k.CreatedAt, &k.LastUsedAt, &k.ExpiresAt, &k.Disabled, &k.Role); err != nil {
+			return nil, err
+		}
+		keys = append(keys, &k)
+	}
+	return keys, rows.Err()
+}
+
+// DisableAPIKey marks an API key as disabled; it is kept for the audit trail.
+func (s *SQLiteStore) DisableAPIKey(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE api_keys SET disabled = 1 WHERE id = ?`, id)
+	return err
+}
+
+// GetConfigOverride returns the override stored for key; ok is false when
+// there is none.
+func (s *SQLiteStore) GetConfigOverride(ctx context.Context, key string) (string, bool, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM config_overrides WHERE key = ?`, key).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return value, true, nil
+}
+
+// SetConfigOverride creates or replaces the override for key.
+func (s *SQLiteStore) SetConfigOverride(ctx context.Context, key, value, updatedByKeyID string) error {
 	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO audit_logs (id, api_key_id, endpoint, method, request_size, response_code, duration_ms, timestamp)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
-		log.ID, log.APIKeyID, log.Endpoint, log.Method, log.RequestSize,
-		log.ResponseCode, log.DurationMs, log.Timestamp)
+		INSERT INTO config_overrides (key, value, updated_at, updated_by_key_id) VALUES (?, ?, ?, ?)
+		ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at,
+			updated_by_key_id = excluded.updated_by_key_id`,
+		key, value, time.Now(), updatedByKeyID)
+	return err
+}
+
+// DeleteConfigOverride removes the override for key.
+func (s *SQLiteStore) DeleteConfigOverride(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM config_overrides WHERE key = ?`, key)
 	return err
 }
 
-// GetAuditLogs returns paginated audit logs.
-func (s *SQLiteStore) GetAuditLogs(ctx context.Context, limit, offset int) ([]*models.AuditLog, error) {
+// ListConfigOverrides returns all overrides ordered by key.
+func (s *SQLiteStore) ListConfigOverrides(ctx context.Context) ([]*models.ConfigOverride, error) {
 	rows, err := s.db.QueryContext(ctx, `
-		SELECT id, api_key_id, endpoint, method, request_size, response_code, duration_ms, timestamp
-		FROM audit_logs ORDER BY timestamp DESC LIMIT ? OFFSET ?`, limit, offset)
+		SELECT key, value, updated_at, updated_by_key_id FROM config_overrides ORDER BY key`)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	var overrides []*models.ConfigOverride
+	for rows.Next() {
+		var o models.ConfigOverride
+		if err := rows.Scan(&o.Key, &o.Value, &o.UpdatedAt, &o.UpdatedByKeyID); err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, &o)
+	}
+	return overrides, rows.Err()
+}
+
+// LogRequest stores an audit log entry, chaining its hash to the previous entry.
+func (s *SQLiteStore) LogRequest(ctx context.Context, log *models.AuditLog) error {
+	s.auditMu.Lock()
+	defer s.auditMu.Unlock()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var prevHash string
+	err = tx.QueryRowContext(ctx, `SELECT hash FROM audit_logs ORDER BY rowid DESC LIMIT 1`).Scan(&prevHash)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	log.PrevHash = prevHash
+	log.Hash = auditHash(prevHash, log)
+
+	var detailsJSON []byte
+	if len(log.Details) > 0 {
+		detailsJSON, _ = json.Marshal(log.Details)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO audit_logs (id, api_key_id, endpoint, method, request_size, response_code, duration_ms, timestamp,
+			prev_hash, hash, details, prompt_tokens, completion_tokens)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		log.ID, log.APIKeyID, log.Endpoint, log.Method, log.RequestSize,
+		log.ResponseCode, log.DurationMs, log.Timestamp, log.PrevHash, log.Hash, string(detailsJSON),
+		log.PromptTokens, log.CompletionTokens)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetAuditLogs returns paginated audit logs matching filter, newest first,
+// and the total number of matches. If after is set, keyset pagination on
+// (timestamp, id) is used instead of offset.
+func (s *SQLiteStore) GetAuditLogs(ctx context.Context, filter AuditFilter, limit, offset int, after *Cursor) ([]*models.AuditLog, int, error) {
+	var where []string
+	var args []interface{}
+	if filter.APIKeyID != "" {
+		where = append(where, "api_key_id = ?")
+		args = append(args, filter.APIKeyID)
+	}
+	if filter.Endpoint != "" {
+		where = append(where, "endpoint = ?")
+		args = append(args, filter.Endpoint)
+	}
+	if filter.Method != "" {
+		where = append(where, "method = ?")
+		args = append(args, filter.Method)
+	}
+	if filter.StatusCode != 0 {
+		where = append(where, "response_code = ?")
+		args = append(args, filter.StatusCode)
+	}
+	if !filter.Since.IsZero() {
+		where = append(where, "timestamp >= ?")
+		args = append(args, filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		where = append(where, "timestamp < ?")
+		args = append(args, filter.Until)
+	}
+	if filter.MinDurationMs > 0 {
+		where = append(where, "duration_ms >= ?")
+		args = append(args, filter.MinDurationMs)
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM audit_logs`
+	if len(where) > 0 {
+		countQuery += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	if after != nil {
+		where = append(where, "(timestamp < ? OR (timestamp = ? AND id < ?))")
+		args = append(args, after.CreatedAt, after.CreatedAt, after.ID)
+		offset = 0
+	}
+	query := `
+		SELECT id, api_key_id, endpoint, method, request_size, response_code, duration_ms, timestamp,
+			prev_hash, hash, details, prompt_tokens, completion_tokens
+		FROM audit_logs`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	query += ` ORDER BY timestamp DESC, id DESC LIMIT ? OFFSET ?`
+	args = append(args, limit, offset)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
 	var logs []*models.AuditLog
 	for rows.Next() {
 		var l models.AuditLog
+		var detailsJSON string
 		if err := rows.Scan(&l.ID, &l.APIKeyID, &l.Endpoint, &l.Method,
-			&l.RequestSize, &l.ResponseCode, &l.DurationMs, &l.Timestamp); err != nil {
-			return nil, err
+			&l.RequestSize, &l.ResponseCode, &l.DurationMs, &l.Timestamp,
+			&l.PrevHash, &l.Hash, &detailsJSON, &l.PromptTokens, &l.CompletionTokens); err != nil {
+			return nil, 0, err
+		}
+		if detailsJSON != "" {
+			json.Unmarshal([]byte(detailsJSON), &l.Details)
 		}
 		logs = append(logs, &l)
 	}
-	return logs, rows.Err()
+	return logs, total, rows.Err()
+}
+
+// VerifyAuditChain recomputes the hash chain for audit logs written since the
+// given time. Entries are walked in insertion order, which is the order the
+// chain was built in, from the first entry inserted with a timestamp at or
+// after since; that entry is trusted as the anchor. Later entries are all
+// walked whatever their timestamp, since a slow request is logged with its
+// start time after faster ones. Entries logged before hashing was
+// introduced are skipped.
+func (s *SQLiteStore) VerifyAuditChain(ctx context.Context, since time.Time) (bool, string, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, api_key_id, endpoint, timestamp, prev_hash, hash
+		FROM audit_logs
+		WHERE rowid >= (SELECT MIN(rowid) FROM audit_logs WHERE timestamp >= ?)
+			AND rowid >= (SELECT first_rowid FROM audit_chain_start)
+		ORDER BY rowid`, since)
+	if err != nil {
+		return false, "", err
+	}
+	defer rows.Close()
+
+	var prevHash string
+	first := true
+	for rows.Next() {
+		var l models.AuditLog
+		if err := rows.Scan(&l.ID, &l.APIKeyID, &l.Endpoint, &l.Timestamp, &l.PrevHash, &l.Hash); err != nil {
+			return false, "", err
+		}
+		if !first && l.PrevHash != prevHash {
+			return false, l.ID, nil
+		}
+		if auditHash(l.PrevHash, &l) != l.Hash {
+			return false, l.ID, nil
+		}
+		prevHash = l.Hash
+		first = false
+	}
+	if err := rows.Err(); err != nil {
+		return false, "", err
+	}
+	return true, "", nil
 }
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/factchecker/verity/internal/models"
+)
+
+func newTestSQLiteStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+	store, err := NewSQLiteStore(filepath.Join(t.TempDir(), "verity.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+// logAt appends an audit log stamped with the given request start time.
+func logAt(t *testing.T, store *SQLiteStore, id string, start time.Time) {
+	t.Helper()
+	err := store.LogRequest(context.Background(), &models.AuditLog{
+		ID: id, APIKeyID: "key", Endpoint: "/api/v1/results", Method: "GET", Timestamp: start,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSQLiteVerifyAuditChain(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	since := base.Add(time.Minute)
+
+	t.Run("slow request started before since", func(t *testing.T) {
+		store := newTestSQLiteStore(t)
+		logAt(t, store, "a", base)
+		logAt(t, store, "fast", since.Add(time.Second))
+		logAt(t, store, "slow", base.Add(30*time.Second)) // finished after fast
+		logAt(t, store, "b", since.Add(time.Minute))
+
+		valid, broken, err := store.VerifyAuditChain(ctx, since)
+		if err != nil || !valid {
+			t.Errorf("VerifyAuditChain() = %v, %q, %v, want valid", valid, broken, err)
+		}
+	})
+
+	t.Run("blanked leading hashes", func(t *testing.T) {
+		store := newTestSQLiteStore(t)
+		for _, id := range []string{"a", "b", "c"} {
+			logAt(t, store, id, base)
+		}
+		if _, err := store.db.Exec(`UPDATE audit_logs SET hash = '', prev_hash = '' WHERE id IN ('a', 'b')`); err != nil {
+			t.Fatal(err)
+		}
+
+		valid, broken, err := store.VerifyAuditChain(ctx, time.Time{})
+		if err != nil || valid || broken != "a" {
+			t.Errorf("VerifyAuditChain() = %v, %q, %v, want broken at a", valid, broken, err)
+		}
+	})
+
+	t.Run("logs written before hashing", func(t *testing.T) {
+		store := newTestSQLiteStore(t)
+		// Simulate a database created before the hash columns
+		if _, err := store.db.Exec(`DELETE FROM audit_chain_start`); err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range []string{"legacy1", "legacy2"} {
+			if _, err := store.db.Exec(`INSERT INTO audit_logs (id, api_key_id, endpoint, method, request_size,
+				response_code, duration_ms, timestamp) VALUES (?, 'key', '/', 'GET', 0, 200, 0, ?)`, id, base); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := store.Migrate(); err != nil {
+			t.Fatal(err)
+		}
+		logAt(t, store, "a", base)
+		logAt(t, store, "b", base)
+
+		valid, broken, err := store.VerifyAuditChain(ctx, time.Time{})
+		if err != nil || !valid {
+			t.Errorf("VerifyAuditChain() = %v, %q, %v, want valid", valid, broken, err)
+		}
+
+		if _, err := store.db.Exec(`UPDATE audit_logs SET hash = '' WHERE id = 'a'`); err != nil {
+			t.Fatal(err)
+		}
+		valid, broken, err = store.VerifyAuditChain(ctx, time.Time{})
+		if err != nil || valid || broken != "a" {
+			t.Errorf("after blanking a: VerifyAuditChain() = %v, %q, %v, want broken at a", valid, broken, err)
+		}
+	})
+}
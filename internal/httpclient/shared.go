@@ -0,0 +1,35 @@
+// Package httpclient provides the connection pool shared by search clients.
+package httpclient
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	// sharedMaxIdleConnsPerHost keeps enough idle connections to reuse them
+	// across concurrent claim verifications hitting the same source.
+	sharedMaxIdleConnsPerHost = 20
+
+	// sharedMaxConnsPerHost caps simultaneous connections to any one host.
+	sharedMaxConnsPerHost = 50
+
+	// sharedTimeout bounds each request. Callers needing a different
+	// timeout copy the client and override Timeout, keeping the transport.
+	sharedTimeout = 15 * time.Second
+)
+
+// NewSharedHTTPClient creates the HTTP client used by all search clients.
+// It should be created once and passed to every client so that they share
+// one connection pool instead of each opening their own connections.
+func NewSharedHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = sharedMaxIdleConnsPerHost
+	transport.MaxConnsPerHost = sharedMaxConnsPerHost
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   sharedTimeout,
+	}
+}
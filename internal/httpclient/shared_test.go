@@ -0,0 +1,51 @@
+package httpclient_test
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/factchecker/verity/internal/httpclient"
+	"github.com/factchecker/verity/internal/search"
+)
+
+func TestSearchClientsShareTransport(t *testing.T) {
+	shared := httpclient.NewSharedHTTPClient()
+	transport, ok := shared.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("shared transport is %T, want *http.Transport", shared.Transport)
+	}
+	if transport.MaxIdleConnsPerHost != 20 || transport.MaxConnsPerHost != 50 {
+		t.Errorf("pool limits = %d idle, %d total per host, want 20 and 50",
+			transport.MaxIdleConnsPerHost, transport.MaxConnsPerHost)
+	}
+
+	clients := map[string]interface{}{
+		"duckduckgo":      search.NewDuckDuckGoClient(shared, time.Second, nil, 0),
+		"wikipedia":       search.NewWikipediaClient(shared),
+		"pubmed":          search.NewPubMedClient(shared, "", 0),
+		"crossref":        search.NewCrossRefClient(shared),
+		"arxiv":           search.NewArXivClient(shared),
+		"semanticscholar": search.NewSemanticScholarClient(shared),
+		"google":          search.NewGoogleSearchClient(shared, "key", "engine"),
+		"newsapi":         search.NewNewsAPIClient(shared, "key"),
+		"searxng":         search.NewSearXNGClient(shared, "http://searxng.invalid"),
+		"static":          search.NewStaticURLSearchClient(shared, "https://example.org/"),
+		"wikidata":        search.NewWikidataReliabilityLookup(shared),
+	}
+
+	want := reflect.ValueOf(transport).Pointer()
+	for name, client := range clients {
+		// The clients keep their *http.Client unexported
+		field := reflect.ValueOf(client).Elem().FieldByName("httpClient")
+		if !field.IsValid() || field.IsNil() {
+			t.Errorf("%s: no httpClient field", name)
+			continue
+		}
+		got := field.Elem().FieldByName("Transport").Elem()
+		if got.Kind() != reflect.Pointer || got.Pointer() != want {
+			t.Errorf("%s: uses its own transport, want the shared one", name)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Package httpclient provides shared HTTP client construction with tuned transports.
+package httpclient
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/factchecker/verity/internal/config"
+	"github.com/rs/zerolog/log"
+)
+
+// NewLLMHTTPClient creates an HTTP client for LLM providers with connection
+// pool limits taken from the configuration. Zero values keep Go's defaults.
+func NewLLMHTTPClient(cfg config.LLMConfig) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.MaxIdleConns > 0 {
+		transport.MaxIdleConns = cfg.MaxIdleConns
+		// Idle connections all go to the same provider host
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConns
+	}
+	if cfg.MaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = cfg.MaxConnsPerHost
+	}
+	if cfg.IdleConnTimeoutSecs > 0 {
+		transport.IdleConnTimeout = time.Duration(cfg.IdleConnTimeoutSecs) * time.Second
+	}
+
+	log.Debug().
+		Str("provider", cfg.Provider).
+		Int("max_idle_conns", transport.MaxIdleConns).
+		Int("max_idle_conns_per_host", transport.MaxIdleConnsPerHost).
+		Int("max_conns_per_host", transport.MaxConnsPerHost).
+		Dur("idle_conn_timeout", transport.IdleConnTimeout).
+		Msg("LLM HTTP connection pool configured")
+
+	return &http.Client{Transport: transport}
+}
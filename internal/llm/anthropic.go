@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/factchecker/verity/internal/config"
+	"github.com/factchecker/verity/internal/httpclient"
 )
 
 // AnthropicProvider implements Provider using Anthropic Claude API.
@@ -33,7 +34,7 @@ func NewAnthropicProvider(cfg *config.LLMConfig) (*AnthropicProvider, error) {
 	return &AnthropicProvider{
 		apiKey:     cfg.APIKey,
 		model:      model,
-		httpClient: &http.Client{},
+		httpClient: httpclient.NewLLMHTTPClient(*cfg),
 	}, nil
 }
 
@@ -42,11 +43,21 @@ func (p *AnthropicProvider) Name() string {
 	return "anthropic"
 }
 
+// Model returns the default completion model.
+func (p *AnthropicProvider) Model() string {
+	return p.model
+}
+
 // SupportsEmbeddings returns false as Anthropic doesn't provide embeddings API.
 func (p *AnthropicProvider) SupportsEmbeddings() bool {
 	return false
 }
 
+// SupportsVision returns false; image input is not implemented for Anthropic.
+func (p *AnthropicProvider) SupportsVision() bool {
+	return false
+}
+
 type anthropicRequest struct {
 	Model     string              `json:"model"`
 	MaxTokens int                 `json:"max_tokens"`
@@ -63,6 +74,10 @@ type anthropicResponse struct {
 	Content []struct {
 		Text string `json:"text"`
 	} `json:"content"`
+	Usage struct {
+		InputTokens  int64 `json:"input_tokens"`
+		OutputTokens int64 `json:"output_tokens"`
+	} `json:"usage"`
 	Error *struct {
 		Message string `json:"message"`
 	} `json:"error,omitempty"`
@@ -127,6 +142,7 @@ func (p *AnthropicProvider) CompleteWithSystem(ctx context.Context, system, user
 	if result.Error != nil {
 		return "", fmt.Errorf("Anthropic error: %s", result.Error.Message)
 	}
+	recordUsage(ctx, TokensUsed{PromptTokens: result.Usage.InputTokens, CompletionTokens: result.Usage.OutputTokens})
 
 	if len(result.Content) == 0 {
 		return "", fmt.Errorf("Anthropic returned no content")
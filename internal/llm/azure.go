@@ -0,0 +1,73 @@
+// Package llm provides Azure OpenAI implementation of the Provider interface.
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/factchecker/verity/internal/config"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// azureAPIVersion is the Azure OpenAI REST API version requested.
+const azureAPIVersion = "2024-02-01"
+
+// AzureProvider implements Provider using an Azure OpenAI resource. Requests
+// go to {endpoint}/openai/deployments/{deployment}/..., authenticated with
+// the api-key header, and are otherwise handled exactly as for OpenAI.
+type AzureProvider struct {
+	*OpenAIProvider
+}
+
+// NewAzureProvider creates a new Azure OpenAI provider. The deployment
+// replaces the model name: azure_deployment (or model, when unset) names the
+// chat deployment, and fallback_model and embedding_model, when set, name
+// deployments too.
+func NewAzureProvider(cfg *config.LLMConfig) (*AzureProvider, error) {
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("Azure OpenAI API key is required")
+	}
+	if cfg.AzureEndpoint == "" {
+		return nil, fmt.Errorf("Azure OpenAI endpoint is required")
+	}
+
+	deployment := cfg.AzureDeployment
+	if deployment == "" {
+		deployment = cfg.Model
+	}
+	if deployment == "" {
+		return nil, fmt.Errorf("Azure OpenAI deployment is required")
+	}
+
+	clientCfg := openai.DefaultAzureConfig(cfg.APIKey, cfg.AzureEndpoint)
+	clientCfg.APIVersion = azureAPIVersion
+	clientCfg.AzureModelMapperFunc = func(model string) string {
+		return model // models are already deployment names
+	}
+
+	return &AzureProvider{
+		OpenAIProvider: &OpenAIProvider{
+			client:         openai.NewClientWithConfig(clientCfg),
+			model:          deployment,
+			embeddingModel: cfg.EmbeddingModel,
+		},
+	}, nil
+}
+
+// Name returns the provider name.
+func (p *AzureProvider) Name() string {
+	return "azure"
+}
+
+// SupportsEmbeddings returns true if an embedding deployment is configured.
+func (p *AzureProvider) SupportsEmbeddings() bool {
+	return p.embeddingModel != ""
+}
+
+// Embed generates embeddings for the given text.
+func (p *AzureProvider) Embed(ctx context.Context, text string) ([]float32, error) {
+	if p.embeddingModel == "" {
+		return nil, fmt.Errorf("Azure OpenAI embedding deployment is not configured")
+	}
+	return p.OpenAIProvider.Embed(ctx, text)
+}
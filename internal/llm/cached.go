@@ -0,0 +1,157 @@
+// Package llm provides response caching for LLM providers.
+package llm
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+// DefaultResponseCacheSize is the number of completions, and separately of
+// embeddings, a cache keeps when no size is configured.
+const DefaultResponseCacheSize = 1000
+
+// cachedProvider answers repeated completion and embedding requests from a
+// bounded LRU cache instead of calling the wrapped provider. Failed calls
+// are not cached.
+type cachedProvider struct {
+	Provider
+	completions *lruCache[string]
+	embeddings  *lruCache[[]float32]
+}
+
+// cachedVisionProvider is a cachedProvider for providers that also accept
+// images, so that VisionProvider assertions keep working. Image prompts
+// are not cached.
+type cachedVisionProvider struct {
+	cachedProvider
+	vision VisionProvider
+}
+
+// WithCache wraps provider so that identical completion and embedding
+// requests are served from memory. Each cache holds up to size entries;
+// size <= 0 uses DefaultResponseCacheSize.
+func WithCache(provider Provider, size int) Provider {
+	if size <= 0 {
+		size = DefaultResponseCacheSize
+	}
+	cached := cachedProvider{
+		Provider:    provider,
+		completions: newLRUCache[string](size),
+		embeddings:  newLRUCache[[]float32](size),
+	}
+	if vision, ok := provider.(VisionProvider); ok {
+		return &cachedVisionProvider{cachedProvider: cached, vision: vision}
+	}
+	return &cached
+}
+
+// Complete generates a completion for the given prompt.
+func (p *cachedProvider) Complete(ctx context.Context, prompt string, opts CompletionOptions) (string, error) {
+	key := completionKey("complete", "", prompt, opts)
+	if text, ok := p.completions.get(key); ok {
+		return text, nil
+	}
+	text, err := p.Provider.Complete(ctx, prompt, opts)
+	if err != nil {
+		return "", err
+	}
+	p.completions.add(key, text)
+	return text, nil
+}
+
+// CompleteWithSystem generates a completion with a system prompt.
+func (p *cachedProvider) CompleteWithSystem(ctx context.Context, system, user string, opts CompletionOptions) (string, error) {
+	key := completionKey("system", system, user, opts)
+	if text, ok := p.completions.get(key); ok {
+		return text, nil
+	}
+	text, err := p.Provider.CompleteWithSystem(ctx, system, user, opts)
+	if err != nil {
+		return "", err
+	}
+	p.completions.add(key, text)
+	return text, nil
+}
+
+// Embed generates embeddings for the given text.
+func (p *cachedProvider) Embed(ctx context.Context, text string) ([]float32, error) {
+	key := hashKey(text)
+	if embedding, ok := p.embeddings.get(key); ok {
+		return append([]float32(nil), embedding...), nil
+	}
+	embedding, err := p.Provider.Embed(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	p.embeddings.add(key, append([]float32(nil), embedding...))
+	return embedding, nil
+}
+
+// CompleteWithImage generates a completion for a prompt about an image.
+func (p *cachedVisionProvider) CompleteWithImage(ctx context.Context, system, user, imageURL string, opts CompletionOptions) (string, error) {
+	return p.vision.CompleteWithImage(ctx, system, user, imageURL, opts)
+}
+
+// completionKey identifies a completion request by its prompts and every
+// option that changes the response.
+func completionKey(kind, system, user string, opts CompletionOptions) string {
+	return hashKey(fmt.Sprintf("%s\x00%s\x00%s\x00%s\x00%d\x00%g",
+		kind, system, user, opts.Model, opts.MaxTokens, opts.Temperature))
+}
+
+func hashKey(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// lruCache is a fixed-size cache evicting the least recently used entry.
+type lruCache[V any] struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // front is most recently used
+	entries map[string]*list.Element
+}
+
+type lruEntry[V any] struct {
+	key   string
+	value V
+}
+
+func newLRUCache[V any](size int) *lruCache[V] {
+	return &lruCache[V]{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (c *lruCache[V]) get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*lruEntry[V]).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+func (c *lruCache[V]) add(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*lruEntry[V]).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[V]{key: key, value: value})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[V]).key)
+	}
+}
@@ -0,0 +1,210 @@
+// Package llm provides Cohere implementation of the Provider interface.
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/factchecker/verity/internal/config"
+	"github.com/factchecker/verity/internal/httpclient"
+)
+
+// cohereBaseURL is the Cohere API root.
+const cohereBaseURL = "https://api.cohere.ai"
+
+// CohereProvider implements Provider using the Cohere API.
+type CohereProvider struct {
+	apiKey         string
+	model          string
+	embeddingModel string
+	baseURL        string
+	httpClient     *http.Client
+}
+
+// NewCohereProvider creates a new Cohere provider.
+func NewCohereProvider(cfg *config.LLMConfig) (*CohereProvider, error) {
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("Cohere API key is required")
+	}
+
+	model := cfg.Model
+	if model == "" {
+		model = "command-r"
+	}
+
+	// The default embedding model is OpenAI's; Cohere cannot serve it
+	embeddingModel := cfg.EmbeddingModel
+	if embeddingModel == "" || strings.HasPrefix(embeddingModel, "text-embedding") {
+		embeddingModel = "embed-multilingual-v3.0"
+	}
+
+	return &CohereProvider{
+		apiKey:         cfg.APIKey,
+		model:          model,
+		embeddingModel: embeddingModel,
+		baseURL:        cohereBaseURL,
+		httpClient:     httpclient.NewLLMHTTPClient(*cfg),
+	}, nil
+}
+
+// Name returns the provider name.
+func (p *CohereProvider) Name() string {
+	return "cohere"
+}
+
+// Model returns the default completion model.
+func (p *CohereProvider) Model() string {
+	return p.model
+}
+
+// SupportsEmbeddings returns true as Cohere supports embeddings.
+func (p *CohereProvider) SupportsEmbeddings() bool {
+	return true
+}
+
+// SupportsVision returns false; Command models do not accept images.
+func (p *CohereProvider) SupportsVision() bool {
+	return false
+}
+
+type cohereChatRequest struct {
+	Model       string  `json:"model"`
+	Message     string  `json:"message"`
+	Preamble    string  `json:"preamble,omitempty"`
+	MaxTokens   int     `json:"max_tokens"`
+	Temperature float64 `json:"temperature"`
+}
+
+type cohereChatResponse struct {
+	Text    string     `json:"text"`
+	Meta    cohereMeta `json:"meta"`
+	Message string     `json:"message"` // set on errors
+}
+
+type cohereEmbedRequest struct {
+	Model     string   `json:"model"`
+	Texts     []string `json:"texts"`
+	InputType string   `json:"input_type"`
+}
+
+type cohereEmbedResponse struct {
+	Embeddings [][]float32 `json:"embeddings"`
+	Meta       cohereMeta  `json:"meta"`
+	Message    string      `json:"message"` // set on errors
+}
+
+type cohereMeta struct {
+	BilledUnits struct {
+		InputTokens  int64 `json:"input_tokens"`
+		OutputTokens int64 `json:"output_tokens"`
+	} `json:"billed_units"`
+}
+
+// Complete generates a completion for the given prompt.
+func (p *CohereProvider) Complete(ctx context.Context, prompt string, opts CompletionOptions) (string, error) {
+	return p.CompleteWithSystem(ctx, "", prompt, opts)
+}
+
+// CompleteWithSystem generates a completion with a system prompt, which
+// Cohere takes as the preamble.
+func (p *CohereProvider) CompleteWithSystem(ctx context.Context, system, user string, opts CompletionOptions) (string, error) {
+	model := opts.Model
+	if model == "" {
+		model = p.model
+	}
+
+	maxTokens := opts.MaxTokens
+	if maxTokens == 0 {
+		maxTokens = 2048
+	}
+
+	var result cohereChatResponse
+	status, err := p.post(ctx, "/v1/chat", cohereChatRequest{
+		Model:       model,
+		Message:     user,
+		Preamble:    system,
+		MaxTokens:   maxTokens,
+		Temperature: opts.Temperature,
+	}, &result)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusOK {
+		return "", fmt.Errorf("Cohere error (status %d): %s", status, result.Message)
+	}
+	recordUsage(ctx, TokensUsed{
+		PromptTokens:     result.Meta.BilledUnits.InputTokens,
+		CompletionTokens: result.Meta.BilledUnits.OutputTokens,
+	})
+
+	if result.Text == "" {
+		return "", fmt.Errorf("Cohere returned no text")
+	}
+
+	return result.Text, nil
+}
+
+// Embed generates embeddings for the given text.
+func (p *CohereProvider) Embed(ctx context.Context, text string) ([]float32, error) {
+	var result cohereEmbedResponse
+	status, err := p.post(ctx, "/v1/embed", cohereEmbedRequest{
+		Model:     p.embeddingModel,
+		Texts:     []string{text},
+		InputType: "search_document",
+	}, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Cohere embedding error (status %d): %s", status, result.Message)
+	}
+	recordUsage(ctx, TokensUsed{PromptTokens: result.Meta.BilledUnits.InputTokens})
+
+	if len(result.Embeddings) == 0 {
+		return nil, fmt.Errorf("Cohere returned no embeddings")
+	}
+
+	return result.Embeddings[0], nil
+}
+
+// post sends body as JSON to the API path and decodes the response into
+// result, returning the HTTP status.
+func (p *CohereProvider) post(ctx context.Context, path string, body, result interface{}) (int, error) {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", p.baseURL+path, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("Cohere request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return 0, fmt.Errorf("failed to parse response (status %d): %w", resp.StatusCode, err)
+	}
+
+	return resp.StatusCode, nil
+}
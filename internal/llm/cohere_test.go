@@ -0,0 +1,201 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/factchecker/verity/internal/config"
+)
+
+// newTestCohere returns a Cohere provider whose requests go to handler.
+func newTestCohere(t *testing.T, cfg config.LLMConfig, handler http.HandlerFunc) *CohereProvider {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	if cfg.APIKey == "" {
+		cfg.APIKey = "test-key"
+	}
+	p, err := NewCohereProvider(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.baseURL = srv.URL
+	return p
+}
+
+func TestNewCohereProvider(t *testing.T) {
+	if _, err := NewCohereProvider(&config.LLMConfig{}); err == nil {
+		t.Error("NewCohereProvider() without an API key succeeded, want error")
+	}
+
+	p, err := NewCohereProvider(&config.LLMConfig{APIKey: "key", EmbeddingModel: "text-embedding-ada-002"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.model != "command-r" {
+		t.Errorf("model = %q, want command-r", p.model)
+	}
+	if p.embeddingModel != "embed-multilingual-v3.0" {
+		t.Errorf("embeddingModel = %q, want the Cohere default in place of an OpenAI model", p.embeddingModel)
+	}
+
+	provider, err := NewProvider(&config.LLMConfig{Provider: "cohere", APIKey: "key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if provider.Name() != "cohere" {
+		t.Errorf("NewProvider(cohere).Name() = %q", provider.Name())
+	}
+	if provider.Model() != "command-r" {
+		t.Errorf("NewProvider(cohere).Model() = %q, want the default model", provider.Model())
+	}
+}
+
+func TestCohereCompleteWithSystem(t *testing.T) {
+	var got cohereChatRequest
+	p := newTestCohere(t, config.LLMConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/chat" {
+			t.Errorf("request = %s %s, want POST /v1/chat", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer test-key" {
+			t.Errorf("Authorization = %q", auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		w.Write([]byte(`{"text":"It is verified.","meta":{"billed_units":{"input_tokens":12,"output_tokens":4}}}`))
+	})
+
+	ctx, usage := WithUsageRecorder(context.Background())
+	text, err := p.CompleteWithSystem(ctx, "You are a fact-checker.", "Is the sky blue?", CompletionOptions{Temperature: 0.2})
+	if err != nil {
+		t.Fatalf("CompleteWithSystem() error = %v", err)
+	}
+	if text != "It is verified." {
+		t.Errorf("CompleteWithSystem() = %q", text)
+	}
+
+	want := cohereChatRequest{
+		Model:       "command-r",
+		Message:     "Is the sky blue?",
+		Preamble:    "You are a fact-checker.",
+		MaxTokens:   2048,
+		Temperature: 0.2,
+	}
+	if got != want {
+		t.Errorf("request = %+v, want %+v", got, want)
+	}
+	if total := usage.Total(); total.PromptTokens != 12 || total.CompletionTokens != 4 {
+		t.Errorf("usage = %+v, want 12 prompt and 4 completion tokens", total)
+	}
+}
+
+func TestCohereCompleteOptions(t *testing.T) {
+	var got map[string]interface{}
+	p := newTestCohere(t, config.LLMConfig{Model: "command-r-plus"}, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"text":"ok"}`))
+	})
+
+	if _, err := p.Complete(context.Background(), "hello", CompletionOptions{MaxTokens: 100}); err != nil {
+		t.Fatal(err)
+	}
+	if got["model"] != "command-r-plus" || got["max_tokens"] != float64(100) {
+		t.Errorf("request = %v, want the configured model and max_tokens 100", got)
+	}
+	if _, ok := got["preamble"]; ok {
+		t.Errorf("request has a preamble without a system prompt: %v", got)
+	}
+
+	if _, err := p.Complete(context.Background(), "hello", CompletionOptions{Model: "command-light"}); err != nil {
+		t.Fatal(err)
+	}
+	if got["model"] != "command-light" {
+		t.Errorf("model = %v, want the per-request override", got["model"])
+	}
+}
+
+func TestCohereCompleteErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{"api error", http.StatusUnauthorized, `{"message":"invalid api token"}`, "status 401): invalid api token"},
+		{"empty text", http.StatusOK, `{"text":""}`, "no text"},
+		{"invalid json", http.StatusBadGateway, `<html>bad gateway</html>`, "failed to parse response (status 502)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestCohere(t, config.LLMConfig{}, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+			_, err := p.Complete(context.Background(), "hello", CompletionOptions{})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Complete() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCohereEmbed(t *testing.T) {
+	var got cohereEmbedRequest
+	p := newTestCohere(t, config.LLMConfig{EmbeddingModel: "embed-english-v3.0"}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embed" {
+			t.Errorf("path = %s, want /v1/embed", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"embeddings":[[0.1,0.2,0.3]],"meta":{"billed_units":{"input_tokens":3}}}`))
+	})
+
+	ctx, usage := WithUsageRecorder(context.Background())
+	embedding, err := p.Embed(ctx, "the sky is blue")
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if want := []float32{0.1, 0.2, 0.3}; !reflect.DeepEqual(embedding, want) {
+		t.Errorf("Embed() = %v, want %v", embedding, want)
+	}
+
+	want := cohereEmbedRequest{Model: "embed-english-v3.0", Texts: []string{"the sky is blue"}, InputType: "search_document"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("request = %+v, want %+v", got, want)
+	}
+	if total := usage.Total(); total.PromptTokens != 3 {
+		t.Errorf("usage = %+v, want 3 prompt tokens", total)
+	}
+}
+
+func TestCohereEmbedErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{"api error", http.StatusTooManyRequests, `{"message":"rate limited"}`, "status 429): rate limited"},
+		{"no embeddings", http.StatusOK, `{"embeddings":[]}`, "no embeddings"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestCohere(t, config.LLMConfig{}, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+			_, err := p.Embed(context.Background(), "text")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Embed() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/factchecker/verity/internal/config"
+	"github.com/factchecker/verity/internal/httpclient"
 )
 
 // GeminiProvider implements Provider using Google Gemini API.
@@ -33,7 +34,7 @@ func NewGeminiProvider(cfg *config.LLMConfig) (*GeminiProvider, error) {
 	return &GeminiProvider{
 		apiKey:     cfg.APIKey,
 		model:      model,
-		httpClient: &http.Client{},
+		httpClient: httpclient.NewLLMHTTPClient(*cfg),
 	}, nil
 }
 
@@ -42,11 +43,21 @@ func (p *GeminiProvider) Name() string {
 	return "gemini"
 }
 
+// Model returns the default completion model.
+func (p *GeminiProvider) Model() string {
+	return p.model
+}
+
 // SupportsEmbeddings returns true as Gemini supports embeddings.
 func (p *GeminiProvider) SupportsEmbeddings() bool {
 	return true
 }
 
+// SupportsVision returns false; image input is not implemented for Gemini.
+func (p *GeminiProvider) SupportsVision() bool {
+	return false
+}
+
 type geminiRequest struct {
 	Contents         []geminiContent        `json:"contents"`
 	SystemInstruction *geminiContent        `json:"systemInstruction,omitempty"`
@@ -75,6 +86,10 @@ type geminiResponse struct {
 			} `json:"parts"`
 		} `json:"content"`
 	} `json:"candidates"`
+	UsageMetadata struct {
+		PromptTokenCount     int64 `json:"promptTokenCount"`
+		CandidatesTokenCount int64 `json:"candidatesTokenCount"`
+	} `json:"usageMetadata"`
 	Error *struct {
 		Message string `json:"message"`
 		Code    int    `json:"code"`
@@ -167,6 +182,10 @@ func (p *GeminiProvider) CompleteWithSystem(ctx context.Context, system, user st
 	if result.Error != nil {
 		return "", fmt.Errorf("Gemini error: %s (code %d)", result.Error.Message, result.Error.Code)
 	}
+	recordUsage(ctx, TokensUsed{
+		PromptTokens:     result.UsageMetadata.PromptTokenCount,
+		CompletionTokens: result.UsageMetadata.CandidatesTokenCount,
+	})
 
 	if len(result.Candidates) == 0 || len(result.Candidates[0].Content.Parts) == 0 {
 		return "", fmt.Errorf("Gemini returned no content")
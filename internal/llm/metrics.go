@@ -0,0 +1,54 @@
+// Package llm provides call counting for LLM providers.
+package llm
+
+import (
+	"context"
+
+	"github.com/factchecker/verity/internal/metrics"
+)
+
+// countingProvider counts every call to the wrapped provider in
+// metrics.LLMCalls, labelled with the provider name.
+type countingProvider struct {
+	Provider
+}
+
+// countingVisionProvider is a countingProvider for providers that also
+// accept images, so that VisionProvider assertions keep working.
+type countingVisionProvider struct {
+	countingProvider
+	vision VisionProvider
+}
+
+// WithMetrics wraps provider so that its calls are counted.
+func WithMetrics(provider Provider) Provider {
+	counted := countingProvider{provider}
+	if vision, ok := provider.(VisionProvider); ok {
+		return &countingVisionProvider{countingProvider: counted, vision: vision}
+	}
+	return &counted
+}
+
+// Complete generates a completion for the given prompt.
+func (p *countingProvider) Complete(ctx context.Context, prompt string, opts CompletionOptions) (string, error) {
+	metrics.LLMCalls.WithLabelValues(p.Name()).Inc()
+	return p.Provider.Complete(ctx, prompt, opts)
+}
+
+// CompleteWithSystem generates a completion with a system prompt.
+func (p *countingProvider) CompleteWithSystem(ctx context.Context, system, user string, opts CompletionOptions) (string, error) {
+	metrics.LLMCalls.WithLabelValues(p.Name()).Inc()
+	return p.Provider.CompleteWithSystem(ctx, system, user, opts)
+}
+
+// Embed generates embeddings for the given text.
+func (p *countingProvider) Embed(ctx context.Context, text string) ([]float32, error) {
+	metrics.LLMCalls.WithLabelValues(p.Name()).Inc()
+	return p.Provider.Embed(ctx, text)
+}
+
+// CompleteWithImage generates a completion for a prompt about an image.
+func (p *countingVisionProvider) CompleteWithImage(ctx context.Context, system, user, imageURL string, opts CompletionOptions) (string, error) {
+	metrics.LLMCalls.WithLabelValues(p.Name()).Inc()
+	return p.vision.CompleteWithImage(ctx, system, user, imageURL, opts)
+}
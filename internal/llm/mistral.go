@@ -0,0 +1,60 @@
+// Package llm provides Mistral AI implementation of the Provider interface.
+package llm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/factchecker/verity/internal/config"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// mistralBaseURL is the Mistral API root. Its chat completions and
+// embeddings endpoints follow the OpenAI wire format.
+const mistralBaseURL = "https://api.mistral.ai/v1"
+
+// MistralProvider implements Provider using the Mistral AI API. Requests go
+// to /v1/chat/completions, with the system prompt as a leading system
+// message, and /v1/embeddings, and are otherwise handled as for OpenAI.
+type MistralProvider struct {
+	*OpenAIProvider
+}
+
+// NewMistralProvider creates a new Mistral provider.
+func NewMistralProvider(cfg *config.LLMConfig) (*MistralProvider, error) {
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("Mistral API key is required")
+	}
+
+	model := cfg.Model
+	if model == "" {
+		model = "mistral-small-latest"
+	}
+
+	// The default embedding model is OpenAI's; Mistral cannot serve it
+	embeddingModel := cfg.EmbeddingModel
+	if embeddingModel == "" || strings.HasPrefix(embeddingModel, "text-embedding") {
+		embeddingModel = "mistral-embed"
+	}
+
+	clientCfg := openai.DefaultConfig(cfg.APIKey)
+	clientCfg.BaseURL = mistralBaseURL
+
+	return &MistralProvider{
+		OpenAIProvider: &OpenAIProvider{
+			client:         openai.NewClientWithConfig(clientCfg),
+			model:          model,
+			embeddingModel: embeddingModel,
+		},
+	}, nil
+}
+
+// Name returns the provider name.
+func (p *MistralProvider) Name() string {
+	return "mistral"
+}
+
+// SupportsVision returns false; image input is not implemented for Mistral.
+func (p *MistralProvider) SupportsVision() bool {
+	return false
+}
@@ -0,0 +1,112 @@
+// Package llm provides a deterministic mock implementation of the Provider interface.
+package llm
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+)
+
+// MockCall records a single completion request made to a MockProvider.
+type MockCall struct {
+	System string
+	User   string
+	Opts   CompletionOptions
+}
+
+// MockProvider returns scripted responses without calling any external API.
+// Responses are matched by substring against the user prompt, checked in
+// insertion order; Default is returned when nothing matches. It is intended
+// for prompt regression checks and local development.
+type MockProvider struct {
+	mu        sync.Mutex
+	patterns  []string
+	responses map[string]string
+	Default   string
+	calls     []MockCall
+}
+
+// NewMockProvider creates a mock provider with a default response.
+func NewMockProvider(defaultResponse string) *MockProvider {
+	return &MockProvider{
+		responses: make(map[string]string),
+		Default:   defaultResponse,
+	}
+}
+
+// On registers a response for user prompts containing pattern.
+func (p *MockProvider) On(pattern, response string) *MockProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, exists := p.responses[pattern]; !exists {
+		p.patterns = append(p.patterns, pattern)
+	}
+	p.responses[pattern] = response
+	return p
+}
+
+// Calls returns a copy of all recorded completion requests.
+func (p *MockProvider) Calls() []MockCall {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]MockCall(nil), p.calls...)
+}
+
+// Name returns the provider name.
+func (p *MockProvider) Name() string {
+	return "mock"
+}
+
+// Model returns "mock", which has no pricing.
+func (p *MockProvider) Model() string {
+	return "mock"
+}
+
+// SupportsEmbeddings returns true; embeddings are derived from a text hash.
+func (p *MockProvider) SupportsEmbeddings() bool {
+	return true
+}
+
+// SupportsVision returns false; the mock cannot read images.
+func (p *MockProvider) SupportsVision() bool {
+	return false
+}
+
+// Complete generates a completion for the given prompt.
+func (p *MockProvider) Complete(ctx context.Context, prompt string, opts CompletionOptions) (string, error) {
+	return p.CompleteWithSystem(ctx, "", prompt, opts)
+}
+
+// CompleteWithSystem returns the first scripted response matching the user prompt.
+func (p *MockProvider) CompleteWithSystem(ctx context.Context, system, user string, opts CompletionOptions) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = append(p.calls, MockCall{System: system, User: user, Opts: opts})
+
+	for _, pattern := range p.patterns {
+		if strings.Contains(user, pattern) {
+			return p.responses[pattern], nil
+		}
+	}
+	if p.Default == "" {
+		return "", fmt.Errorf("mock provider has no response for prompt")
+	}
+	return p.Default, nil
+}
+
+// Embed returns a deterministic pseudo-embedding derived from the text.
+func (p *MockProvider) Embed(ctx context.Context, text string) ([]float32, error) {
+	vec := make([]float32, 16)
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		h := fnv.New32a()
+		h.Write([]byte(word))
+		vec[h.Sum32()%uint32(len(vec))]++
+	}
+	return vec, nil
+}
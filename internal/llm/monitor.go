@@ -0,0 +1,153 @@
+// Package llm provides background health monitoring of LLM providers.
+package llm
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultProbeInterval is how often the monitor pings the provider.
+	DefaultProbeInterval = 30 * time.Second
+
+	// probeWindow is the number of recent probe results kept.
+	probeWindow = 10
+
+	// unhealthyAfter is the number of consecutive failed probes after which
+	// the provider is considered down.
+	unhealthyAfter = 3
+
+	// probeTimeout bounds a single probe request.
+	probeTimeout = 15 * time.Second
+)
+
+// ProviderHealth is a snapshot of a provider's recent probe results.
+type ProviderHealth struct {
+	Healthy     bool      `json:"healthy"`
+	Probes      int       `json:"probes"`       // Probes in the sliding window
+	Successes   int       `json:"successes"`    // Successful probes in the window
+	LastProbe   time.Time `json:"last_probe"`   // Zero before the first probe
+	LastSuccess time.Time `json:"last_success"` // Zero if no probe has succeeded
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// ProviderHealthMonitor periodically pings an LLM provider with a cheap
+// completion and tracks whether it is reachable, so that requests can be
+// rejected up front during an outage instead of failing mid-verification.
+type ProviderHealthMonitor struct {
+	provider Provider
+	interval time.Duration
+
+	// healthy is read on every request, so it is kept outside the mutex.
+	healthy atomic.Bool
+
+	mu          sync.Mutex
+	results     []bool // Most recent last, at most probeWindow entries
+	lastProbe   time.Time
+	lastSuccess time.Time
+	lastErr     error
+}
+
+// NewProviderHealthMonitor creates a monitor that probes provider every
+// interval. The provider is assumed healthy until probes say otherwise.
+func NewProviderHealthMonitor(provider Provider, interval time.Duration) *ProviderHealthMonitor {
+	if interval <= 0 {
+		interval = DefaultProbeInterval
+	}
+	m := &ProviderHealthMonitor{provider: provider, interval: interval}
+	m.healthy.Store(true)
+	return m
+}
+
+// Run probes the provider immediately and then every interval until ctx is
+// cancelled.
+func (m *ProviderHealthMonitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.Probe(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Probe sends a single ping completion and records the result.
+func (m *ProviderHealthMonitor) Probe(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	opts := DefaultCompletionOptions()
+	opts.MaxTokens = 5
+	_, err := m.provider.Complete(ctx, "Say OK in one word", opts)
+	m.record(err)
+	return err
+}
+
+// record adds a probe result to the window and updates the health flag.
+// The provider turns unhealthy after consecutive failures and healthy again
+// on the first success.
+func (m *ProviderHealthMonitor) record(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.results = append(m.results, err == nil)
+	if len(m.results) > probeWindow {
+		m.results = m.results[len(m.results)-probeWindow:]
+	}
+	m.lastProbe = time.Now()
+	m.lastErr = err
+	if err == nil {
+		m.lastSuccess = m.lastProbe
+	}
+
+	failures := 0
+	for i := len(m.results) - 1; i >= 0 && !m.results[i]; i-- {
+		failures++
+	}
+	healthy := failures < unhealthyAfter
+
+	if was := m.healthy.Swap(healthy); was != healthy {
+		if healthy {
+			log.Info().Str("provider", m.provider.Name()).Msg("LLM provider recovered")
+		} else {
+			log.Error().Err(err).Str("provider", m.provider.Name()).Int("failed_probes", failures).Msg("LLM provider is unhealthy")
+		}
+	} else if err != nil {
+		log.Warn().Err(err).Str("provider", m.provider.Name()).Msg("LLM provider probe failed")
+	}
+}
+
+// Healthy reports whether the provider is currently considered reachable.
+func (m *ProviderHealthMonitor) Healthy() bool {
+	return m.healthy.Load()
+}
+
+// Status returns a snapshot of the provider's recent probe results.
+func (m *ProviderHealthMonitor) Status() ProviderHealth {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := ProviderHealth{
+		Healthy:     m.healthy.Load(),
+		Probes:      len(m.results),
+		LastProbe:   m.lastProbe,
+		LastSuccess: m.lastSuccess,
+	}
+	for _, ok := range m.results {
+		if ok {
+			status.Successes++
+		}
+	}
+	if m.lastErr != nil {
+		status.LastError = m.lastErr.Error()
+	}
+	return status
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/factchecker/verity/internal/config"
+	"github.com/factchecker/verity/internal/httpclient"
 )
 
 // OllamaProvider implements Provider using local Ollama server.
@@ -34,7 +35,7 @@ func NewOllamaProvider(cfg *config.LLMConfig) (*OllamaProvider, error) {
 	return &OllamaProvider{
 		baseURL:    baseURL,
 		model:      model,
-		httpClient: &http.Client{},
+		httpClient: httpclient.NewLLMHTTPClient(*cfg),
 	}, nil
 }
 
@@ -43,11 +44,21 @@ func (p *OllamaProvider) Name() string {
 	return "ollama"
 }
 
+// Model returns the default completion model.
+func (p *OllamaProvider) Model() string {
+	return p.model
+}
+
 // SupportsEmbeddings returns true as Ollama supports embeddings.
 func (p *OllamaProvider) SupportsEmbeddings() bool {
 	return true
 }
 
+// SupportsVision returns false; image input is not implemented for Ollama.
+func (p *OllamaProvider) SupportsVision() bool {
+	return false
+}
+
 type ollamaGenerateRequest struct {
 	Model   string `json:"model"`
 	Prompt  string `json:"prompt"`
@@ -59,9 +70,11 @@ type ollamaGenerateRequest struct {
 }
 
 type ollamaGenerateResponse struct {
-	Response string `json:"response"`
-	Done     bool   `json:"done"`
-	Error    string `json:"error,omitempty"`
+	Response        string `json:"response"`
+	Done            bool   `json:"done"`
+	PromptEvalCount int64  `json:"prompt_eval_count"`
+	EvalCount       int64  `json:"eval_count"`
+	Error           string `json:"error,omitempty"`
 }
 
 type ollamaEmbeddingRequest struct {
@@ -124,6 +137,7 @@ func (p *OllamaProvider) CompleteWithSystem(ctx context.Context, system, user st
 	if result.Error != "" {
 		return "", fmt.Errorf("Ollama error: %s", result.Error)
 	}
+	recordUsage(ctx, TokensUsed{PromptTokens: result.PromptEvalCount, CompletionTokens: result.EvalCount})
 
 	return result.Response, nil
 }
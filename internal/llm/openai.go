@@ -46,11 +46,21 @@ func (p *OpenAIProvider) Name() string {
 	return "openai"
 }
 
+// Model returns the default completion model.
+func (p *OpenAIProvider) Model() string {
+	return p.model
+}
+
 // SupportsEmbeddings returns true as OpenAI supports embeddings.
 func (p *OpenAIProvider) SupportsEmbeddings() bool {
 	return true
 }
 
+// SupportsVision returns true; see CompleteWithImage.
+func (p *OpenAIProvider) SupportsVision() bool {
+	return true
+}
+
 // Complete generates a completion for the given prompt.
 func (p *OpenAIProvider) Complete(ctx context.Context, prompt string, opts CompletionOptions) (string, error) {
 	return p.CompleteWithSystem(ctx, "", prompt, opts)
@@ -58,6 +68,27 @@ func (p *OpenAIProvider) Complete(ctx context.Context, prompt string, opts Compl
 
 // CompleteWithSystem generates a completion with a system prompt.
 func (p *OpenAIProvider) CompleteWithSystem(ctx context.Context, system, user string, opts CompletionOptions) (string, error) {
+	return p.complete(ctx, system, openai.ChatCompletionMessage{
+		Role:    openai.ChatMessageRoleUser,
+		Content: user,
+	}, opts)
+}
+
+// CompleteWithImage generates a completion for a prompt about an image. The
+// image URL may be an http(s) URL or a base64 data URL. The configured model
+// must be vision-capable, such as gpt-4o.
+func (p *OpenAIProvider) CompleteWithImage(ctx context.Context, system, user, imageURL string, opts CompletionOptions) (string, error) {
+	return p.complete(ctx, system, openai.ChatCompletionMessage{
+		Role: openai.ChatMessageRoleUser,
+		MultiContent: []openai.ChatMessagePart{
+			{Type: openai.ChatMessagePartTypeImageURL, ImageURL: &openai.ChatMessageImageURL{URL: imageURL}},
+			{Type: openai.ChatMessagePartTypeText, Text: user},
+		},
+	}, opts)
+}
+
+// complete sends the user message, preceded by the system prompt if any.
+func (p *OpenAIProvider) complete(ctx context.Context, system string, user openai.ChatCompletionMessage, opts CompletionOptions) (string, error) {
 	model := opts.Model
 	if model == "" {
 		model = p.model
@@ -70,10 +101,7 @@ func (p *OpenAIProvider) CompleteWithSystem(ctx context.Context, system, user st
 			Content: system,
 		})
 	}
-	messages = append(messages, openai.ChatCompletionMessage{
-		Role:    openai.ChatMessageRoleUser,
-		Content: user,
-	})
+	messages = append(messages, user)
 
 	maxTokens := opts.MaxTokens
 	if maxTokens == 0 {
@@ -89,6 +117,10 @@ func (p *OpenAIProvider) CompleteWithSystem(ctx context.Context, system, user st
 	if err != nil {
 		return "", fmt.Errorf("OpenAI completion failed: %w", err)
 	}
+	recordUsage(ctx, TokensUsed{
+		PromptTokens:     int64(resp.Usage.PromptTokens),
+		CompletionTokens: int64(resp.Usage.CompletionTokens),
+	})
 
 	if len(resp.Choices) == 0 {
 		return "", fmt.Errorf("OpenAI returned no choices")
@@ -106,6 +138,7 @@ func (p *OpenAIProvider) Embed(ctx context.Context, text string) ([]float32, err
 	if err != nil {
 		return nil, fmt.Errorf("OpenAI embedding failed: %w", err)
 	}
+	recordUsage(ctx, TokensUsed{PromptTokens: int64(resp.Usage.PromptTokens)})
 
 	if len(resp.Data) == 0 {
 		return nil, fmt.Errorf("OpenAI returned no embeddings")
@@ -0,0 +1,89 @@
+// Package llm provides LLM cost estimation from a model pricing table.
+package llm
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ModelPricing is the price of a model in USD per 1,000 tokens.
+type ModelPricing struct {
+	InputPer1K  float64 `yaml:"input_per_1k"`
+	OutputPer1K float64 `yaml:"output_per_1k"`
+}
+
+// PricingTable maps model names, or name prefixes, to their prices.
+type PricingTable map[string]ModelPricing
+
+//go:embed pricing.yaml
+var defaultPricingYAML []byte
+
+// DefaultPricing returns the bundled pricing table.
+func DefaultPricing() PricingTable {
+	table := make(PricingTable)
+	if err := yaml.Unmarshal(defaultPricingYAML, &table); err != nil {
+		panic(fmt.Sprintf("invalid bundled pricing table: %v", err))
+	}
+	return table
+}
+
+// LoadPricing returns the bundled pricing table with the entries of the
+// YAML file at path, in the same format, added or replacing bundled ones.
+// An empty path returns the bundled table.
+func LoadPricing(path string) (PricingTable, error) {
+	table := DefaultPricing()
+	if path == "" {
+		return table, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return table, fmt.Errorf("failed to read pricing file: %w", err)
+	}
+	var overrides PricingTable
+	if err := yaml.Unmarshal(data, &overrides); err != nil {
+		return table, fmt.Errorf("failed to parse pricing file: %w", err)
+	}
+	for model, pricing := range overrides {
+		table[model] = pricing
+	}
+	return table, nil
+}
+
+// Lookup returns the pricing of model: its exact entry, else the entry of
+// the longest name prefix, so that gpt-4o-2024-08-06 is priced as gpt-4o.
+func (t PricingTable) Lookup(model string) (ModelPricing, bool) {
+	if p, ok := t[model]; ok {
+		return p, true
+	}
+	var best string
+	for name := range t {
+		if strings.HasPrefix(model, name) && len(name) > len(best) {
+			best = name
+		}
+	}
+	if best == "" {
+		return ModelPricing{}, false
+	}
+	return t[best], true
+}
+
+// EstimateCost returns the cost in USD of the given tokens with model, or
+// 0 for models not in the table, such as local Ollama models.
+func (t PricingTable) EstimateCost(model string, promptTokens, completionTokens int) float64 {
+	p, ok := t.Lookup(model)
+	if !ok {
+		return 0
+	}
+	return float64(promptTokens)/1000*p.InputPer1K + float64(completionTokens)/1000*p.OutputPer1K
+}
+
+// EstimateCost returns the cost in USD of the given tokens with model
+// according to the bundled pricing table.
+func EstimateCost(model string, promptTokens, completionTokens int) float64 {
+	return DefaultPricing().EstimateCost(model, promptTokens, completionTokens)
+}
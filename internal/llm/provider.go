@@ -37,8 +37,21 @@ type Provider interface {
 	// Name returns the provider name.
 	Name() string
 
+	// Model returns the model used when CompletionOptions.Model is empty.
+	Model() string
+
 	// SupportsEmbeddings returns whether this provider supports embeddings.
 	SupportsEmbeddings() bool
+
+	// SupportsVision returns whether this provider implements VisionProvider.
+	SupportsVision() bool
+}
+
+// VisionProvider is implemented by providers that accept images.
+type VisionProvider interface {
+	// CompleteWithImage generates a completion for a prompt about the image
+	// at imageURL, which may be a base64 data URL.
+	CompleteWithImage(ctx context.Context, system, user, imageURL string, opts CompletionOptions) (string, error)
 }
 
 // NewProvider creates a new LLM provider based on configuration.
@@ -46,13 +59,43 @@ func NewProvider(cfg *config.LLMConfig) (Provider, error) {
 	switch cfg.Provider {
 	case "openai":
 		return NewOpenAIProvider(cfg)
+	case "azure":
+		return NewAzureProvider(cfg)
 	case "anthropic":
 		return NewAnthropicProvider(cfg)
 	case "gemini":
 		return NewGeminiProvider(cfg)
 	case "ollama":
 		return NewOllamaProvider(cfg)
+	case "cohere":
+		return NewCohereProvider(cfg)
+	case "mistral":
+		return NewMistralProvider(cfg)
 	default:
 		return nil, fmt.Errorf("unsupported LLM provider: %s", cfg.Provider)
 	}
 }
+
+// defaultContextWindows are conservative context sizes, in tokens, for
+// providers when none is configured.
+var defaultContextWindows = map[string]int{
+	"openai":    128000,
+	"azure":     128000,
+	"anthropic": 200000,
+	"gemini":    1000000,
+	"ollama":    8192,
+	"cohere":    128000,
+	"mistral":   32000,
+}
+
+// ContextWindow returns the configured context window in tokens, falling
+// back to the provider's default.
+func ContextWindow(cfg *config.LLMConfig) int {
+	if cfg.ContextWindowTokens > 0 {
+		return cfg.ContextWindowTokens
+	}
+	if n, ok := defaultContextWindows[cfg.Provider]; ok {
+		return n
+	}
+	return 8192
+}
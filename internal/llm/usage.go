@@ -0,0 +1,53 @@
+// Package llm provides per-request token usage accounting.
+package llm
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// TokensUsed is the token usage an LLM API reported for one or more calls.
+type TokensUsed struct {
+	PromptTokens     int64 `json:"prompt_tokens"`
+	CompletionTokens int64 `json:"completion_tokens"`
+}
+
+// UsageRecorder accumulates the tokens of every LLM call made with a
+// context from WithUsageRecorder. It is safe for concurrent use.
+type UsageRecorder struct {
+	prompt     atomic.Int64
+	completion atomic.Int64
+
+	// parent is the recorder of an enclosing context, which is charged too
+	parent *UsageRecorder
+}
+
+type usageRecorderKey struct{}
+
+// WithUsageRecorder returns a context that records the token usage of LLM
+// calls made with it, or with contexts derived from it, in recorder. Calls
+// still count towards recorders already in ctx, so that a request and each
+// verification within it can be accounted separately.
+func WithUsageRecorder(ctx context.Context) (_ context.Context, recorder *UsageRecorder) {
+	parent, _ := ctx.Value(usageRecorderKey{}).(*UsageRecorder)
+	recorder = &UsageRecorder{parent: parent}
+	return context.WithValue(ctx, usageRecorderKey{}, recorder), recorder
+}
+
+// Total returns the tokens recorded so far.
+func (r *UsageRecorder) Total() TokensUsed {
+	return TokensUsed{
+		PromptTokens:     r.prompt.Load(),
+		CompletionTokens: r.completion.Load(),
+	}
+}
+
+// recordUsage adds the usage reported for an LLM call to the recorders in
+// ctx, if any. Providers call it once per successful API response.
+func recordUsage(ctx context.Context, used TokensUsed) {
+	r, _ := ctx.Value(usageRecorderKey{}).(*UsageRecorder)
+	for ; r != nil; r = r.parent {
+		r.prompt.Add(used.PromptTokens)
+		r.completion.Add(used.CompletionTokens)
+	}
+}
@@ -0,0 +1,62 @@
+// Package metrics provides Prometheus operational metrics.
+//
+// Metrics are registered with the default Prometheus registry, whose
+// collectors update atomically so recording never blocks the request path,
+// and are served in the Prometheus exposition format by Handler.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefaultBuckets are the latency histogram upper bounds in seconds. They
+// extend Prometheus' defaults to a minute, since a verification can take
+// that long.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// Metrics exposed by Verity.
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "verity_http_requests_total",
+		Help: "Total HTTP requests by method, route and status code.",
+	}, []string{"method", "path", "status"})
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "verity_http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds by method and route.",
+		Buckets: DefaultBuckets,
+	}, []string{"method", "path"})
+	VerificationsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "verity_verifications_in_flight",
+		Help: "Documents currently being extracted and verified.",
+	})
+	ClaimsVerified = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "verity_claims_verified_total",
+		Help: "Total claims verified by resulting status.",
+	}, []string{"status"})
+	LLMCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "verity_llm_calls_total",
+		Help: "Total LLM calls by provider.",
+	}, []string{"provider"})
+	SearchCalls = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "verity_search_calls_total",
+		Help: "Total evidence searches by source.",
+	}, []string{"source"})
+	LLMFallbacks = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "verity_llm_fallback_total",
+		Help: "Total verifications retried with the fallback model after an unparseable response.",
+	})
+	URLPreValidationHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "verity_url_prevalidation_hits_total",
+		Help: "Total search result URLs dropped by HEAD pre-validation.",
+	})
+)
+
+// Handler serves every registered metric, including the Go runtime and
+// process metrics of the default registry.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
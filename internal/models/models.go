@@ -37,43 +37,180 @@ const (
 	SourceTypeModelBased     SourceType = "model_based"
 )
 
+// Source formats of claims extracted from HTML documents.
+const (
+	SourceFormatProse = "prose"
+	SourceFormatTable = "table"
+	SourceFormatList  = "list"
+)
+
 // Claim represents an atomic factual claim extracted from text.
 type Claim struct {
-	ID                 string             `json:"id"`
-	Text               string             `json:"text"`
-	Type               ClaimType          `json:"type"`
-	SentenceIndex      int                `json:"sentence_index"`
-	Status             VerificationStatus `json:"status"`
-	Confidence         float64            `json:"confidence"`
-	SourceType         SourceType         `json:"source_type"`
-	Evidences          []Evidence         `json:"evidences"`
-	Reasoning          string             `json:"reasoning,omitempty"`
-	CreatedAt          time.Time          `json:"created_at"`
+	ID                  string             `json:"id"`
+	Text                string             `json:"text"`
+	Type                ClaimType          `json:"type"`
+	SubType             string             `json:"sub_type,omitempty"` // Ontology refinement of Type, e.g. "macroeconomic"
+	SentenceIndex       int                `json:"sentence_index"`
+	SentenceIndices     []int              `json:"sentence_indices,omitempty"` // Sentences of duplicate claims merged into this one
+	OriginalSentence    string             `json:"original_sentence,omitempty"`
+	ExtractabilityScore float64            `json:"extractability_score"` // How verifiable the claim is (0-1)
+	IsOpinion           bool               `json:"is_opinion"`
+	DetectedLanguage    string             `json:"detected_language,omitempty"`  // ISO 639-1 code
+	SourceFormat        string             `json:"source_format,omitempty"`      // prose, table or list, for HTML input
+	TranslatedText      string             `json:"translated_text,omitempty"`    // Text in the primary language, used for evidence search
+	SourceDocument      string             `json:"source_document,omitempty"`    // Model that generated the text, when disclosed
+	SuggestedSearches   []string           `json:"suggested_searches,omitempty"` // Queries that could resolve an inconclusive verdict
+	Status              VerificationStatus `json:"status"`
+	Confidence          float64            `json:"confidence"`
+	SourceType          SourceType         `json:"source_type"`
+	Evidences           []Evidence         `json:"evidences"`
+	Reasoning           string             `json:"reasoning,omitempty"`
+	ChainOfThought      string             `json:"chain_of_thought,omitempty"`
+	Significance        float64            `json:"significance"`
+	CreatedAt           time.Time          `json:"created_at"`
+	AnalysisID          string             `json:"analysis_id,omitempty"` // Only set by claim search
 }
 
 // Evidence represents a piece of evidence found for a claim.
 type Evidence struct {
-	ID             string    `json:"id"`
-	SourceName     string    `json:"source_name"`
-	SourceURL      string    `json:"source_url"`
-	SourceType     string    `json:"source_type"` // web, academic, encyclopedia
-	Snippet        string    `json:"snippet"`
-	RelevanceScore float64   `json:"relevance_score"`
-	RetrievedAt    time.Time `json:"retrieved_at"`
+	ID             string     `json:"id"`
+	SourceName     string     `json:"source_name"`
+	SourceURL      string     `json:"source_url"`
+	SourceType     string     `json:"source_type"`       // web, academic, encyclopedia
+	Fetcher        string     `json:"fetcher,omitempty"` // Search source that retrieved it, e.g. "DuckDuckGo"
+	Snippet        string     `json:"snippet"`
+	ContentType    string     `json:"content_type,omitempty"` // Media type of the fetched page, when fetched
+	RelevanceScore float64    `json:"relevance_score"`
+	TrustScore     float64    `json:"trust_score"` // Ranking score from the evidence ranking formula
+	RetrievedAt    time.Time  `json:"retrieved_at"`
+	PublishedAt    *time.Time `json:"published_at,omitempty"` // Publication date, when the source reports one
+	IsUseful       bool       `json:"is_useful"`
+	Usefulness     string     `json:"usefulness,omitempty"` // LLM explanation of usefulness
+	ClaimID        string     `json:"claim_id,omitempty"`   // Only set when looked up by URL
+}
+
+// EvidenceQualityStats aggregates evidence usefulness for a domain and source type.
+type EvidenceQualityStats struct {
+	Domain                 string  `json:"domain"`
+	SourceType             string  `json:"source_type"`
+	TotalEvidences         int     `json:"total_evidences"`
+	UsefulEvidences        int     `json:"useful_evidences"`
+	PercentUseful          float64 `json:"percent_useful"`
+	AvgUsefulSnippetLength float64 `json:"avg_useful_snippet_length"`
+}
+
+// DBHealthStats describes the size and contents of the database.
+type DBHealthStats struct {
+	DBSizeBytes             int64      `json:"db_size_bytes"`
+	WALSizeBytes            int64      `json:"sqlite_wal_size_bytes"`
+	AnalysisCount           int        `json:"analysis_count"`
+	ClaimCount              int        `json:"claim_count"`
+	APIKeyCount             int        `json:"api_key_count"`
+	AuditLogCount           int        `json:"audit_log_count"`
+	OldestAnalysisCreatedAt *time.Time `json:"oldest_analysis_created_at,omitempty"`
+	NewestAnalysisCreatedAt *time.Time `json:"newest_analysis_created_at,omitempty"`
+	AvgClaimsPerAnalysis    float64    `json:"avg_claims_per_analysis"`
+	CollectedAt             time.Time  `json:"collected_at"`
+}
+
+// ProviderComparison records how several LLM providers verified the same
+// claim against the same evidence.
+type ProviderComparison struct {
+	ID              string           `json:"id"`
+	ClaimID         string           `json:"claim_id"`
+	ProviderResults []ProviderResult `json:"provider_results"`
+	Agreement       bool             `json:"agreement"` // Every provider reached the same status
+	CreatedAt       time.Time        `json:"created_at"`
+}
+
+// ProviderResult is one provider's verdict in a ProviderComparison.
+type ProviderResult struct {
+	Provider   string             `json:"provider"`
+	Model      string             `json:"model,omitempty"`
+	Status     VerificationStatus `json:"status,omitempty"`
+	Confidence float64            `json:"confidence"`
+	Reasoning  string             `json:"reasoning,omitempty"`
+	Error      string             `json:"error,omitempty"`
+}
+
+// WebhookStatus is the delivery state of a webhook notification.
+type WebhookStatus string
+
+const (
+	WebhookPending   WebhookStatus = "pending"
+	WebhookDelivered WebhookStatus = "delivered"
+	WebhookFailed    WebhookStatus = "failed"
+)
+
+// WebhookDelivery records a completed verification being posted to the
+// webhook URL given in its request.
+type WebhookDelivery struct {
+	ID          string        `json:"id"`
+	AnalysisID  string        `json:"analysis_id"`
+	URL         string        `json:"url"`
+	Status      WebhookStatus `json:"status"`
+	Attempts    int           `json:"attempts"`
+	LastError   string        `json:"last_error,omitempty"`
+	CreatedAt   time.Time     `json:"created_at"`
+	DeliveredAt *time.Time    `json:"delivered_at,omitempty"`
+}
+
+// ScoreTrendPoint summarizes analysis scores within one time bucket.
+type ScoreTrendPoint struct {
+	Date     string  `json:"date"`
+	Count    int     `json:"count"`
+	AvgScore float64 `json:"avg_score"`
+	MinScore float64 `json:"min_score"`
+	MaxScore float64 `json:"max_score"`
 }
 
 // AnalysisResult represents the overall result of fact-checking a document.
 type AnalysisResult struct {
-	ID                  string    `json:"id"`
-	DocumentHash        string    `json:"document_hash"`
-	OverallScore        float64   `json:"overall_score"`
-	TotalClaims         int       `json:"total_claims"`
-	VerifiedClaims      int       `json:"verified_claims"`
-	MixedClaims         int       `json:"mixed_claims"`
-	UnsupportedClaims   int       `json:"unsupported_claims"`
-	ProcessingTimeMs    int64     `json:"processing_time_ms"`
-	Status              string    `json:"status"` // pending, processing, completed, failed
-	CreatedAt           time.Time `json:"created_at"`
+	ID                string    `json:"id"`
+	DocumentHash      string    `json:"document_hash"`
+	OverallScore      float64   `json:"overall_score"`
+	TotalClaims       int       `json:"total_claims"`
+	VerifiedClaims    int       `json:"verified_claims"`
+	MixedClaims       int       `json:"mixed_claims"`
+	UnsupportedClaims int       `json:"unsupported_claims"`
+	ProcessingTimeMs  int64     `json:"processing_time_ms"`
+	Status            string    `json:"status"` // pending, processing, completed, failed
+	CreatedAt         time.Time `json:"created_at"`
+	TopClaims         []Claim   `json:"top_claims,omitempty"` // Most significant claims, for digests
+
+	// ScoreInterval is the uncertainty range of OverallScore.
+	ScoreInterval ScoreConfidenceInterval `json:"score_confidence_interval"`
+
+	// Claim text lengths, in words. Long claims are harder to verify.
+	AvgClaimLength float64 `json:"avg_claim_length"`
+	MaxClaimLength int     `json:"max_claim_length"`
+	MinClaimLength int     `json:"min_claim_length"`
+
+	// ModelSource is the LLM reported to have generated the text, if any.
+	ModelSource string `json:"model_source,omitempty"`
+
+	// Jurisdiction is the place the text's claims were checked against.
+	Jurisdiction string `json:"jurisdiction,omitempty"`
+
+	// ScheduleReVerifyEvery, when set, re-verifies the analysis' claims
+	// periodically, for documents about evolving situations.
+	ScheduleReVerifyEvery *time.Duration `json:"-"`
+	LastReVerifiedAt      *time.Time     `json:"last_reverified_at,omitempty"`
+
+	// CacheKey identifies the request options the analysis was made with,
+	// empty for defaults. Only analyses with the same key are reused.
+	CacheKey string `json:"-"`
+
+	// Options is the JSON of the request options CacheKey was computed
+	// from, empty for defaults, so the analysis can be re-run with them.
+	Options string `json:"-"`
+}
+
+// ScoreConfidenceInterval bounds an analysis' overall score (0-10) at 95%
+// confidence.
+type ScoreConfidenceInterval struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
 }
 
 // VerificationResponse is the API response for a verification request.
@@ -83,6 +220,26 @@ type VerificationResponse struct {
 	Analysis     AnalysisResult `json:"analysis"`
 	Claims       []Claim        `json:"claims"`
 	Warnings     []Warning      `json:"warnings,omitempty"`
+
+	// Contradictions are pairs of claims of the document that cannot both
+	// be true; their claims have status mixed.
+	Contradictions []Contradiction `json:"contradictions,omitempty"`
+
+	Stale      bool   `json:"stale,omitempty"`      // Cached result older than the staleness threshold
+	Refreshing bool   `json:"refreshing,omitempty"` // A background re-verification is in progress
+	Error      string `json:"error,omitempty"`      // Set instead of a result when a batch document failed
+
+	// EstimatedCostUSD is the estimated LLM cost of this verification; 0
+	// for cached results and models without known pricing.
+	EstimatedCostUSD float64 `json:"estimated_cost_usd,omitempty"`
+}
+
+// Contradiction is a pair of claims from the same document that
+// contradict each other.
+type Contradiction struct {
+	ClaimID            string `json:"claim_id"`
+	ConflictingClaimID string `json:"conflicting_claim_id"`
+	Explanation        string `json:"explanation,omitempty"`
 }
 
 // Warning represents a non-fatal issue during processing.
@@ -93,31 +250,112 @@ type Warning struct {
 
 // APIKey represents an API key for authentication.
 type APIKey struct {
-	ID                string    `json:"id"`
-	KeyHash           string    `json:"-"` // Never expose
-	Name              string    `json:"name"`
-	RequestsPerMinute int       `json:"requests_per_minute"`
-	TokensPerDay      int       `json:"tokens_per_day"`
-	CreatedAt         time.Time `json:"created_at"`
+	ID                string     `json:"id"`
+	KeyHash           string     `json:"-"` // Never expose
+	Name              string     `json:"name"`
+	RequestsPerMinute int        `json:"requests_per_minute"`
+	TokensPerDay      int        `json:"tokens_per_day"`
+	CreatedAt         time.Time  `json:"created_at"`
 	LastUsedAt        *time.Time `json:"last_used_at,omitempty"`
+	ExpiresAt         *time.Time `json:"expires_at,omitempty"` // nil never expires
+	Disabled          bool       `json:"disabled,omitempty"`   // set once an expired key has been swept
+	Role              string     `json:"role"`
+}
+
+// API key roles. Admin keys may call every endpoint; reader keys may not
+// verify text, manage keys or read audit logs.
+const (
+	RoleAdmin  = "admin"
+	RoleReader = "reader"
+)
+
+// ConfigOverride is a configuration value set at runtime through the admin
+// API, taking precedence over the configuration file.
+type ConfigOverride struct {
+	Key            string    `json:"key"`
+	Value          string    `json:"value"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	UpdatedByKeyID string    `json:"updated_by_key_id,omitempty"`
 }
 
 // AuditLog represents an API request audit entry.
 type AuditLog struct {
-	ID            string    `json:"id"`
-	APIKeyID      string    `json:"api_key_id"`
-	Endpoint      string    `json:"endpoint"`
-	Method        string    `json:"method"`
-	RequestSize   int64     `json:"request_size"`
-	ResponseCode  int       `json:"response_code"`
-	DurationMs    int64     `json:"duration_ms"`
-	Timestamp     time.Time `json:"timestamp"`
+	ID           string    `json:"id"`
+	APIKeyID     string    `json:"api_key_id"`
+	Endpoint     string    `json:"endpoint"`
+	Method       string    `json:"method"`
+	RequestSize  int64     `json:"request_size"`
+	ResponseCode int       `json:"response_code"`
+	DurationMs   int64     `json:"duration_ms"`
+	Timestamp    time.Time `json:"timestamp"`
+	PrevHash     string    `json:"prev_hash"`
+	Hash         string    `json:"hash"`
+
+	// Details records the outcome of administrative operations
+	Details map[string]interface{} `json:"details,omitempty"`
+
+	// LLM tokens spent serving the request, as reported by the provider
+	PromptTokens     int64 `json:"prompt_tokens"`
+	CompletionTokens int64 `json:"completion_tokens"`
+}
+
+// ProvenanceVersion is the schema version of ClaimProvenance responses.
+const ProvenanceVersion = "1"
+
+// ClaimProvenance traces a claim back through its evidence to the analysis
+// it belongs to.
+type ClaimProvenance struct {
+	ProvenanceVersion string               `json:"provenance_version"`
+	Claim             ProvenanceClaim      `json:"claim"`
+	Evidences         []ProvenanceEvidence `json:"evidences"`
+	Analysis          ProvenanceAnalysis   `json:"analysis"`
+}
+
+// ProvenanceClaim identifies a claim in a provenance chain.
+type ProvenanceClaim struct {
+	ID         string `json:"id"`
+	Text       string `json:"text"`
+	AnalysisID string `json:"analysis_id"`
+}
+
+// ProvenanceEvidence records where and when a piece of evidence was fetched.
+type ProvenanceEvidence struct {
+	URL           string    `json:"url"`
+	FetchedAt     time.Time `json:"fetched_at"`
+	Fetcher       string    `json:"fetcher"`
+	SnippetLength int       `json:"snippet_length"`
+}
+
+// ProvenanceAnalysis identifies the analysis a claim belongs to.
+type ProvenanceAnalysis struct {
+	ID           string    `json:"id"`
+	DocumentHash string    `json:"document_hash"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ClaimUpdate is a reviewer correction to a claim's verdict.
+type ClaimUpdate struct {
+	ID           string             `json:"id"`
+	Status       VerificationStatus `json:"status"`
+	Confidence   *float64           `json:"confidence,omitempty"` // Optional: unchanged when omitted
+	Reasoning    string             `json:"reasoning,omitempty"`  // Optional: unchanged when empty
+	ReviewerNote string             `json:"reviewer_note,omitempty"`
 }
 
 // VerifyRequest is the request body for verification endpoints.
 type VerifyRequest struct {
-	Text        string `json:"text"`
-	ModelSource string `json:"model_source,omitempty"` // Optional: GPT-4, Claude, etc.
+	Text              string   `json:"text"`
+	ModelSource       string   `json:"model_source,omitempty"`       // Optional: GPT-4, Claude, etc.
+	EvidenceLanguages []string `json:"evidence_languages,omitempty"` // Optional: overrides configured language filter
+	Explain           bool     `json:"explain,omitempty"`            // Optional: include chain-of-thought reasoning
+	FocusHints        []string `json:"focus_hints,omitempty"`        // Optional: topics to prioritize during extraction
+	IgnoreHints       []string `json:"ignore_hints,omitempty"`       // Optional: topics to skip during extraction
+	ImageBase64       string   `json:"image_base64,omitempty"`       // Optional: image to extract claims from, base64-encoded
+	ImageURL          string   `json:"image_url,omitempty"`          // Optional: image to extract claims from, by URL
+	Format            string   `json:"format,omitempty"`             // Optional: "abstract" for scientific abstracts
+	Jurisdiction      string   `json:"jurisdiction,omitempty"`       // Optional: where claims apply, e.g. US, BR, EU
+	WebhookURL        string   `json:"webhook_url,omitempty"`        // Optional: receives the response by POST when done
+	WebhookSecret     string   `json:"webhook_secret,omitempty"`     // Optional: HMAC-SHA256 key signing webhook bodies
 }
 
 // BatchVerifyRequest is the request body for batch verification.
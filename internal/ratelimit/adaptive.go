@@ -0,0 +1,118 @@
+// Package ratelimit provides load-adaptive rate limiting.
+package ratelimit
+
+import (
+	"math"
+	"runtime"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// loadSampleInterval is how often CPU and heap usage are sampled.
+	loadSampleInterval = 5 * time.Second
+
+	// throttleAbove and recoverBelow are the load fractions at which limits
+	// are reduced and restored. The gap keeps limits from flapping.
+	throttleAbove = 0.8
+	recoverBelow  = 0.6
+
+	// throttleFactor scales every limit while the server is under load.
+	throttleFactor = 0.5
+)
+
+// LoadAdaptiveRateLimiter wraps a Limiter and halves every key's limit while
+// the process is under heavy CPU or memory load. CPU load is the process's
+// CPU time over wall time across all cores; memory load is the heap size
+// relative to the runtime memory limit (GOMEMLIMIT), so it only applies when
+// a limit is set.
+type LoadAdaptiveRateLimiter struct {
+	limiter   *Limiter
+	throttled atomic.Bool
+
+	lastCPU    time.Duration
+	lastSample time.Time
+}
+
+// NewLoadAdaptiveRateLimiter creates a limiter whose buckets refill over the
+// given period and starts sampling server load.
+func NewLoadAdaptiveRateLimiter(period time.Duration) *LoadAdaptiveRateLimiter {
+	a := &LoadAdaptiveRateLimiter{limiter: NewLimiter(period)}
+	a.lastCPU, _ = processCPUTime()
+	a.lastSample = time.Now()
+	go a.monitor()
+	return a
+}
+
+// Take consumes a token from the bucket for key with the given limit,
+// reduced while the server is under load. It also reports whether the limit
+// was reduced.
+func (a *LoadAdaptiveRateLimiter) Take(key string, limit int) (State, bool, bool) {
+	throttled := a.throttled.Load()
+	if throttled {
+		limit = max(int(float64(limit)*throttleFactor), 1)
+	}
+	state, allowed := a.limiter.Take(key, limit)
+	return state, allowed, throttled
+}
+
+// Throttled reports whether limits are currently reduced.
+func (a *LoadAdaptiveRateLimiter) Throttled() bool {
+	return a.throttled.Load()
+}
+
+func (a *LoadAdaptiveRateLimiter) monitor() {
+	ticker := time.NewTicker(loadSampleInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		cpu, heap := a.sample()
+		a.adjust(cpu, heap)
+	}
+}
+
+// sample returns the CPU and heap load as fractions of the available
+// capacity. Either is zero when it cannot be measured.
+func (a *LoadAdaptiveRateLimiter) sample() (cpu, heap float64) {
+	now := time.Now()
+	if used, ok := processCPUTime(); ok {
+		wall := now.Sub(a.lastSample) * time.Duration(runtime.NumCPU())
+		if wall > 0 {
+			cpu = float64(used-a.lastCPU) / float64(wall)
+		}
+		a.lastCPU = used
+	}
+	a.lastSample = now
+
+	// A negative input reads the limit without changing it
+	if memLimit := debug.SetMemoryLimit(-1); memLimit > 0 && memLimit < math.MaxInt64 {
+		var ms runtime.MemStats
+		runtime.ReadMemStats(&ms)
+		heap = float64(ms.HeapAlloc) / float64(memLimit)
+	}
+	return cpu, heap
+}
+
+// adjust reduces limits when either load exceeds throttleAbove and restores
+// them once both are below recoverBelow.
+func (a *LoadAdaptiveRateLimiter) adjust(cpu, heap float64) {
+	throttled := a.throttled.Load()
+	switch {
+	case !throttled && (cpu > throttleAbove || heap > throttleAbove):
+		a.throttled.Store(true)
+		log.Info().
+			Float64("cpu", cpu).
+			Float64("heap", heap).
+			Float64("limit_factor", throttleFactor).
+			Msg("Server under load, reducing rate limits")
+	case throttled && cpu < recoverBelow && heap < recoverBelow:
+		a.throttled.Store(false)
+		log.Info().
+			Float64("cpu", cpu).
+			Float64("heap", heap).
+			Float64("limit_factor", 1).
+			Msg("Server load recovered, restoring rate limits")
+	}
+}
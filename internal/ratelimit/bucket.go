@@ -0,0 +1,145 @@
+// Package ratelimit provides token bucket rate limiting keyed by client.
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// State is a snapshot of a bucket after a request was considered.
+type State struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time // when the bucket will be full again
+}
+
+// TokenBucket allows up to capacity requests per period, refilling continuously.
+type TokenBucket struct {
+	mu         sync.Mutex
+	capacity   float64
+	tokens     float64
+	refillRate float64 // tokens per second
+	last       time.Time
+}
+
+// NewTokenBucket creates a full bucket holding capacity tokens per period.
+func NewTokenBucket(capacity int, period time.Duration) *TokenBucket {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &TokenBucket{
+		capacity:   float64(capacity),
+		tokens:     float64(capacity),
+		refillRate: float64(capacity) / period.Seconds(),
+		last:       time.Now(),
+	}
+}
+
+// Take consumes a token if one is available and reports the resulting state.
+func (b *TokenBucket) Take() (State, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.refill(now)
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	return b.state(now), allowed
+}
+
+// Capacity returns the bucket's maximum number of tokens.
+func (b *TokenBucket) Capacity() int {
+	return int(b.capacity)
+}
+
+// Resize changes the bucket to hold capacity tokens per period. Tokens
+// already available are kept, up to the new capacity, so that resizing
+// never grants a client a fresh burst.
+func (b *TokenBucket) Resize(capacity int, period time.Duration) {
+	if capacity < 1 {
+		capacity = 1
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refill(time.Now())
+	b.capacity = float64(capacity)
+	b.tokens = math.Min(b.tokens, b.capacity)
+	b.refillRate = float64(capacity) / period.Seconds()
+}
+
+func (b *TokenBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Seconds()
+	b.tokens = math.Min(b.capacity, b.tokens+elapsed*b.refillRate)
+	b.last = now
+}
+
+func (b *TokenBucket) state(now time.Time) State {
+	missing := b.capacity - b.tokens
+	return State{
+		Limit:     int(b.capacity),
+		Remaining: int(math.Floor(b.tokens)),
+		Reset:     now.Add(time.Duration(missing / b.refillRate * float64(time.Second))),
+	}
+}
+
+// idleBucketTTL is how long an unused bucket is kept before being dropped.
+const idleBucketTTL = 10 * time.Minute
+
+type entry struct {
+	bucket   *TokenBucket
+	lastSeen time.Time
+}
+
+// Limiter holds one token bucket per client key.
+type Limiter struct {
+	mu      sync.Mutex
+	period  time.Duration
+	buckets map[string]*entry
+}
+
+// NewLimiter creates a limiter whose buckets refill over the given period.
+func NewLimiter(period time.Duration) *Limiter {
+	l := &Limiter{
+		period:  period,
+		buckets: make(map[string]*entry),
+	}
+	go l.sweep()
+	return l
+}
+
+// Take consumes a token from the bucket for key, creating it with the given
+// limit if needed. A bucket whose limit changed is resized in place.
+func (l *Limiter) Take(key string, limit int) (State, bool) {
+	l.mu.Lock()
+	e, ok := l.buckets[key]
+	if !ok {
+		e = &entry{bucket: NewTokenBucket(limit, l.period)}
+		l.buckets[key] = e
+	} else if e.bucket.Capacity() != max(limit, 1) {
+		e.bucket.Resize(limit, l.period)
+	}
+	e.lastSeen = time.Now()
+	l.mu.Unlock()
+
+	return e.bucket.Take()
+}
+
+// sweep periodically drops buckets that have not been used recently.
+func (l *Limiter) sweep() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for range ticker.C {
+		l.mu.Lock()
+		for key, e := range l.buckets {
+			if time.Since(e.lastSeen) > idleBucketTTL {
+				delete(l.buckets, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
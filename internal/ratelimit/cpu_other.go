@@ -0,0 +1,10 @@
+//go:build !unix
+
+package ratelimit
+
+import "time"
+
+// processCPUTime is not available on this platform; only memory load is used.
+func processCPUTime() (time.Duration, bool) {
+	return 0, false
+}
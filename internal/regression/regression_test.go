@@ -0,0 +1,161 @@
+// Package regression checks that claim extraction and verification give
+// stable results for a fixed set of canonical cases, so that changes to the
+// system prompts or the post-processing around them can be reviewed against
+// a known baseline.
+package regression
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/factchecker/verity/internal/config"
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/models"
+	"github.com/factchecker/verity/internal/verify"
+)
+
+// fixtureDir holds one JSON file per regression case.
+const fixtureDir = "../../testdata/regression"
+
+// maxUnsupportedDrift is how many claims per case may move into or out of
+// the unsupported status before the case fails.
+const maxUnsupportedDrift = 1
+
+// regressionCase is a canonical input and the results expected for it.
+// Extraction is the scripted extraction response; ExtractionRaw is used
+// instead when the response is not a bare JSON object, e.g. fenced markdown.
+type regressionCase struct {
+	Name               string          `json:"name"`
+	Text               string          `json:"text"`
+	Extraction         json.RawMessage `json:"extraction"`
+	ExtractionRaw      string          `json:"extraction_raw"`
+	ExpectedClaimCount int             `json:"expected_claim_count"`
+	Claims             []expectedClaim `json:"claims"`
+}
+
+// expectedClaim is a claim the extraction should keep, the evidence it is
+// verified against, the scripted verdict and the expected outcome.
+type expectedClaim struct {
+	Text           string          `json:"text"`
+	Evidence       []string        `json:"evidence"`
+	Verdict        json.RawMessage `json:"verdict"`
+	ExpectedStatus string          `json:"expected_status"`
+	Confidence     [2]float64      `json:"confidence"` // inclusive [min, max]
+}
+
+func loadCases(t *testing.T) []regressionCase {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(fixtureDir, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatalf("no regression fixtures in %s", fixtureDir)
+	}
+
+	cases := make([]regressionCase, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var c regressionCase
+		if err := json.Unmarshal(data, &c); err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		cases = append(cases, c)
+	}
+	return cases
+}
+
+// newMockProvider scripts the extraction response and one verdict per
+// expected claim. Extraction prompts start with "Text to analyze:" and
+// verification prompts with "Claim: <text>\n", so neither matches the other.
+func newMockProvider(c regressionCase) *llm.MockProvider {
+	extraction := c.ExtractionRaw
+	if extraction == "" {
+		extraction = string(c.Extraction)
+	}
+	provider := llm.NewMockProvider("")
+	provider.On("Text to analyze:", extraction)
+	for _, claim := range c.Claims {
+		if claim.Verdict != nil {
+			provider.On("Claim: "+claim.Text+"\n", string(claim.Verdict))
+		}
+	}
+	return provider
+}
+
+func fixtureEvidence(snippets []string) []models.Evidence {
+	evidences := make([]models.Evidence, len(snippets))
+	for i, s := range snippets {
+		evidences[i] = models.Evidence{
+			SourceName: "Fixture",
+			SourceURL:  fmt.Sprintf("https://example.org/evidence/%d", i),
+			SourceType: "web",
+			Snippet:    s,
+		}
+	}
+	return evidences
+}
+
+func TestVerificationConsistency(t *testing.T) {
+	cfg := config.DefaultConfig()
+
+	for _, c := range loadCases(t) {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := context.Background()
+			provider := newMockProvider(c)
+			extractor := verify.NewClaimExtractor(provider, cfg)
+			verifier := verify.NewClaimVerifier(provider, "", llm.ContextWindow(&cfg.LLM))
+
+			claims, err := extractor.Extract(ctx, c.Text, verify.ExtractOptions{})
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if len(claims) != c.ExpectedClaimCount {
+				t.Fatalf("Extract() returned %d claims, want %d", len(claims), c.ExpectedClaimCount)
+			}
+
+			expected := make(map[string]expectedClaim, len(c.Claims))
+			for _, e := range c.Claims {
+				expected[e.Text] = e
+			}
+
+			unsupportedDrift := 0
+			for _, claim := range claims {
+				want, ok := expected[claim.Text]
+				if !ok {
+					t.Errorf("unexpected claim %q", claim.Text)
+					continue
+				}
+
+				verdict, err := verifier.Verify(ctx, claim, fixtureEvidence(want.Evidence), false, "")
+				if err != nil {
+					t.Errorf("Verify(%q) error = %v", claim.Text, err)
+					continue
+				}
+
+				wantStatus := models.VerificationStatus(want.ExpectedStatus)
+				if verdict.Status != wantStatus {
+					if verdict.Status == models.StatusUnsupported || wantStatus == models.StatusUnsupported {
+						unsupportedDrift++
+					} else {
+						t.Errorf("Verify(%q) status = %s, want %s", claim.Text, verdict.Status, wantStatus)
+					}
+				}
+				if verdict.Confidence < want.Confidence[0] || verdict.Confidence > want.Confidence[1] {
+					t.Errorf("Verify(%q) confidence = %.2f, want within [%.2f, %.2f]",
+						claim.Text, verdict.Confidence, want.Confidence[0], want.Confidence[1])
+				}
+			}
+			if unsupportedDrift > maxUnsupportedDrift {
+				t.Errorf("%d claims moved into or out of unsupported, want at most %d", unsupportedDrift, maxUnsupportedDrift)
+			}
+		})
+	}
+}
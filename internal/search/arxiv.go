@@ -0,0 +1,160 @@
+// Package search provides arXiv preprint search implementation.
+package search
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// arXivAPIURL is the arXiv search API, which answers with an Atom feed.
+const arXivAPIURL = "https://export.arxiv.org/api/query"
+
+// arXivRequestInterval is the delay arXiv asks clients to keep between
+// API calls, per https://info.arxiv.org/help/api/tou.html
+const arXivRequestInterval = 3 * time.Second
+
+// ArXivClient searches preprints using the arXiv API. Preprints are often
+// the canonical source for recent results in physics, mathematics and
+// computer science, long before or instead of journal publication.
+type ArXivClient struct {
+	httpClient *http.Client
+	throttle   *DomainThrottler
+}
+
+// NewArXivClient creates a new arXiv client.
+func NewArXivClient(httpClient *http.Client) *ArXivClient {
+	return &ArXivClient{
+		httpClient: httpClient,
+		throttle:   NewDomainThrottler(arXivRequestInterval),
+	}
+}
+
+// Name returns the source name.
+func (c *ArXivClient) Name() string {
+	return "arXiv"
+}
+
+// Available returns true as arXiv requires no API key.
+func (c *ArXivClient) Available() bool {
+	return true
+}
+
+type arXivFeed struct {
+	Entries []struct {
+		ID        string `xml:"id"`
+		Title     string `xml:"title"`
+		Summary   string `xml:"summary"`
+		Published string `xml:"published"`
+		Links     []struct {
+			Href  string `xml:"href,attr"`
+			Rel   string `xml:"rel,attr"`
+			Type  string `xml:"type,attr"`
+			Title string `xml:"title,attr"`
+		} `xml:"link"`
+	} `xml:"entry"`
+}
+
+// Search searches arXiv for preprints matching all keywords of the query.
+func (c *ArXivClient) Search(ctx context.Context, query string, maxResults int, opts SearchOptions) ([]models.Evidence, error) {
+	keywords := strings.Fields(extractKeywords(query))
+	if len(keywords) == 0 {
+		return nil, nil
+	}
+	terms := make([]string, len(keywords))
+	for i, k := range keywords {
+		terms[i] = "all:" + k
+	}
+	searchQuery := strings.Join(terms, " AND ")
+	log.Debug().Str("original", query).Str("search_query", searchQuery).Msg("arXiv: Searching")
+
+	params := url.Values{}
+	params.Set("search_query", searchQuery)
+	params.Set("max_results", fmt.Sprintf("%d", maxResults))
+	params.Set("sortBy", "relevance")
+
+	if err := c.throttle.Wait(ctx, "export.arxiv.org"); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", arXivAPIURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Verity/1.0 (Fact-checking tool)")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("arXiv search failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("arXiv returned status %d", resp.StatusCode)
+	}
+
+	var feed arXivFeed
+	if err := xml.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to decode feed: %w", err)
+	}
+
+	now := time.Now()
+	var evidences []models.Evidence
+
+	for _, entry := range feed.Entries {
+		title := strings.Join(strings.Fields(entry.Title), " ")
+		if title == "" {
+			continue
+		}
+
+		// Prefer the abstract page, then the PDF, then the entry ID (itself
+		// the abstract page URL)
+		link, pdf := "", ""
+		for _, l := range entry.Links {
+			switch {
+			case l.Rel == "alternate" && link == "":
+				link = l.Href
+			case l.Title == "pdf" || l.Type == "application/pdf":
+				pdf = l.Href
+			}
+		}
+		if link == "" {
+			link = pdf
+		}
+		if link == "" {
+			link = strings.TrimSpace(entry.ID)
+		}
+
+		snippet := title + " (arXiv preprint"
+		var publishedAt *time.Time
+		if published, err := time.Parse(time.RFC3339, strings.TrimSpace(entry.Published)); err == nil {
+			snippet += ", " + published.Format("2006-01-02")
+			publishedAt = &published
+		}
+		snippet += ")"
+		if abstract := strings.Join(strings.Fields(entry.Summary), " "); abstract != "" {
+			snippet += " " + truncate(abstract, maxAbstractLength)
+		}
+
+		evidences = append(evidences, models.Evidence{
+			ID:          uuid.New().String(),
+			SourceName:  "arXiv",
+			SourceURL:   link,
+			SourceType:  "preprint",
+			Snippet:     snippet,
+			RetrievedAt: now,
+			PublishedAt: publishedAt,
+		})
+	}
+
+	log.Debug().Int("count", len(evidences)).Msg("arXiv: Search completed")
+	return evidences, nil
+}
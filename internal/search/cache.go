@@ -0,0 +1,112 @@
+// Package search provides search result caching.
+package search
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// CachedSearchClient wraps a SearchClient and reuses its results for
+// repeated queries, so that a claim appearing in many documents is only
+// searched once per TTL. Failed searches are not cached.
+type CachedSearchClient struct {
+	SearchClient
+	ttl time.Duration
+
+	entries   sync.Map // key -> searchCacheEntry
+	lastSweep atomic.Int64
+}
+
+type searchCacheEntry struct {
+	evidence []models.Evidence
+	expires  time.Time
+}
+
+// NewCachedSearchClient wraps client with a cache of results younger than ttl.
+func NewCachedSearchClient(client SearchClient, ttl time.Duration) *CachedSearchClient {
+	c := &CachedSearchClient{SearchClient: client, ttl: ttl}
+	c.lastSweep.Store(time.Now().UnixNano())
+	return c
+}
+
+// Close closes the wrapped client if it holds resources.
+func (c *CachedSearchClient) Close() error {
+	if closer, ok := c.SearchClient.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Search returns cached evidence for the query when available, searching
+// the wrapped client otherwise. Each call gets fresh evidence IDs.
+func (c *CachedSearchClient) Search(ctx context.Context, query string, maxResults int, opts SearchOptions) ([]models.Evidence, error) {
+	key := c.key(query, maxResults, opts)
+	now := time.Now()
+
+	if v, ok := c.entries.Load(key); ok {
+		entry := v.(searchCacheEntry)
+		if now.Before(entry.expires) {
+			log.Debug().Str("source", c.Name()).Str("query", query).Msg("Search cache hit")
+			return copyEvidence(entry.evidence), nil
+		}
+		c.entries.Delete(key)
+	}
+
+	evidence, err := c.SearchClient.Search(ctx, query, maxResults, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	c.entries.Store(key, searchCacheEntry{evidence: copyEvidence(evidence), expires: now.Add(c.ttl)})
+	c.sweep(now)
+	return evidence, nil
+}
+
+// key identifies a search by source, query and every option that changes
+// its results.
+func (c *CachedSearchClient) key(query string, maxResults int, opts SearchOptions) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%d\x00%s\x00%s\x00%s",
+		c.Name(), query, maxResults, opts.ClaimLanguage, opts.Jurisdiction, strings.Join(opts.Languages, ","))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// sweep drops expired entries, at most once per TTL, so that one-off
+// queries do not accumulate in long-running servers.
+func (c *CachedSearchClient) sweep(now time.Time) {
+	last := c.lastSweep.Load()
+	if now.UnixNano()-last < int64(c.ttl) || !c.lastSweep.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+	c.entries.Range(func(k, v any) bool {
+		if !now.Before(v.(searchCacheEntry).expires) {
+			c.entries.Delete(k)
+		}
+		return true
+	})
+}
+
+// copyEvidence copies evidence with new IDs, so that callers may modify
+// the results and evidence from different verifications stays distinct.
+func copyEvidence(evidence []models.Evidence) []models.Evidence {
+	if evidence == nil {
+		return nil
+	}
+	copied := make([]models.Evidence, len(evidence))
+	copy(copied, evidence)
+	for i := range copied {
+		copied[i].ID = uuid.New().String()
+	}
+	return copied
+}
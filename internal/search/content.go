@@ -0,0 +1,69 @@
+// Package search provides content-type aware text extraction for fetched pages.
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// maxJSONContent bounds pretty-printed JSON returned as evidence.
+const maxJSONContent = 500
+
+// extractContent converts a fetched response body to plain text according to
+// its Content-Type, returning the text and the media type it was treated as.
+// Unsupported types, such as PDFs or images, return an error.
+func extractContent(body []byte, contentType string) (string, string, error) {
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	}
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, body, "", "  "); err != nil {
+			return "", mediaType, fmt.Errorf("invalid JSON: %w", err)
+		}
+		text := pretty.String()
+		if len(text) > maxJSONContent {
+			text = text[:maxJSONContent]
+		}
+		return text, mediaType, nil
+	case mediaType == "application/xml" || mediaType == "text/xml" || (strings.HasSuffix(mediaType, "+xml") && mediaType != "application/xhtml+xml"):
+		return extractTextFromXML(body), mediaType, nil
+	case mediaType == "text/plain":
+		return strings.TrimSpace(string(body)), mediaType, nil
+	case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+		return extractTextFromHTML(string(body)), mediaType, nil
+	default:
+		return "", mediaType, fmt.Errorf("unsupported content type %s", mediaType)
+	}
+}
+
+// extractTextFromXML joins the text nodes of an XML document.
+func extractTextFromXML(body []byte) string {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.Strict = false
+
+	var parts []string
+	for {
+		tok, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		if data, ok := tok.(xml.CharData); ok {
+			if text := strings.TrimSpace(string(data)); text != "" {
+				parts = append(parts, text)
+			}
+		}
+	}
+	return strings.Join(strings.Fields(strings.Join(parts, " ")), " ")
+}
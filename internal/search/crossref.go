@@ -0,0 +1,127 @@
+// Package search provides CrossRef paper lookup.
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/google/uuid"
+)
+
+// CrossRefClient looks up papers in the CrossRef works API, which covers
+// journals and publishers outside PubMed's biomedical scope.
+type CrossRefClient struct {
+	httpClient *http.Client
+}
+
+// NewCrossRefClient creates a new CrossRef client using httpClient.
+func NewCrossRefClient(httpClient *http.Client) *CrossRefClient {
+	return &CrossRefClient{httpClient: httpClient}
+}
+
+// Name returns the source name.
+func (c *CrossRefClient) Name() string {
+	return "CrossRef"
+}
+
+type crossrefAuthor struct {
+	Family string `json:"family"`
+}
+
+type crossrefResponse struct {
+	Message struct {
+		Items []struct {
+			DOI            string           `json:"DOI"`
+			URL            string           `json:"URL"`
+			Title          []string         `json:"title"`
+			ContainerTitle []string         `json:"container-title"`
+			Abstract       string           `json:"abstract"` // JATS XML
+			Author         []crossrefAuthor `json:"author"`
+		} `json:"items"`
+	} `json:"message"`
+}
+
+// FindPaper searches CrossRef for works published in year with an author
+// named author. Keywords are matched against the bibliographic record.
+func (c *CrossRefClient) FindPaper(ctx context.Context, author string, year int, keywords []string, maxResults int) ([]models.Evidence, error) {
+	params := url.Values{
+		"query.author": {author},
+		"filter":       {fmt.Sprintf("from-pub-date:%d,until-pub-date:%d", year, year)},
+		"rows":         {fmt.Sprintf("%d", maxResults)},
+		"select":       {"DOI,URL,title,container-title,abstract,author"},
+	}
+	if len(keywords) > 0 {
+		params.Set("query.bibliographic", strings.Join(keywords, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.crossref.org/works?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Verity/1.0 (fact-checker)")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("CrossRef search failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("CrossRef returned status %d", resp.StatusCode)
+	}
+
+	var data crossrefResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode CrossRef response: %w", err)
+	}
+
+	now := time.Now()
+	// The query is filtered to year, so every work was published in it
+	publishedAt := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var evidences []models.Evidence
+	for _, item := range data.Message.Items {
+		if len(item.Title) == 0 || !hasAuthor(item.Author, author) {
+			continue
+		}
+
+		snippet := item.Title[0]
+		if len(item.ContainerTitle) > 0 {
+			snippet += fmt.Sprintf(" (Published in %s, %d)", item.ContainerTitle[0], year)
+		}
+		if abstract := extractTextFromXML([]byte(item.Abstract)); abstract != "" {
+			snippet += " " + truncate(abstract, maxAbstractLength)
+		}
+
+		link := item.URL
+		if link == "" {
+			link = "https://doi.org/" + item.DOI
+		}
+
+		evidences = append(evidences, models.Evidence{
+			ID:          uuid.New().String(),
+			SourceName:  "CrossRef",
+			SourceURL:   link,
+			SourceType:  "academic",
+			Snippet:     snippet,
+			RetrievedAt: now,
+			PublishedAt: &publishedAt,
+		})
+	}
+	return evidences, nil
+}
+
+// hasAuthor reports whether any author's family name matches surname.
+func hasAuthor(authors []crossrefAuthor, surname string) bool {
+	for _, a := range authors {
+		if strings.EqualFold(a.Family, surname) {
+			return true
+		}
+	}
+	return false
+}
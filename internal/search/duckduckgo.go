@@ -4,6 +4,7 @@ package search
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,22 +14,103 @@ import (
 	"sync"
 	"time"
 
+	"github.com/factchecker/verity/internal/metrics"
 	"github.com/factchecker/verity/internal/models"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 	"golang.org/x/net/html"
 )
 
+// defaultPageFetchTimeout applies when no page fetch timeout is configured.
+const defaultPageFetchTimeout = 10 * time.Second
+
+// preValidateTimeout bounds each HEAD request made to pre-validate a URL.
+const preValidateTimeout = 3 * time.Second
+
+// deadStatuses are HEAD responses that mean a page is not worth fetching.
+var deadStatuses = map[int]bool{
+	http.StatusForbidden:                  true,
+	http.StatusNotFound:                   true,
+	http.StatusGone:                       true,
+	http.StatusUnavailableForLegalReasons: true,
+}
+
+// waybackAPIURL is the Wayback Machine availability API, which returns the
+// most recent snapshot of a URL.
+const waybackAPIURL = "https://archive.org/wayback/available"
+
+// pageStatusError is returned when a result page answers other than 200.
+type pageStatusError int
+
+func (e pageStatusError) Error() string {
+	return fmt.Sprintf("status %d", int(e))
+}
+
 // DuckDuckGoClient searches using DuckDuckGo and fetches page content.
 type DuckDuckGoClient struct {
-	httpClient *http.Client
+	httpClient     *http.Client
+	pageTimeout    time.Duration
+	domainTimeouts map[string]time.Duration
+	quality        *SnippetQualityFilter
+	preValidate    bool
+	throttler      *DomainThrottler
+	wayback        bool
 }
 
-// NewDuckDuckGoClient creates a new DuckDuckGo client.
-func NewDuckDuckGoClient() *DuckDuckGoClient {
+// NewDuckDuckGoClient creates a new DuckDuckGo client using httpClient. Result pages are
+// fetched with pageTimeout unless domainTimeouts has an entry for the host
+// or one of its parent domains. Page text with fewer than minSnippetWords
+// words, or that otherwise looks like boilerplate, is not used as evidence.
+func NewDuckDuckGoClient(httpClient *http.Client, pageTimeout time.Duration, domainTimeouts map[string]time.Duration, minSnippetWords int) *DuckDuckGoClient {
+	if pageTimeout <= 0 {
+		pageTimeout = defaultPageFetchTimeout
+	}
 	return &DuckDuckGoClient{
-		httpClient: &http.Client{Timeout: 15 * time.Second},
+		httpClient:     httpClient,
+		pageTimeout:    pageTimeout,
+		domainTimeouts: domainTimeouts,
+		quality:        NewSnippetQualityFilter(minSnippetWords),
+	}
+}
+
+// SetPreValidateURLs enables checking result URLs with HEAD requests before
+// fetching their content, skipping pages that are gone or forbidden.
+func (c *DuckDuckGoClient) SetPreValidateURLs(enabled bool) {
+	c.preValidate = enabled
+}
+
+// SetWaybackFallback enables fetching the latest Wayback Machine snapshot
+// of result pages that answer with an error status. Evidence from a
+// snapshot has "[archived]" appended to its SourceName.
+func (c *DuckDuckGoClient) SetWaybackFallback(enabled bool) {
+	c.wayback = enabled
+}
+
+// SetMinDomainFetchInterval spaces result page fetches from the same domain
+// at least interval apart. Zero disables throttling.
+func (c *DuckDuckGoClient) SetMinDomainFetchInterval(interval time.Duration) {
+	c.throttler = NewDomainThrottler(interval)
+}
+
+// fetchTimeout returns the page fetch timeout for a URL's host, checking the
+// host itself and then each parent domain.
+func (c *DuckDuckGoClient) fetchTimeout(pageURL string) time.Duration {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return c.pageTimeout
+	}
+	host := strings.ToLower(u.Hostname())
+	for host != "" {
+		if t, ok := c.domainTimeouts[host]; ok && t > 0 {
+			return t
+		}
+		i := strings.Index(host, ".")
+		if i < 0 {
+			break
+		}
+		host = host[i+1:]
 	}
+	return c.pageTimeout
 }
 
 // Name returns the source name.
@@ -109,8 +191,12 @@ func extractKeywords(claim string) string {
 }
 
 // Search searches DuckDuckGo for evidence and fetches page content with retry logic.
-func (c *DuckDuckGoClient) Search(ctx context.Context, query string, maxResults int) ([]models.Evidence, error) {
+func (c *DuckDuckGoClient) Search(ctx context.Context, query string, maxResults int, opts SearchOptions) ([]models.Evidence, error) {
 	keywords := extractKeywords(query)
+	if opts.Jurisdiction != "" {
+		keywords += " " + opts.Jurisdiction
+	}
+	acceptLang := acceptLanguage(opts.ClaimLanguage)
 	log.Debug().Str("original", query).Str("keywords", keywords).Msg("DuckDuckGo: Searching")
 
 	var evidences []models.Evidence
@@ -131,7 +217,7 @@ func (c *DuckDuckGoClient) Search(ctx context.Context, query string, maxResults
 
 	// Retry logic for HTML search
 	for attempt := 0; attempt < 2; attempt++ {
-		htmlEvidences, err := c.searchHTMLWithContent(ctx, keywords, maxResults)
+		htmlEvidences, err := c.searchHTMLWithContent(ctx, keywords, maxResults, acceptLang)
 		if err == nil {
 			evidences = append(evidences, htmlEvidences...)
 			break
@@ -223,6 +309,23 @@ func (c *DuckDuckGoClient) searchInstantAnswer(ctx context.Context, query string
 	return evidences, nil
 }
 
+// defaultAcceptLanguage prefers Portuguese, then English, when the claim's
+// language is unknown.
+const defaultAcceptLanguage = "pt-PT,pt;q=0.9,en-US;q=0.8,en;q=0.7"
+
+// acceptLanguage returns the Accept-Language header for a claim in the
+// given language, falling back to English for results in other languages.
+func acceptLanguage(claimLanguage string) string {
+	switch lang := NormalizeLanguage(claimLanguage); lang {
+	case "":
+		return defaultAcceptLanguage
+	case "en":
+		return "en-US,en;q=0.9"
+	default:
+		return lang + ",en;q=0.8"
+	}
+}
+
 // searchResult holds parsed search result data
 type searchResult struct {
 	Title   string
@@ -231,15 +334,19 @@ type searchResult struct {
 }
 
 // searchHTMLWithContent searches and fetches actual page content
-func (c *DuckDuckGoClient) searchHTMLWithContent(ctx context.Context, query string, maxResults int) ([]models.Evidence, error) {
+func (c *DuckDuckGoClient) searchHTMLWithContent(ctx context.Context, query string, maxResults int, acceptLang string) ([]models.Evidence, error) {
 	// Get search results
-	results, err := c.getSearchResults(ctx, query, maxResults+2)
+	results, err := c.getSearchResults(ctx, query, maxResults+2, acceptLang)
 	if err != nil {
 		return nil, err
 	}
 
 	log.Debug().Int("results", len(results)).Msg("DuckDuckGo: Found search results")
 
+	if c.preValidate {
+		results = c.preValidateURLs(ctx, results)
+	}
+
 	// Fetch content from top results concurrently
 	var evidences []models.Evidence
 	var mu sync.Mutex
@@ -255,11 +362,29 @@ func (c *DuckDuckGoClient) searchHTMLWithContent(ctx context.Context, query stri
 		wg.Add(1)
 		go func(r searchResult) {
 			defer wg.Done()
+			// Wait for the domain's turn before taking a fetch slot, so
+			// throttled fetches do not hold up other domains
+			throttleErr := c.throttler.Wait(ctx, extractDomain(r.URL))
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
 
 			// Try to fetch page content
-			content, err := c.fetchPageContent(ctx, r.URL)
+			var content, contentType string
+			err := throttleErr
+			if err == nil {
+				content, contentType, err = c.fetchPageContent(ctx, r.URL, acceptLang)
+			}
+			sourceName := extractDomain(r.URL)
+			var status pageStatusError
+			if c.wayback && errors.As(err, &status) {
+				if archived, werr := c.fetchViaWayback(ctx, r.URL); werr == nil {
+					log.Debug().Str("url", r.URL).Int("status", int(status)).Msg("Using archived copy of page")
+					content, contentType, err = archived, "", nil
+					sourceName += " [archived]"
+				} else {
+					log.Debug().Str("url", r.URL).Err(werr).Msg("No archived copy of page")
+				}
+			}
 			if err != nil {
 				log.Debug().Str("url", r.URL).Err(err).Msg("Failed to fetch page")
 				// Use snippet from search results as fallback
@@ -275,14 +400,25 @@ func (c *DuckDuckGoClient) searchHTMLWithContent(ctx context.Context, query stri
 				content = content[:1000] + "..."
 			}
 
+			// Garbled page text falls back to the search result snippet
+			if !c.quality.IsHighQuality(content) && content != r.Snippet {
+				log.Debug().Str("url", r.URL).Msg("Low quality page content, using search snippet")
+				content = r.Snippet
+			}
+			if !c.quality.IsHighQuality(content) {
+				log.Debug().Str("url", r.URL).Msg("Discarding low quality snippet")
+				return
+			}
+
 			if content != "" {
 				mu.Lock()
 				evidences = append(evidences, models.Evidence{
 					ID:          uuid.New().String(),
-					SourceName:  extractDomain(r.URL),
+					SourceName:  sourceName,
 					SourceURL:   r.URL,
 					SourceType:  "web_page",
 					Snippet:     content,
+					ContentType: contentType,
 					RetrievedAt: time.Now(),
 				})
 				mu.Unlock()
@@ -294,8 +430,58 @@ func (c *DuckDuckGoClient) searchHTMLWithContent(ctx context.Context, query stri
 	return evidences, nil
 }
 
+// preValidateURLs sends a HEAD request to each result URL concurrently and
+// drops those answering 403, 404, 410 or 451. Redirects are followed and
+// the result URL is replaced by the final one. URLs whose HEAD request
+// fails or gets any other status are kept, since many servers do not
+// handle HEAD properly.
+func (c *DuckDuckGoClient) preValidateURLs(ctx context.Context, results []searchResult) []searchResult {
+	dead := make([]bool, len(results))
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(r *searchResult, dead *bool) {
+			defer wg.Done()
+			req, err := http.NewRequestWithContext(ctx, http.MethodHead, r.URL, nil)
+			if err != nil {
+				return
+			}
+			req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+
+			client := *c.httpClient
+			client.Timeout = preValidateTimeout
+			resp, err := client.Do(req)
+			if err != nil {
+				log.Debug().Str("url", r.URL).Err(err).Msg("URL pre-validation failed, fetching anyway")
+				return
+			}
+			resp.Body.Close()
+
+			if deadStatuses[resp.StatusCode] {
+				log.Debug().Str("url", r.URL).Int("status", resp.StatusCode).Msg("Dropping dead result URL")
+				*dead = true
+				return
+			}
+			if final := resp.Request.URL.String(); final != r.URL {
+				r.URL = final
+			}
+		}(&results[i], &dead[i])
+	}
+	wg.Wait()
+
+	kept := results[:0]
+	for i, r := range results {
+		if dead[i] {
+			metrics.URLPreValidationHits.Inc()
+			continue
+		}
+		kept = append(kept, r)
+	}
+	return kept
+}
+
 // getSearchResults parses DuckDuckGo HTML search results
-func (c *DuckDuckGoClient) getSearchResults(ctx context.Context, query string, maxResults int) ([]searchResult, error) {
+func (c *DuckDuckGoClient) getSearchResults(ctx context.Context, query string, maxResults int, acceptLang string) ([]searchResult, error) {
 	u := fmt.Sprintf("https://html.duckduckgo.com/html/?q=%s", url.QueryEscape(query))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
@@ -304,7 +490,7 @@ func (c *DuckDuckGoClient) getSearchResults(ctx context.Context, query string, m
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
 	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "pt-PT,pt;q=0.9,en-US;q=0.8,en;q=0.7")
+	req.Header.Set("Accept-Language", acceptLang)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -384,42 +570,90 @@ func decodeRedirectURL(rawURL string) string {
 	return rawURL
 }
 
-// fetchPageContent fetches and extracts text content from a web page
-func (c *DuckDuckGoClient) fetchPageContent(ctx context.Context, pageURL string) (string, error) {
+// fetchPageContent fetches a web page and extracts its text according to
+// the response Content-Type, which is returned alongside the text
+func (c *DuckDuckGoClient) fetchPageContent(ctx context.Context, pageURL, acceptLang string) (string, string, error) {
 	// Skip certain domains that block scraping
 	skipDomains := []string{"facebook.com", "instagram.com", "twitter.com", "x.com", "linkedin.com"}
 	for _, domain := range skipDomains {
 		if strings.Contains(pageURL, domain) {
-			return "", fmt.Errorf("skipped domain")
+			return "", "", fmt.Errorf("skipped domain")
 		}
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET", pageURL, nil)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
 	req.Header.Set("Accept", "text/html,application/xhtml+xml")
-	req.Header.Set("Accept-Language", "pt-PT,pt;q=0.9,en;q=0.8")
+	req.Header.Set("Accept-Language", acceptLang)
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	timeout := c.fetchTimeout(pageURL)
+	log.Trace().Str("url", pageURL).Dur("timeout", timeout).Msg("Fetching page")
+	// Copy the client to change the timeout while keeping the shared transport
+	client := *c.httpClient
+	client.Timeout = timeout
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("status %d", resp.StatusCode)
+		return "", "", pageStatusError(resp.StatusCode)
 	}
 
 	// Limit body size
 	body, err := io.ReadAll(io.LimitReader(resp.Body, 500*1024))
+	if err != nil {
+		return "", "", err
+	}
+
+	return extractContent(body, resp.Header.Get("Content-Type"))
+}
+
+// fetchViaWayback looks up the most recent Wayback Machine snapshot of
+// pageURL and returns its text.
+func (c *DuckDuckGoClient) fetchViaWayback(ctx context.Context, pageURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", waybackAPIURL+"?url="+url.QueryEscape(pageURL), nil)
 	if err != nil {
 		return "", err
 	}
+	req.Header.Set("User-Agent", "Verity/1.0 (Fact-checking tool)")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("wayback lookup failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("wayback lookup returned status %d", resp.StatusCode)
+	}
+
+	var availability struct {
+		ArchivedSnapshots struct {
+			Closest struct {
+				Available bool   `json:"available"`
+				Timestamp string `json:"timestamp"`
+				Status    string `json:"status"`
+			} `json:"closest"`
+		} `json:"archived_snapshots"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&availability); err != nil {
+		return "", fmt.Errorf("failed to decode wayback response: %w", err)
+	}
+	snapshot := availability.ArchivedSnapshots.Closest
+	if !snapshot.Available || snapshot.Timestamp == "" || snapshot.Status != "200" {
+		return "", fmt.Errorf("no snapshot available")
+	}
 
-	return extractTextFromHTML(string(body)), nil
+	// The id_ modifier serves the page as archived, without the Wayback
+	// Machine's toolbar
+	snapshotURL := "https://web.archive.org/web/" + snapshot.Timestamp + "id_/" + pageURL
+	content, _, err := c.fetchPageContent(ctx, snapshotURL, "")
+	return content, err
 }
 
 // extractTextFromHTML extracts readable text from HTML content
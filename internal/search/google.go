@@ -0,0 +1,154 @@
+// Package search provides Google Custom Search implementation.
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// googleMaxResults is the most results the Custom Search API returns per
+// request.
+const googleMaxResults = 10
+
+// googleSearchURL is the Custom Search JSON API endpoint.
+const googleSearchURL = "https://www.googleapis.com/customsearch/v1"
+
+// ErrGoogleQuotaExceeded is returned when the Custom Search API rejects a
+// request because the daily or per-minute query quota is used up.
+var ErrGoogleQuotaExceeded = errors.New("Google Custom Search quota exceeded")
+
+// GoogleSearchClient searches using the Google Custom Search JSON API.
+type GoogleSearchClient struct {
+	httpClient *http.Client
+	apiKey     string
+	engineID   string
+}
+
+// NewGoogleSearchClient creates a new Google Custom Search client for the
+// given API key and programmable search engine ID (cx).
+func NewGoogleSearchClient(httpClient *http.Client, apiKey, engineID string) *GoogleSearchClient {
+	return &GoogleSearchClient{
+		httpClient: httpClient,
+		apiKey:     apiKey,
+		engineID:   engineID,
+	}
+}
+
+// Name returns the source name.
+func (c *GoogleSearchClient) Name() string {
+	return "Google"
+}
+
+// Available returns true if both an API key and a search engine ID are set.
+func (c *GoogleSearchClient) Available() bool {
+	return c.apiKey != "" && c.engineID != ""
+}
+
+type googleSearchResponse struct {
+	Items []struct {
+		Title   string `json:"title"`
+		Link    string `json:"link"`
+		Snippet string `json:"snippet"`
+	} `json:"items"`
+}
+
+type googleErrorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+		Errors  []struct {
+			Reason string `json:"reason"`
+		} `json:"errors"`
+	} `json:"error"`
+}
+
+// quotaExceeded reports whether the error body reports a quota or rate
+// limit, which Google signals with 403 as well as 429.
+func (r *googleErrorResponse) quotaExceeded() bool {
+	for _, e := range r.Error.Errors {
+		switch e.Reason {
+		case "rateLimitExceeded", "dailyLimitExceeded", "userRateLimitExceeded", "quotaExceeded":
+			return true
+		}
+	}
+	return false
+}
+
+// Search searches Google for evidence, in the claim's language when known.
+func (c *GoogleSearchClient) Search(ctx context.Context, query string, maxResults int, opts SearchOptions) ([]models.Evidence, error) {
+	keywords := extractKeywords(query)
+	if opts.Jurisdiction != "" {
+		keywords += " " + opts.Jurisdiction
+	}
+	log.Debug().Str("original", query).Str("keywords", keywords).Msg("Google: Searching")
+
+	params := url.Values{}
+	params.Set("key", c.apiKey)
+	params.Set("cx", c.engineID)
+	params.Set("q", keywords)
+	params.Set("num", strconv.Itoa(max(1, min(maxResults, googleMaxResults))))
+	if lang := NormalizeLanguage(opts.ClaimLanguage); lang != "" {
+		params.Set("hl", lang)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", googleSearchURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Verity/1.0 (Fact-checking tool)")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Google search failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errData googleErrorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&errData)
+		if resp.StatusCode == http.StatusTooManyRequests ||
+			resp.StatusCode == http.StatusForbidden && errData.quotaExceeded() {
+			return nil, ErrGoogleQuotaExceeded
+		}
+		if errData.Error.Message != "" {
+			return nil, fmt.Errorf("Google returned status %d: %s", resp.StatusCode, errData.Error.Message)
+		}
+		return nil, fmt.Errorf("Google returned status %d", resp.StatusCode)
+	}
+
+	var data googleSearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	now := time.Now()
+	var evidences []models.Evidence
+	for _, item := range data.Items {
+		if item.Link == "" || item.Snippet == "" {
+			continue
+		}
+		evidences = append(evidences, models.Evidence{
+			ID:          uuid.New().String(),
+			SourceName:  extractDomain(item.Link),
+			SourceURL:   item.Link,
+			SourceType:  "search_engine",
+			Snippet:     item.Snippet,
+			RetrievedAt: now,
+		})
+		if len(evidences) >= maxResults {
+			break
+		}
+	}
+
+	log.Debug().Int("count", len(evidences)).Msg("Google: Search completed")
+	return evidences, nil
+}
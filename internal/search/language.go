@@ -0,0 +1,131 @@
+// Package search provides lightweight language detection for evidence snippets.
+package search
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/language"
+)
+
+// languageStopWords holds high-frequency function words used to tell apart
+// languages written in the Latin script.
+var languageStopWords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "in", "that", "was", "for", "with", "are", "by", "this", "from"},
+	"pt": {"de", "que", "não", "em", "uma", "para", "com", "os", "do", "da", "são", "foi", "pelo", "também"},
+	"es": {"de", "que", "el", "los", "las", "en", "por", "una", "con", "para", "del", "es", "fue", "también"},
+	"fr": {"le", "la", "les", "et", "des", "est", "une", "du", "dans", "pour", "que", "pas", "sur", "avec"},
+	"de": {"der", "die", "und", "das", "ist", "nicht", "ein", "eine", "mit", "von", "den", "für", "auch", "wird"},
+	"it": {"il", "di", "che", "la", "per", "una", "sono", "della", "con", "non", "gli", "del", "anche", "nel"},
+}
+
+// DetectLanguage returns the ISO 639-1 code of the most likely language of
+// text, or "" when it cannot be determined with reasonable confidence.
+// Non-Latin scripts are identified by code point ranges; Latin-script
+// languages by stop-word frequency.
+func DetectLanguage(text string) string {
+	var letters, han, kana, hangul, cyrillic, arabic, greek, hebrew int
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Arabic, r):
+			arabic++
+		case unicode.Is(unicode.Greek, r):
+			greek++
+		case unicode.Is(unicode.Hebrew, r):
+			hebrew++
+		}
+	}
+	if letters == 0 {
+		return ""
+	}
+
+	threshold := letters / 3
+	switch {
+	case kana > 0 && kana+han > threshold:
+		return "ja"
+	case han > threshold:
+		return "zh"
+	case hangul > threshold:
+		return "ko"
+	case cyrillic > threshold:
+		return "ru"
+	case arabic > threshold:
+		return "ar"
+	case greek > threshold:
+		return "el"
+	case hebrew > threshold:
+		return "he"
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	counts := make(map[string]int, len(words))
+	for _, w := range words {
+		counts[w]++
+	}
+
+	best, bestScore, secondScore := "", 0, 0
+	for lang, stopWords := range languageStopWords {
+		score := 0
+		for _, sw := range stopWords {
+			score += counts[sw]
+		}
+		if score > bestScore {
+			best, bestScore, secondScore = lang, score, bestScore
+		} else if score > secondScore {
+			secondScore = score
+		}
+	}
+
+	// Require a few hits and a clear margin to avoid guessing on short text.
+	if bestScore < 3 || bestScore == secondScore {
+		return ""
+	}
+	return best
+}
+
+// NormalizeLanguage reduces a language tag such as "fr-CA" to its base
+// ISO 639-1 code, or returns "" when tag is not a valid language.
+func NormalizeLanguage(tag string) string {
+	t, err := language.Parse(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if err != nil {
+		return ""
+	}
+	base, confidence := t.Base()
+	if confidence != language.Exact {
+		return ""
+	}
+	return base.String()
+}
+
+// normalizeLanguages reduces language tags such as "en-US" or "pt_BR" to
+// their base ISO 639-1 code.
+func normalizeLanguages(tags []string) map[string]bool {
+	if len(tags) == 0 {
+		return nil
+	}
+	allowed := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		t, err := language.Parse(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+		if err != nil {
+			allowed[strings.ToLower(tag)] = true
+			continue
+		}
+		base, _ := t.Base()
+		allowed[base.String()] = true
+	}
+	return allowed
+}
@@ -0,0 +1,173 @@
+// Package search provides near-duplicate snippet detection with MinHash.
+package search
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// MinHash parameters: signatures of minHashSize values split into
+// minHashBands bands of minHashRows rows. Two snippets become candidates
+// when any band matches exactly, which for 16×8 happens with probability
+// above 0.9 once their similarity exceeds about 0.8.
+const (
+	minHashSize  = 128
+	minHashBands = 16
+	minHashRows  = minHashSize / minHashBands
+)
+
+// defaultNearDuplicateThreshold is the estimated Jaccard similarity above
+// which two snippets are considered the same text.
+const defaultNearDuplicateThreshold = 0.8
+
+// MinHashDeduplicator drops evidence whose snippet is a near duplicate of
+// a higher-priority one, such as the same wire story republished by
+// several syndicators. Similarity is the Jaccard index of the snippets'
+// character 3-grams, estimated with MinHash; banding (locality-sensitive
+// hashing) limits comparisons to likely candidates.
+type MinHashDeduplicator struct {
+	threshold float64
+	seeds     [minHashSize]uint64
+}
+
+// NewMinHashDeduplicator creates a deduplicator treating snippets with an
+// estimated similarity above threshold as duplicates.
+func NewMinHashDeduplicator(threshold float64) *MinHashDeduplicator {
+	d := &MinHashDeduplicator{threshold: threshold}
+	seed := uint64(0x9E3779B97F4A7C15)
+	for i := range d.seeds {
+		seed = splitmix64(seed)
+		d.seeds[i] = seed
+	}
+	return d
+}
+
+// Dedupe keeps, of each group of near-duplicate snippets, the evidence with
+// the highest relevance score, or the earliest on ties. Surviving evidence
+// keeps its original order. Evidence without a snippet is always kept.
+func (d *MinHashDeduplicator) Dedupe(evidences []models.Evidence) []models.Evidence {
+	if d == nil || len(evidences) < 2 {
+		return evidences
+	}
+
+	order := make([]int, len(evidences))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return evidences[order[i]].RelevanceScore > evidences[order[j]].RelevanceScore
+	})
+
+	signatures := make(map[int]*[minHashSize]uint64)
+	buckets := make(map[[2]uint64][]int) // (band, band hash) -> kept evidence
+	drop := make(map[int]bool)
+	for _, i := range order {
+		sig := d.signature(evidences[i].Snippet)
+		if sig == nil {
+			continue
+		}
+		keys := bandKeys(sig)
+
+		duplicate := false
+	candidates:
+		for _, key := range keys {
+			for _, j := range buckets[key] {
+				if similarity(sig, signatures[j]) > d.threshold {
+					log.Debug().
+						Str("url", evidences[i].SourceURL).
+						Str("duplicate_of", evidences[j].SourceURL).
+						Msg("Dropping near-duplicate evidence snippet")
+					duplicate = true
+					break candidates
+				}
+			}
+		}
+		if duplicate {
+			drop[i] = true
+			continue
+		}
+
+		signatures[i] = sig
+		for _, key := range keys {
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+
+	if len(drop) == 0 {
+		return evidences
+	}
+	kept := evidences[:0:0]
+	for i, e := range evidences {
+		if !drop[i] {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// signature computes the MinHash signature of text's character 3-grams,
+// after lowercasing and collapsing whitespace. It returns nil for empty text.
+func (d *MinHashDeduplicator) signature(text string) *[minHashSize]uint64 {
+	runes := []rune(strings.Join(strings.Fields(strings.ToLower(text)), " "))
+	if len(runes) == 0 {
+		return nil
+	}
+
+	var sig [minHashSize]uint64
+	for i := range sig {
+		sig[i] = ^uint64(0)
+	}
+	for start := 0; start == 0 || start+3 <= len(runes); start++ {
+		shingle := string(runes[start:min(start+3, len(runes))])
+		h := fnv.New64a()
+		h.Write([]byte(shingle))
+		base := h.Sum64()
+		for i, seed := range d.seeds {
+			if v := splitmix64(base ^ seed); v < sig[i] {
+				sig[i] = v
+			}
+		}
+	}
+	return &sig
+}
+
+// bandKeys hashes each band of a signature into a bucket key.
+func bandKeys(sig *[minHashSize]uint64) [minHashBands][2]uint64 {
+	var keys [minHashBands][2]uint64
+	buf := make([]byte, 8)
+	for b := range keys {
+		h := fnv.New64a()
+		for _, v := range sig[b*minHashRows : (b+1)*minHashRows] {
+			binary.LittleEndian.PutUint64(buf, v)
+			h.Write(buf)
+		}
+		keys[b] = [2]uint64{uint64(b), h.Sum64()}
+	}
+	return keys
+}
+
+// similarity estimates the Jaccard similarity of two sets from their
+// MinHash signatures as the fraction of matching values.
+func similarity(a, b *[minHashSize]uint64) float64 {
+	same := 0
+	for i := range a {
+		if a[i] == b[i] {
+			same++
+		}
+	}
+	return float64(same) / minHashSize
+}
+
+// splitmix64 is a fast 64-bit mixing function, used to derive independent
+// hash functions from one base hash.
+func splitmix64(x uint64) uint64 {
+	x += 0x9E3779B97F4A7C15
+	x = (x ^ (x >> 30)) * 0xBF58476D1CE4E5B9
+	x = (x ^ (x >> 27)) * 0x94D049BB133111EB
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,152 @@
+// Package search provides NewsAPI search implementation.
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// newsAPIURL is the NewsAPI endpoint searching all indexed articles.
+const newsAPIURL = "https://newsapi.org/v2/everything"
+
+// newsAPILanguages are the article languages NewsAPI can filter by.
+var newsAPILanguages = map[string]bool{
+	"ar": true, "de": true, "en": true, "es": true, "fr": true, "he": true, "it": true,
+	"nl": true, "no": true, "pt": true, "ru": true, "sv": true, "ud": true, "zh": true,
+}
+
+// NewsAPIClient searches news articles using NewsAPI, which indexes
+// articles within minutes of publication and so covers breaking news that
+// general web search has not picked up yet.
+type NewsAPIClient struct {
+	httpClient *http.Client
+	apiKey     string
+}
+
+// NewNewsAPIClient creates a new NewsAPI client.
+func NewNewsAPIClient(httpClient *http.Client, apiKey string) *NewsAPIClient {
+	return &NewsAPIClient{
+		httpClient: httpClient,
+		apiKey:     apiKey,
+	}
+}
+
+// Name returns the source name.
+func (c *NewsAPIClient) Name() string {
+	return "NewsAPI"
+}
+
+// Available returns true if an API key is set.
+func (c *NewsAPIClient) Available() bool {
+	return c.apiKey != ""
+}
+
+type newsAPIResponse struct {
+	Status   string `json:"status"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Articles []struct {
+		Source struct {
+			Name string `json:"name"`
+		} `json:"source"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		URL         string `json:"url"`
+		PublishedAt string `json:"publishedAt"`
+	} `json:"articles"`
+}
+
+// Search searches news articles for evidence, in the claim's language when
+// NewsAPI supports it and English otherwise, most relevant first.
+func (c *NewsAPIClient) Search(ctx context.Context, query string, maxResults int, opts SearchOptions) ([]models.Evidence, error) {
+	keywords := extractKeywords(query)
+	if opts.Jurisdiction != "" {
+		keywords += " " + opts.Jurisdiction
+	}
+	log.Debug().Str("original", query).Str("keywords", keywords).Msg("NewsAPI: Searching")
+
+	language := NormalizeLanguage(opts.ClaimLanguage)
+	if !newsAPILanguages[language] {
+		language = "en"
+	}
+
+	params := url.Values{}
+	params.Set("q", keywords)
+	params.Set("language", language)
+	params.Set("sortBy", "relevancy")
+	params.Set("pageSize", strconv.Itoa(max(1, min(maxResults, 100))))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", newsAPIURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Verity/1.0 (Fact-checking tool)")
+	req.Header.Set("X-Api-Key", c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("NewsAPI search failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var data newsAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("NewsAPI returned status %d", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || data.Status == "error" {
+		if data.Code == "rateLimited" {
+			return nil, fmt.Errorf("NewsAPI request quota exceeded")
+		}
+		return nil, fmt.Errorf("NewsAPI returned status %d: %s", resp.StatusCode, data.Message)
+	}
+
+	now := time.Now()
+	var evidences []models.Evidence
+	for _, a := range data.Articles {
+		if a.URL == "" || a.Title == "" {
+			continue
+		}
+		snippet := a.Title
+		if a.Description != "" {
+			snippet = strings.TrimSuffix(snippet, ".") + ". " + a.Description
+		}
+		var publishedAt *time.Time
+		if published, err := time.Parse(time.RFC3339, a.PublishedAt); err == nil {
+			snippet += " (published " + published.Format("2006-01-02") + ")"
+			publishedAt = &published
+		}
+		sourceName := a.Source.Name
+		if sourceName == "" {
+			sourceName = extractDomain(a.URL)
+		}
+
+		evidences = append(evidences, models.Evidence{
+			ID:          uuid.New().String(),
+			SourceName:  sourceName,
+			SourceURL:   a.URL,
+			SourceType:  "news",
+			Snippet:     snippet,
+			RetrievedAt: now,
+			PublishedAt: publishedAt,
+		})
+		if len(evidences) >= maxResults {
+			break
+		}
+	}
+
+	log.Debug().Int("count", len(evidences)).Msg("NewsAPI: Search completed")
+	return evidences, nil
+}
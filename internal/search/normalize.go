@@ -0,0 +1,107 @@
+// Package search provides URL canonicalization for evidence deduplication.
+package search
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/factchecker/verity/internal/models"
+)
+
+// sessionParams are query parameters that identify a visit rather than a
+// resource. Parameters starting with utm_ are also removed.
+var sessionParams = map[string]bool{
+	"sid": true, "sessionid": true, "session_id": true, "phpsessid": true,
+	"jsessionid": true, "aspsessionid": true, "fbclid": true, "gclid": true,
+	"msclkid": true, "amp": true, "outputtype": true,
+}
+
+// mobileLabels are host labels used by mobile and AMP mirrors.
+var mobileLabels = map[string]bool{"m": true, "mobile": true, "amp": true}
+
+// CanonicalURL reduces a URL to a canonical form so that mobile, AMP, cached
+// and session-tagged variants of the same page compare equal. The result is
+// meant for comparison, not necessarily for fetching. Unparseable input is
+// returned unchanged.
+func CanonicalURL(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+
+	// AMP caches embed the origin URL in the path:
+	// www.google.com/amp/s/example.com/x, example-com.cdn.ampproject.org/c/s/example.com/x
+	host := strings.ToLower(u.Hostname())
+	if (strings.HasPrefix(host, "google.") || strings.Contains(host, ".google.")) && strings.HasPrefix(u.Path, "/amp/") {
+		return CanonicalURL("https://" + strings.TrimPrefix(strings.TrimPrefix(u.Path, "/amp/"), "s/"))
+	}
+	if strings.HasSuffix(host, ".cdn.ampproject.org") {
+		p := strings.TrimPrefix(u.Path, "/")
+		for _, prefix := range []string{"c/s/", "v/s/", "i/s/", "c/", "v/", "i/"} {
+			if strings.HasPrefix(p, prefix) {
+				return CanonicalURL("https://" + strings.TrimPrefix(p, prefix))
+			}
+		}
+	}
+
+	// Strip www, mobile and AMP subdomains, keeping at least two labels
+	labels := strings.Split(host, ".")
+	kept := labels[:0:0]
+	for i, l := range labels {
+		remaining := len(labels) - i
+		if (l == "www" || mobileLabels[l]) && len(kept)+remaining > 2 {
+			continue
+		}
+		kept = append(kept, l)
+	}
+	host = strings.Join(kept, ".")
+
+	path := u.Path
+	if i := strings.Index(strings.ToLower(path), ";jsessionid="); i >= 0 {
+		path = path[:i]
+	}
+	path = strings.TrimSuffix(path, "/amp")
+	path = strings.Replace(path, "/amp/", "/", 1)
+
+	query := u.Query()
+	for key := range query {
+		lower := strings.ToLower(key)
+		if sessionParams[lower] || strings.HasPrefix(lower, "utm_") {
+			query.Del(key)
+		}
+	}
+
+	// Wikipedia: /w/index.php?title=X and /wiki/X with spaces are the same page
+	if strings.HasSuffix(host, "wikipedia.org") {
+		if path == "/w/index.php" && query.Get("title") != "" {
+			path = "/wiki/" + query.Get("title")
+			query.Del("title")
+		}
+		path = strings.ReplaceAll(path, " ", "_")
+	}
+
+	path = strings.TrimSuffix(path, "/")
+	canonical := "https://" + host + path
+	if len(query) > 0 {
+		canonical += "?" + query.Encode()
+	}
+	return canonical
+}
+
+// dedupeByCanonicalURL drops evidence whose URL canonicalizes to one already
+// seen, keeping the first occurrence. Evidence without a URL is kept.
+func dedupeByCanonicalURL(evidences []models.Evidence) []models.Evidence {
+	seen := make(map[string]bool, len(evidences))
+	kept := evidences[:0:0]
+	for _, e := range evidences {
+		if e.SourceURL != "" {
+			key := CanonicalURL(e.SourceURL)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+		kept = append(kept, e)
+	}
+	return kept
+}
@@ -0,0 +1,117 @@
+// Package search provides search sources loaded from Go plugins.
+package search
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"plugin"
+	"strings"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// PluginSymbol is the constructor every search plugin must export:
+//
+//	func NewSearchClient() search.SearchClient
+const PluginSymbol = "NewSearchClient"
+
+// LoadPlugins loads every .so file in dir as a search plugin. Plugins that
+// fail to load are logged and skipped, so one broken plugin does not stop
+// the others or the server.
+func LoadPlugins(dir string) []SearchClient {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.so"))
+	if err != nil {
+		log.Error().Err(err).Str("dir", dir).Msg("Failed to list search plugins")
+		return nil
+	}
+
+	var clients []SearchClient
+	for _, path := range paths {
+		client, err := LoadPlugin(path)
+		if err != nil {
+			log.Error().Err(err).Str("path", path).Msg("Failed to load search plugin")
+			continue
+		}
+		log.Info().Str("path", path).Str("source", client.Name()).Msg("Search plugin loaded")
+		clients = append(clients, client)
+	}
+	return clients
+}
+
+// LoadPlugin opens a plugin built with -buildmode=plugin, checks that it
+// exports PluginSymbol with the expected type and returns its client
+// wrapped in a PluginSearchClientAdapter.
+func LoadPlugin(path string) (client SearchClient, err error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	sym, err := p.Lookup(PluginSymbol)
+	if err != nil {
+		return nil, err
+	}
+	newClient, ok := sym.(func() SearchClient)
+	if !ok {
+		return nil, fmt.Errorf("%s has type %T, want func() search.SearchClient", PluginSymbol, sym)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			client, err = nil, fmt.Errorf("%s panicked: %v", PluginSymbol, r)
+		}
+	}()
+	c := newClient()
+	if c == nil {
+		return nil, fmt.Errorf("%s returned nil", PluginSymbol)
+	}
+	return &PluginSearchClientAdapter{client: c, path: path}, nil
+}
+
+// PluginSearchClientAdapter isolates the server from plugin bugs: panics in
+// the plugin's client become errors instead of crashing the process.
+type PluginSearchClientAdapter struct {
+	client SearchClient
+	path   string
+}
+
+// Search calls the plugin's Search, converting a panic into an error.
+// Evidence returned without an ID is given one.
+func (a *PluginSearchClientAdapter) Search(ctx context.Context, query string, maxResults int, opts SearchOptions) (evidences []models.Evidence, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			evidences, err = nil, fmt.Errorf("search plugin %s panicked: %v", a.path, r)
+		}
+	}()
+	evidences, err = a.client.Search(ctx, query, maxResults, opts)
+	for i := range evidences {
+		if evidences[i].ID == "" {
+			evidences[i].ID = uuid.New().String()
+		}
+	}
+	return evidences, err
+}
+
+// Name returns the plugin's source name, or its file name if Name panics.
+func (a *PluginSearchClientAdapter) Name() (name string) {
+	defer func() {
+		if r := recover(); r != nil {
+			name = strings.TrimSuffix(filepath.Base(a.path), ".so")
+		}
+	}()
+	return a.client.Name()
+}
+
+// Available returns whether the plugin's client is configured; a panic
+// counts as unavailable.
+func (a *PluginSearchClientAdapter) Available() (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().Str("path", a.path).Interface("panic", r).Msg("Search plugin panicked")
+			ok = false
+		}
+	}()
+	return a.client.Available()
+}
@@ -4,26 +4,87 @@ package search
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/factchecker/verity/internal/models"
 	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// NCBI E-utilities rate limits, per https://www.ncbi.nlm.nih.gov/books/NBK25497/
+const (
+	pubmedRateAnonymous = 3
+	pubmedRateWithKey   = 10
 )
 
 // PubMedClient searches using NCBI PubMed API.
 type PubMedClient struct {
 	httpClient *http.Client
+	apiKey     string
+	tokens     chan struct{}
+	journals   *JournalDeduplicator
+
+	stop      chan struct{} // closed by Close to stop refilling tokens
+	closeOnce sync.Once
 }
 
-// NewPubMedClient creates a new PubMed client.
-func NewPubMedClient() *PubMedClient {
-	return &PubMedClient{
-		httpClient: &http.Client{Timeout: 10 * time.Second},
+// NewPubMedClient creates a new PubMed client using httpClient. The API key
+// is optional. maxPerJournal limits how many articles from one journal are
+// returned; zero disables the limit.
+func NewPubMedClient(httpClient *http.Client, apiKey string, maxPerJournal int) *PubMedClient {
+	rate := pubmedRateAnonymous
+	if apiKey != "" {
+		rate = pubmedRateWithKey
+	}
+
+	c := &PubMedClient{
+		httpClient: httpClient,
+		apiKey:     apiKey,
+		tokens:     make(chan struct{}, rate),
+		journals:   NewJournalDeduplicator(maxPerJournal),
+		stop:       make(chan struct{}),
+	}
+
+	// Token bucket: start full and refill one token per 1/rate seconds.
+	for i := 0; i < rate; i++ {
+		c.tokens <- struct{}{}
 	}
+	go func() {
+		ticker := time.NewTicker(time.Second / time.Duration(rate))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-c.stop:
+				return
+			}
+			select {
+			case c.tokens <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	log.Info().
+		Bool("api_key", apiKey != "").
+		Int("requests_per_second", rate).
+		Msg("PubMed rate limit configured")
+
+	return c
+}
+
+// Close stops the rate limiter's refill goroutine. Searches after Close
+// block until their context is done once the remaining tokens are spent.
+func (c *PubMedClient) Close() error {
+	c.closeOnce.Do(func() { close(c.stop) })
+	return nil
 }
 
 // Name returns the source name.
@@ -50,27 +111,95 @@ type pubmedSummaryResponse struct {
 	} `json:"result"`
 }
 
-// Search searches PubMed for academic evidence.
-func (c *PubMedClient) Search(ctx context.Context, query string, maxResults int) ([]models.Evidence, error) {
-	// Search for article IDs
-	searchURL := fmt.Sprintf(
-		"https://eutils.ncbi.nlm.nih.gov/entrez/eutils/esearch.fcgi?db=pubmed&term=%s&retmax=%d&retmode=json",
-		url.QueryEscape(query), maxResults)
+// pubmedArticle is an article summary returned by esummary.
+type pubmedArticle struct {
+	PMID    string
+	Title   string
+	PubDate string
+	Source  string // Journal name
+}
+
+type pubmedFetchResponse struct {
+	Articles []struct {
+		PMID         string `xml:"MedlineCitation>PMID"`
+		AbstractText []struct {
+			Label string `xml:"Label,attr"`
+			Text  string `xml:",chardata"`
+		} `xml:"MedlineCitation>Article>Abstract>AbstractText"`
+	} `xml:"PubmedArticle"`
+}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", searchURL, nil)
+// wait blocks until the rate limiter grants a request slot.
+func (c *PubMedClient) wait(ctx context.Context) error {
+	select {
+	case <-c.tokens:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// eutilsURL builds an E-utilities URL, appending the API key when configured.
+func (c *PubMedClient) eutilsURL(tool string, params url.Values) string {
+	if c.apiKey != "" {
+		params.Set("api_key", c.apiKey)
+	}
+	return fmt.Sprintf("https://eutils.ncbi.nlm.nih.gov/entrez/eutils/%s.fcgi?%s", tool, params.Encode())
+}
+
+// get performs a rate-limited GET request against E-utilities.
+func (c *PubMedClient) get(ctx context.Context, u string) (*http.Response, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("PubMed search failed: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
-
 	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
 		return nil, fmt.Errorf("PubMed returned status %d", resp.StatusCode)
 	}
+	return resp, nil
+}
+
+// Search searches PubMed for academic evidence.
+func (c *PubMedClient) Search(ctx context.Context, query string, maxResults int, opts SearchOptions) ([]models.Evidence, error) {
+	return c.search(ctx, query, maxResults, true)
+}
+
+// FindPaper searches PubMed for a paper by the first author's surname and
+// publication year. Keywords, when given, must match at least one term.
+func (c *PubMedClient) FindPaper(ctx context.Context, author string, year int, keywords []string, maxResults int) ([]models.Evidence, error) {
+	term := fmt.Sprintf("%s[au] AND %d[dp]", author, year)
+	if len(keywords) > 0 {
+		term += " AND (" + strings.Join(keywords, " OR ") + ")"
+	}
+	return c.search(ctx, term, maxResults, false)
+}
+
+// search runs an E-utilities search term and builds evidence from the
+// matching articles. limitJournals applies the per-journal article limit.
+func (c *PubMedClient) search(ctx context.Context, term string, maxResults int, limitJournals bool) ([]models.Evidence, error) {
+	// Search for article IDs
+	searchURL := c.eutilsURL("esearch", url.Values{
+		"db":      {"pubmed"},
+		"term":    {term},
+		"retmax":  {fmt.Sprintf("%d", maxResults)},
+		"retmode": {"json"},
+	})
+
+	resp, err := c.get(ctx, searchURL)
+	if err != nil {
+		return nil, fmt.Errorf("PubMed search failed: %w", err)
+	}
+	defer resp.Body.Close()
 
 	var searchData pubmedSearchResponse
 	if err := json.NewDecoder(resp.Body).Decode(&searchData); err != nil {
@@ -83,16 +212,13 @@ func (c *PubMedClient) Search(ctx context.Context, query string, maxResults int)
 
 	// Get summaries for found articles
 	ids := strings.Join(searchData.ESearchResult.IDList, ",")
-	summaryURL := fmt.Sprintf(
-		"https://eutils.ncbi.nlm.nih.gov/entrez/eutils/esummary.fcgi?db=pubmed&id=%s&retmode=json",
-		ids)
-
-	req, err = http.NewRequestWithContext(ctx, "GET", summaryURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create summary request: %w", err)
-	}
+	summaryURL := c.eutilsURL("esummary", url.Values{
+		"db":      {"pubmed"},
+		"id":      {ids},
+		"retmode": {"json"},
+	})
 
-	resp, err = c.httpClient.Do(req)
+	resp, err = c.get(ctx, summaryURL)
 	if err != nil {
 		return nil, fmt.Errorf("PubMed summary failed: %w", err)
 	}
@@ -103,29 +229,190 @@ func (c *PubMedClient) Search(ctx context.Context, query string, maxResults int)
 		return nil, fmt.Errorf("failed to decode summary response: %w", err)
 	}
 
-	now := time.Now()
-	var evidences []models.Evidence
-
+	var articles []pubmedArticle
 	for _, pmid := range searchData.ESearchResult.IDList {
 		article, ok := summaryData.Result[pmid]
 		if !ok || article.Title == "" {
 			continue
 		}
+		articles = append(articles, pubmedArticle{
+			PMID:    pmid,
+			Title:   article.Title,
+			PubDate: article.PubDate,
+			Source:  article.Source,
+		})
+	}
+
+	// Only thin out journals when they could crowd out the rest of the results
+	if limitJournals && len(articles) > maxResults/2 {
+		articles = c.journals.Dedupe(articles)
+	}
+	if len(articles) == 0 {
+		return nil, nil
+	}
+
+	kept := make([]string, len(articles))
+	for i, article := range articles {
+		kept[i] = article.PMID
+	}
+
+	// Abstracts make much better evidence than titles alone, but are optional
+	abstracts, err := c.fetchAbstracts(ctx, strings.Join(kept, ","))
+	if err != nil {
+		log.Debug().Err(err).Msg("PubMed: Failed to fetch abstracts, using titles only")
+	}
+
+	now := time.Now()
+	var evidences []models.Evidence
+
+	for _, article := range articles {
+		var publishedAt *time.Time
+		if t := parsePubDate(article.PubDate); !t.IsZero() {
+			publishedAt = &t
+		}
 
 		snippet := article.Title
 		if article.Source != "" {
 			snippet += fmt.Sprintf(" (Published in %s, %s)", article.Source, article.PubDate)
 		}
+		if abstract := abstracts[article.PMID]; abstract != "" {
+			snippet += " " + truncate(abstract, maxAbstractLength)
+		}
 
 		evidences = append(evidences, models.Evidence{
 			ID:          uuid.New().String(),
 			SourceName:  "PubMed",
-			SourceURL:   fmt.Sprintf("https://pubmed.ncbi.nlm.nih.gov/%s/", pmid),
+			SourceURL:   fmt.Sprintf("https://pubmed.ncbi.nlm.nih.gov/%s/", article.PMID),
 			SourceType:  "academic",
 			Snippet:     snippet,
 			RetrievedAt: now,
+			PublishedAt: publishedAt,
 		})
 	}
 
 	return evidences, nil
 }
+
+// fetchAbstracts retrieves article abstracts keyed by PMID.
+func (c *PubMedClient) fetchAbstracts(ctx context.Context, ids string) (map[string]string, error) {
+	fetchURL := c.eutilsURL("efetch", url.Values{
+		"db":      {"pubmed"},
+		"id":      {ids},
+		"rettype": {"abstract"},
+		"retmode": {"xml"},
+	})
+
+	resp, err := c.get(ctx, fetchURL)
+	if err != nil {
+		return nil, fmt.Errorf("PubMed fetch failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var fetchData pubmedFetchResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&fetchData); err != nil {
+		return nil, fmt.Errorf("failed to decode fetch response: %w", err)
+	}
+
+	abstracts := make(map[string]string, len(fetchData.Articles))
+	for _, article := range fetchData.Articles {
+		var parts []string
+		for _, section := range article.AbstractText {
+			text := strings.TrimSpace(section.Text)
+			if text == "" {
+				continue
+			}
+			if section.Label != "" {
+				text = section.Label + ": " + text
+			}
+			parts = append(parts, text)
+		}
+		abstracts[strings.TrimSpace(article.PMID)] = strings.Join(parts, " ")
+	}
+	return abstracts, nil
+}
+
+// JournalDeduplicator limits how many articles from the same journal are
+// kept, so a single journal issue reporting one result many times does not
+// dominate the evidence pool.
+type JournalDeduplicator struct {
+	maxPerJournal int
+}
+
+// NewJournalDeduplicator creates a deduplicator keeping at most
+// maxPerJournal articles per journal. Zero or less disables it.
+func NewJournalDeduplicator(maxPerJournal int) *JournalDeduplicator {
+	return &JournalDeduplicator{maxPerJournal: maxPerJournal}
+}
+
+// Dedupe keeps the most recently published articles of each journal and
+// drops the rest. Surviving articles keep their original (relevance) order.
+// Articles without a journal name are always kept.
+func (d *JournalDeduplicator) Dedupe(articles []pubmedArticle) []pubmedArticle {
+	if d == nil || d.maxPerJournal <= 0 {
+		return articles
+	}
+
+	byJournal := make(map[string][]int)
+	for i, a := range articles {
+		if journal := strings.ToLower(strings.TrimSpace(a.Source)); journal != "" {
+			byJournal[journal] = append(byJournal[journal], i)
+		}
+	}
+
+	drop := make(map[int]bool)
+	for journal, idx := range byJournal {
+		if len(idx) <= d.maxPerJournal {
+			continue
+		}
+		sort.SliceStable(idx, func(i, j int) bool {
+			return parsePubDate(articles[idx[i]].PubDate).After(parsePubDate(articles[idx[j]].PubDate))
+		})
+		for _, i := range idx[d.maxPerJournal:] {
+			drop[i] = true
+		}
+		log.Debug().
+			Str("journal", journal).
+			Int("articles", len(idx)).
+			Int("kept", d.maxPerJournal).
+			Msg("PubMed: Limiting articles from the same journal")
+	}
+
+	kept := articles[:0:0]
+	for i, a := range articles {
+		if !drop[i] {
+			kept = append(kept, a)
+		}
+	}
+	return kept
+}
+
+// parsePubDate parses esummary publication dates such as "2021 Mar 15",
+// "2021 Mar-Apr", "2020 Winter" or "2019". Missing parts default to the
+// start of the period; unparseable dates return the zero time.
+func parsePubDate(pubDate string) time.Time {
+	fields := strings.Fields(pubDate)
+	if len(fields) == 0 {
+		return time.Time{}
+	}
+
+	year, err := time.Parse("2006", fields[0])
+	if err != nil {
+		return time.Time{}
+	}
+	if len(fields) < 2 || len(fields[1]) < 3 {
+		return year
+	}
+	month, err := time.Parse("Jan", fields[1][:3])
+	if err != nil {
+		return year
+	}
+	date := year.AddDate(0, int(month.Month())-1, 0)
+	if len(fields) < 3 {
+		return date
+	}
+	day, err := time.Parse("2", strings.SplitN(fields[2], "-", 2)[0])
+	if err != nil {
+		return date
+	}
+	return date.AddDate(0, 0, day.Day()-1)
+}
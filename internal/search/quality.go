@@ -0,0 +1,103 @@
+// Package search provides quality filtering of extracted evidence snippets.
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxNonAlphaRatio is the largest share of characters other than letters,
+// digits and spaces a snippet may have before it is treated as markup or
+// navigation debris.
+const maxNonAlphaRatio = 0.4
+
+// unspacedScripts are written without spaces between words, so their
+// characters are counted as words.
+var unspacedScripts = []*unicode.RangeTable{
+	unicode.Han, unicode.Hiragana, unicode.Katakana,
+	unicode.Thai, unicode.Lao, unicode.Khmer, unicode.Myanmar,
+}
+
+// lowQualityMarkers are phrases that indicate a snippet is a cookie notice,
+// script warning, error page or bot check rather than page content. They
+// are matched case-insensitively.
+var lowQualityMarkers = []string{
+	"accept cookies",
+	"accept all cookies",
+	"cookie settings",
+	"enable javascript",
+	"javascript is disabled",
+	"javascript is required",
+	"please enable cookies",
+	"your browser is not supported",
+	"404 not found",
+	"page not found",
+	"403 forbidden",
+	"access denied",
+	"checking your browser",
+	"are you a robot",
+	"verify you are human",
+}
+
+// SnippetQualityFilter rejects extracted page text that is unlikely to be
+// useful evidence.
+type SnippetQualityFilter struct {
+	minWords int
+}
+
+// NewSnippetQualityFilter creates a filter requiring at least minWords
+// words per snippet. Zero or less disables the word count check.
+func NewSnippetQualityFilter(minWords int) *SnippetQualityFilter {
+	return &SnippetQualityFilter{minWords: minWords}
+}
+
+// IsHighQuality reports whether text looks like readable prose: it has
+// enough words, is mostly letters and digits, and contains no low-quality
+// markers.
+func (f *SnippetQualityFilter) IsHighQuality(text string) bool {
+	if countWords(text) < f.minWords {
+		return false
+	}
+
+	var letters, other int
+	for _, r := range text {
+		switch {
+		// Combining marks carry the vowels of scripts such as Thai and Hindi
+		case unicode.IsLetter(r), unicode.IsMark(r), unicode.IsNumber(r):
+			letters++
+		case !unicode.IsSpace(r):
+			other++
+		}
+	}
+	if letters+other == 0 || float64(other)/float64(letters+other) > maxNonAlphaRatio {
+		return false
+	}
+
+	lower := strings.ToLower(text)
+	for _, marker := range lowQualityMarkers {
+		if strings.Contains(lower, marker) {
+			return false
+		}
+	}
+	return true
+}
+
+// countWords counts space-separated words, and each character of scripts
+// written without spaces, such as Chinese, Japanese and Thai.
+func countWords(text string) int {
+	n := 0
+	for _, field := range strings.Fields(text) {
+		spaced := false
+		for _, r := range field {
+			if unicode.In(r, unspacedScripts...) {
+				n++
+			} else {
+				spaced = true
+			}
+		}
+		if spaced {
+			n++
+		}
+	}
+	return n
+}
@@ -3,15 +3,36 @@ package search
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
+	"github.com/factchecker/verity/internal/metrics"
 	"github.com/factchecker/verity/internal/models"
+	"github.com/rs/zerolog/log"
 )
 
+// SearchOptions holds per-search parameters.
+type SearchOptions struct {
+	// Languages overrides the configured evidence language filter.
+	Languages []string
+
+	// ClaimLanguage is the ISO 639-1 language of the claim being checked.
+	// Sources that support it search in that language first.
+	ClaimLanguage string
+
+	// Jurisdiction, e.g. "US" or "EU", is added to keyword searches so that
+	// results concern the place where the claim applies.
+	Jurisdiction string
+}
+
 // SearchClient defines the interface for search providers.
 type SearchClient interface {
 	// Search searches for evidence related to the query.
-	Search(ctx context.Context, query string, maxResults int) ([]models.Evidence, error)
+	Search(ctx context.Context, query string, maxResults int, opts SearchOptions) ([]models.Evidence, error)
 
 	// Name returns the source name.
 	Name() string
@@ -20,9 +41,25 @@ type SearchClient interface {
 	Available() bool
 }
 
+// PaperFinder looks up a specific academic paper from citation details.
+type PaperFinder interface {
+	// FindPaper searches for papers by the first author's surname and the
+	// publication year, optionally narrowed by keywords.
+	FindPaper(ctx context.Context, author string, year int, keywords []string, maxResults int) ([]models.Evidence, error)
+
+	// Name returns the source name.
+	Name() string
+}
+
 // AggregatedSearchClient searches across multiple sources.
 type AggregatedSearchClient struct {
-	clients []SearchClient
+	clients        []SearchClient
+	languageFilter []string
+	nearDuplicates *MinHashDeduplicator
+
+	// budget bounds concurrent source searches across all callers; nil is unbounded.
+	budget  chan struct{}
+	waiting atomic.Int64
 }
 
 // NewAggregatedSearchClient creates a new aggregated search client.
@@ -34,7 +71,55 @@ func NewAggregatedSearchClient(clients ...SearchClient) *AggregatedSearchClient
 			available = append(available, c)
 		}
 	}
-	return &AggregatedSearchClient{clients: available}
+	return &AggregatedSearchClient{
+		clients:        available,
+		nearDuplicates: NewMinHashDeduplicator(defaultNearDuplicateThreshold),
+	}
+}
+
+// SetLanguageFilter restricts evidence to snippets in the given languages.
+// An empty list accepts all languages.
+func (a *AggregatedSearchClient) SetLanguageFilter(languages []string) {
+	a.languageFilter = languages
+}
+
+// SetConcurrencyBudget limits how many source searches may run at once
+// across all concurrent calls to Search. Zero or less removes the limit.
+func (a *AggregatedSearchClient) SetConcurrencyBudget(n int) {
+	if n <= 0 {
+		a.budget = nil
+		return
+	}
+	a.budget = make(chan struct{}, n)
+}
+
+// acquire takes a token from the concurrency budget, waiting if the budget
+// is exhausted. It returns a function that releases the token.
+func (a *AggregatedSearchClient) acquire(ctx context.Context, source string) (func(), error) {
+	if a.budget == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case a.budget <- struct{}{}:
+		return func() { <-a.budget }, nil
+	default:
+	}
+
+	depth := a.waiting.Add(1)
+	defer a.waiting.Add(-1)
+	log.Warn().
+		Str("source", source).
+		Int("budget", cap(a.budget)).
+		Int64("queue_depth", depth).
+		Msg("Search concurrency budget exhausted, waiting")
+
+	select {
+	case a.budget <- struct{}{}:
+		return func() { <-a.budget }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
 
 // SearchResult contains results from a single source.
@@ -44,8 +129,9 @@ type SearchResult struct {
 	Error     error
 }
 
-// Search searches all configured sources concurrently.
-func (a *AggregatedSearchClient) Search(ctx context.Context, query string, maxResultsPerSource int) ([]models.Evidence, []models.Warning) {
+// Search searches all configured sources concurrently. If opts.Languages is
+// non-empty it overrides the configured evidence language filter.
+func (a *AggregatedSearchClient) Search(ctx context.Context, query string, maxResultsPerSource int, opts SearchOptions) ([]models.Evidence, []models.Warning) {
 	if len(a.clients) == 0 {
 		return nil, []models.Warning{{Source: "search", Message: "No search sources configured"}}
 	}
@@ -55,7 +141,17 @@ func (a *AggregatedSearchClient) Search(ctx context.Context, query string, maxRe
 	// Search all sources concurrently
 	for _, client := range a.clients {
 		go func(c SearchClient) {
-			evidences, err := c.Search(ctx, query, maxResultsPerSource)
+			release, err := a.acquire(ctx, c.Name())
+			if err != nil {
+				results <- SearchResult{Source: c.Name(), Error: err}
+				return
+			}
+			metrics.SearchCalls.WithLabelValues(c.Name()).Inc()
+			evidences, err := c.Search(ctx, query, maxResultsPerSource, opts)
+			release()
+			for i := range evidences {
+				evidences[i].Fetcher = c.Name()
+			}
 			results <- SearchResult{
 				Source:    c.Name(),
 				Evidences: evidences,
@@ -95,10 +191,73 @@ func (a *AggregatedSearchClient) Search(ctx context.Context, query string, maxRe
 		}
 	}
 
+	allEvidences = dedupeByCanonicalURL(allEvidences)
+	allEvidences = a.nearDuplicates.Dedupe(allEvidences)
+
+	languages := opts.Languages
+	if len(languages) == 0 {
+		languages = a.languageFilter
+	}
+	allEvidences, filterWarnings := filterByLanguage(allEvidences, languages)
+	warnings = append(warnings, filterWarnings...)
+
 	return allEvidences, warnings
 }
 
+// filterByLanguage drops evidence whose snippet is detected to be in a
+// language outside the allowed list. Snippets whose language cannot be
+// determined are kept.
+func filterByLanguage(evidences []models.Evidence, languages []string) ([]models.Evidence, []models.Warning) {
+	allowed := normalizeLanguages(languages)
+	if len(allowed) == 0 {
+		return evidences, nil
+	}
+
+	var kept []models.Evidence
+	var warnings []models.Warning
+	for _, e := range evidences {
+		lang := DetectLanguage(e.Snippet)
+		if lang == "" || allowed[lang] {
+			kept = append(kept, e)
+			continue
+		}
+		warnings = append(warnings, models.Warning{
+			Source:  e.SourceName,
+			Message: fmt.Sprintf("Filtered evidence in language %q: %s", lang, e.SourceURL),
+		})
+	}
+	return kept, warnings
+}
+
 // HasClients returns whether any search clients are available.
 func (a *AggregatedSearchClient) HasClients() bool {
 	return len(a.clients) > 0
 }
+
+// Close releases the resources of every source that holds any, such as
+// background rate limiters.
+func (a *AggregatedSearchClient) Close() error {
+	var errs []error
+	for _, c := range a.clients {
+		if closer, ok := c.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// maxAbstractLength is the length in bytes abstracts are truncated to in
+// academic evidence snippets.
+const maxAbstractLength = 1000
+
+// truncate shortens s to at most n bytes without splitting a UTF-8
+// sequence, marking the cut with "...".
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
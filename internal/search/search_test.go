@@ -0,0 +1,53 @@
+package search
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"exactly", 7, "exactly"},
+		{"abcdef", 3, "abc..."},
+		{"naïve", 3, "na..."}, // "ï" spans bytes 2-3
+		{"日本語", 4, "日..."},
+	}
+	for _, tt := range tests {
+		got := truncate(tt.s, tt.n)
+		if got != tt.want || !utf8.ValidString(got) {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+
+	long := strings.Repeat("ç", maxAbstractLength)
+	if got := truncate(long, maxAbstractLength); !utf8.ValidString(got) || len(got) > maxAbstractLength+len("...") {
+		t.Errorf("truncate() of a %d-byte abstract = %d bytes, valid UTF-8 %v", len(long), len(got), utf8.ValidString(got))
+	}
+}
+
+func TestPubMedClientClose(t *testing.T) {
+	c := NewPubMedClient(http.DefaultClient, "", 0)
+	for len(c.tokens) > 0 {
+		<-c.tokens
+	}
+	a := NewAggregatedSearchClient(NewCachedSearchClient(c, time.Minute))
+	if err := a.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("second Close() error = %v", err)
+	}
+
+	time.Sleep(2 * time.Second / pubmedRateAnonymous)
+	if n := len(c.tokens); n > 1 {
+		t.Errorf("rate limiter refilled %d tokens after Close", n)
+	}
+}
@@ -0,0 +1,118 @@
+// Package search provides SearXNG meta-search implementation.
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// SearXNGClient searches the web through a self-hosted SearXNG instance,
+// for deployments that must not send claims to external search APIs. The
+// instance must have the JSON output format enabled.
+type SearXNGClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewSearXNGClient creates a new SearXNG client for the instance at baseURL.
+func NewSearXNGClient(httpClient *http.Client, baseURL string) *SearXNGClient {
+	return &SearXNGClient{
+		httpClient: httpClient,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Name returns the source name.
+func (c *SearXNGClient) Name() string {
+	return "SearXNG"
+}
+
+// Available returns true if an instance URL is set.
+func (c *SearXNGClient) Available() bool {
+	return c.baseURL != ""
+}
+
+type searXNGResponse struct {
+	Results []struct {
+		URL     string `json:"url"`
+		Title   string `json:"title"`
+		Content string `json:"content"`
+	} `json:"results"`
+}
+
+// Search searches the general category of the SearXNG instance, in the
+// claim's language when known.
+func (c *SearXNGClient) Search(ctx context.Context, query string, maxResults int, opts SearchOptions) ([]models.Evidence, error) {
+	keywords := extractKeywords(query)
+	if keywords == "" {
+		return nil, nil
+	}
+	log.Debug().Str("original", query).Str("keywords", keywords).Msg("SearXNG: Searching")
+
+	params := url.Values{}
+	params.Set("q", keywords)
+	params.Set("format", "json")
+	params.Set("categories", "general")
+	if language := NormalizeLanguage(opts.ClaimLanguage); language != "" {
+		params.Set("language", language)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Verity/1.0 (Fact-checking tool)")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("SearXNG search failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// SearXNG answers 403 when the JSON format is not enabled in its settings
+		return nil, fmt.Errorf("SearXNG returned status %d", resp.StatusCode)
+	}
+
+	var data searXNGResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	now := time.Now()
+	var evidences []models.Evidence
+	for _, r := range data.Results {
+		if len(evidences) >= maxResults {
+			break
+		}
+		if r.URL == "" || r.Title == "" {
+			continue
+		}
+		snippet := r.Title
+		if content := strings.Join(strings.Fields(r.Content), " "); content != "" {
+			snippet = strings.TrimSuffix(snippet, ".") + ". " + content
+		}
+
+		evidences = append(evidences, models.Evidence{
+			ID:          uuid.New().String(),
+			SourceName:  extractDomain(r.URL),
+			SourceURL:   r.URL,
+			SourceType:  "web_page",
+			Snippet:     snippet,
+			RetrievedAt: now,
+		})
+	}
+
+	log.Debug().Int("count", len(evidences)).Msg("SearXNG: Search completed")
+	return evidences, nil
+}
@@ -0,0 +1,173 @@
+// Package search provides Semantic Scholar academic search implementation.
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// semanticScholarAPIURL is the Semantic Scholar paper search endpoint.
+const semanticScholarAPIURL = "https://api.semanticscholar.org/graph/v1/paper/search"
+
+// semanticScholarRequestInterval keeps requests within the public API limit
+// of 100 requests per 5 minutes.
+const semanticScholarRequestInterval = 5 * time.Minute / 100
+
+// SemanticScholarClient searches academic papers using the Semantic Scholar
+// API, which indexes more venues outside the US and outside medicine than
+// PubMed.
+type SemanticScholarClient struct {
+	httpClient *http.Client
+	tokens     chan struct{} // one token per allowed request
+}
+
+// NewSemanticScholarClient creates a new Semantic Scholar client. Its rate
+// limiter runs for the life of the process.
+func NewSemanticScholarClient(httpClient *http.Client) *SemanticScholarClient {
+	c := &SemanticScholarClient{
+		httpClient: httpClient,
+		tokens:     make(chan struct{}, 1),
+	}
+	c.tokens <- struct{}{}
+	go c.refill(time.NewTicker(semanticScholarRequestInterval))
+	return c
+}
+
+// refill adds a request token on every tick, unless one is still unused.
+func (c *SemanticScholarClient) refill(ticker *time.Ticker) {
+	for range ticker.C {
+		select {
+		case c.tokens <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// wait blocks until a request may be sent or ctx is done.
+func (c *SemanticScholarClient) wait(ctx context.Context) error {
+	select {
+	case <-c.tokens:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Name returns the source name.
+func (c *SemanticScholarClient) Name() string {
+	return "Semantic Scholar"
+}
+
+// Available returns true as the public API requires no key.
+func (c *SemanticScholarClient) Available() bool {
+	return true
+}
+
+type semanticScholarResponse struct {
+	Data []struct {
+		PaperID     string `json:"paperId"`
+		Title       string `json:"title"`
+		Abstract    string `json:"abstract"`
+		Year        int    `json:"year"`
+		Venue       string `json:"venue"`
+		ExternalIDs struct {
+			DOI string `json:"DOI"`
+		} `json:"externalIds"`
+	} `json:"data"`
+}
+
+// Search searches Semantic Scholar for papers matching the query. Papers
+// without an abstract are skipped, as their title alone is rarely evidence.
+func (c *SemanticScholarClient) Search(ctx context.Context, query string, maxResults int, opts SearchOptions) ([]models.Evidence, error) {
+	searchQuery := extractKeywords(query)
+	if searchQuery == "" {
+		return nil, nil
+	}
+	log.Debug().Str("original", query).Str("search_query", searchQuery).Msg("Semantic Scholar: Searching")
+
+	params := url.Values{}
+	params.Set("query", searchQuery)
+	params.Set("fields", "title,abstract,year,venue,externalIds")
+	params.Set("limit", fmt.Sprintf("%d", maxResults))
+
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", semanticScholarAPIURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Verity/1.0 (Fact-checking tool)")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Semantic Scholar search failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Semantic Scholar returned status %d", resp.StatusCode)
+	}
+
+	var result semanticScholarResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	now := time.Now()
+	var evidences []models.Evidence
+
+	for _, paper := range result.Data {
+		abstract := strings.Join(strings.Fields(paper.Abstract), " ")
+		if abstract == "" || paper.Title == "" {
+			continue
+		}
+		abstract = truncate(abstract, maxAbstractLength)
+
+		snippet := paper.Title
+		switch {
+		case paper.Venue != "" && paper.Year > 0:
+			snippet += fmt.Sprintf(" (Published in %s, %d)", paper.Venue, paper.Year)
+		case paper.Year > 0:
+			snippet += fmt.Sprintf(" (%d)", paper.Year)
+		}
+		snippet += " " + abstract
+
+		// Only the year is reported, so date the paper from its start
+		var publishedAt *time.Time
+		if paper.Year > 0 {
+			t := time.Date(paper.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+			publishedAt = &t
+		}
+
+		// Link the DOI when there is one, as the publisher's page is the
+		// authoritative copy
+		link := "https://www.semanticscholar.org/paper/" + paper.PaperID
+		if doi := paper.ExternalIDs.DOI; doi != "" {
+			link = "https://doi.org/" + doi
+		}
+
+		evidences = append(evidences, models.Evidence{
+			ID:          uuid.New().String(),
+			SourceName:  "Semantic Scholar",
+			SourceURL:   link,
+			SourceType:  "academic",
+			Snippet:     snippet,
+			RetrievedAt: now,
+			PublishedAt: publishedAt,
+		})
+	}
+
+	log.Debug().Int("count", len(evidences)).Msg("Semantic Scholar: Search completed")
+	return evidences, nil
+}
@@ -0,0 +1,157 @@
+// Package search provides a search client backed by a fixed, curated URL.
+package search
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/google/uuid"
+)
+
+// staticContentTTL is how long fetched content is reused across claims.
+const staticContentTTL = 10 * time.Minute
+
+// staticSnippetSize is the size of the passage returned as evidence.
+const staticSnippetSize = 1000
+
+// StaticURLSearchClient treats a single whitelisted URL as an evidence
+// source. It ignores external search entirely and returns the passage of the
+// page most relevant to the query.
+type StaticURLSearchClient struct {
+	url        string
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	content     string
+	contentType string
+	fetchedAt   time.Time
+}
+
+// NewStaticURLSearchClient creates a client for a curated URL.
+func NewStaticURLSearchClient(httpClient *http.Client, u string) *StaticURLSearchClient {
+	return &StaticURLSearchClient{
+		url:        u,
+		httpClient: httpClient,
+	}
+}
+
+// Name returns the source name.
+func (c *StaticURLSearchClient) Name() string {
+	return extractDomain(c.url)
+}
+
+// Available returns true when a URL is configured.
+func (c *StaticURLSearchClient) Available() bool {
+	return c.url != ""
+}
+
+// Search returns the most relevant passage of the URL's content.
+func (c *StaticURLSearchClient) Search(ctx context.Context, query string, maxResults int, opts SearchOptions) ([]models.Evidence, error) {
+	content, contentType, err := c.getContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if content == "" {
+		return nil, nil
+	}
+
+	return []models.Evidence{{
+		ID:          uuid.New().String(),
+		SourceName:  c.Name(),
+		SourceURL:   c.url,
+		SourceType:  "curated",
+		Snippet:     bestPassage(content, extractKeywords(query), staticSnippetSize),
+		ContentType: contentType,
+		RetrievedAt: time.Now(),
+	}}, nil
+}
+
+// getContent returns cached page text and its content type, fetching it
+// once per TTL. The lock is not held while fetching, so a slow page never
+// blocks searches served from the cache; concurrent refreshes may both fetch.
+func (c *StaticURLSearchClient) getContent(ctx context.Context) (string, string, error) {
+	c.mu.Lock()
+	if c.content != "" && time.Since(c.fetchedAt) < staticContentTTL {
+		content, contentType := c.content, c.contentType
+		c.mu.Unlock()
+		return content, contentType, nil
+	}
+	c.mu.Unlock()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Verity/1.0 (Fact-checking tool)")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch %s: %w", c.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("%s returned status %d", c.url, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 2*1024*1024))
+	if err != nil {
+		return "", "", err
+	}
+
+	text, contentType, err := extractContent(body, resp.Header.Get("Content-Type"))
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", c.url, err)
+	}
+
+	c.mu.Lock()
+	c.content = text
+	c.contentType = contentType
+	c.fetchedAt = time.Now()
+	c.mu.Unlock()
+	return text, contentType, nil
+}
+
+// bestPassage returns the window of size characters of text with the most
+// keyword hits. Windows are taken over runes and lowercased one by one, as
+// lowercasing can change the byte length of text.
+func bestPassage(text, keywords string, size int) string {
+	runes := []rune(text)
+	if len(runes) <= size {
+		return text
+	}
+
+	terms := strings.Fields(strings.ToLower(keywords))
+
+	bestStart, bestScore := 0, -1
+	step := max(size/4, 1)
+	for start := 0; start < len(runes); start += step {
+		end := min(start+size, len(runes))
+		window := strings.ToLower(string(runes[start:end]))
+		score := 0
+		for _, t := range terms {
+			score += strings.Count(window, t)
+		}
+		if score > bestScore {
+			bestStart, bestScore = start, score
+		}
+		if end == len(runes) {
+			break
+		}
+	}
+
+	passage := string(runes[bestStart:min(bestStart+size, len(runes))])
+	if bestStart > 0 {
+		passage = "..." + passage
+	}
+	if bestStart+size < len(runes) {
+		passage += "..."
+	}
+	return passage
+}
@@ -0,0 +1,60 @@
+// Package search provides per-domain throttling of page fetches.
+package search
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DomainThrottler spaces out requests to the same domain by at least a
+// minimum interval. Each domain holds the earliest time its next request may
+// start; callers reserve a slot with compare-and-swap and sleep outside any
+// lock, so fetches from different domains never wait on each other.
+type DomainThrottler struct {
+	interval time.Duration
+	next     sync.Map // domain -> *atomic.Int64 (unix nanoseconds)
+}
+
+// NewDomainThrottler creates a throttler enforcing interval between requests
+// to the same domain. It returns nil when interval is not positive, which
+// disables throttling.
+func NewDomainThrottler(interval time.Duration) *DomainThrottler {
+	if interval <= 0 {
+		return nil
+	}
+	return &DomainThrottler{interval: interval}
+}
+
+// Wait blocks until a request to domain may start, or ctx is done.
+func (t *DomainThrottler) Wait(ctx context.Context, domain string) error {
+	if t == nil || domain == "" {
+		return nil
+	}
+	v, _ := t.next.LoadOrStore(domain, new(atomic.Int64))
+	next := v.(*atomic.Int64)
+
+	var start int64
+	for {
+		now := time.Now().UnixNano()
+		reserved := next.Load()
+		start = max(now, reserved)
+		if next.CompareAndSwap(reserved, start+int64(t.interval)) {
+			break
+		}
+	}
+
+	delay := time.Until(time.Unix(0, start))
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
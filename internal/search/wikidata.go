@@ -0,0 +1,150 @@
+// Package search provides publisher reliability lookup via Wikidata.
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// wikidataCacheTTL is how long a domain's reliability is cached.
+	wikidataCacheTTL = 24 * time.Hour
+
+	// wikidataFailureTTL is how long a failed lookup is remembered, so that
+	// an unreachable query service is not retried for every evidence.
+	wikidataFailureTTL = 10 * time.Minute
+
+	// UnknownReliability is returned for domains with no Wikidata entity.
+	UnknownReliability = 0.5
+)
+
+// wikidataRankScores maps wikibase:rank values to reliability scores.
+var wikidataRankScores = map[string]float64{
+	"http://wikiba.se/ontology#PreferredRank":  1.0,
+	"http://wikiba.se/ontology#NormalRank":     0.8,
+	"http://wikiba.se/ontology#DeprecatedRank": 0.2,
+}
+
+// WikidataReliabilityLookup rates a domain by the rank of the official
+// website (P856) statement of the Wikidata entity that owns it. Publishers
+// known to Wikidata are rated above unknown sites.
+type WikidataReliabilityLookup struct {
+	httpClient *http.Client
+	endpoint   string
+
+	mu    sync.Mutex
+	cache map[string]wikidataCacheEntry
+}
+
+type wikidataCacheEntry struct {
+	score   float64
+	err     error
+	expires time.Time
+}
+
+// NewWikidataReliabilityLookup creates a lookup against the Wikidata query service.
+func NewWikidataReliabilityLookup(httpClient *http.Client) *WikidataReliabilityLookup {
+	return &WikidataReliabilityLookup{
+		httpClient: httpClient,
+		endpoint:   "https://query.wikidata.org/sparql",
+		cache:      make(map[string]wikidataCacheEntry),
+	}
+}
+
+type wikidataResponse struct {
+	Results struct {
+		Bindings []struct {
+			Rank struct {
+				Value string `json:"value"`
+			} `json:"rank"`
+		} `json:"bindings"`
+	} `json:"results"`
+}
+
+// Reliability returns the reliability score of domain: 1.0 for a preferred
+// official website statement, 0.8 for normal, 0.2 for deprecated and
+// UnknownReliability when no entity lists the domain. Results are cached
+// per domain for 24 hours, and failed lookups for 10 minutes.
+func (l *WikidataReliabilityLookup) Reliability(ctx context.Context, domain string) (float64, error) {
+	domain = strings.TrimPrefix(strings.ToLower(domain), "www.")
+	if domain == "" {
+		return UnknownReliability, nil
+	}
+
+	l.mu.Lock()
+	entry, ok := l.cache[domain]
+	l.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.score, entry.err
+	}
+
+	score, err := l.query(ctx, domain)
+	ttl := wikidataCacheTTL
+	if err != nil {
+		score, ttl = UnknownReliability, wikidataFailureTTL
+	}
+
+	l.mu.Lock()
+	l.cache[domain] = wikidataCacheEntry{score: score, err: err, expires: time.Now().Add(ttl)}
+	l.mu.Unlock()
+	return score, err
+}
+
+// query looks up the official website statements matching domain and
+// returns the best rank found.
+func (l *WikidataReliabilityLookup) query(ctx context.Context, domain string) (float64, error) {
+	// Exact IRI matches use the P856 index; substring filters would scan it
+	var sites []string
+	for _, scheme := range []string{"https://", "http://"} {
+		for _, host := range []string{domain, "www." + domain} {
+			sites = append(sites, "<"+scheme+host+">", "<"+scheme+host+"/>")
+		}
+	}
+	sparql := fmt.Sprintf(`SELECT ?rank WHERE {
+  VALUES ?site { %s }
+  ?item p:P856 ?statement .
+  ?statement ps:P856 ?site ; wikibase:rank ?rank .
+} LIMIT 10`, strings.Join(sites, " "))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", l.endpoint+"?"+url.Values{
+		"query":  {sparql},
+		"format": {"json"},
+	}.Encode(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "Verity/1.0 (fact-checker)")
+	req.Header.Set("Accept", "application/sparql-results+json")
+
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("Wikidata query failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("Wikidata returned status %d", resp.StatusCode)
+	}
+
+	var data wikidataResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return 0, fmt.Errorf("failed to decode Wikidata response: %w", err)
+	}
+
+	best, found := 0.0, false
+	for _, b := range data.Results.Bindings {
+		if s, ok := wikidataRankScores[b.Rank.Value]; ok && (!found || s > best) {
+			best, found = s, true
+		}
+	}
+	if !found {
+		return UnknownReliability, nil
+	}
+	return best, nil
+}
@@ -22,9 +22,9 @@ type WikipediaClient struct {
 }
 
 // NewWikipediaClient creates a new Wikipedia client that searches PT and EN.
-func NewWikipediaClient() *WikipediaClient {
+func NewWikipediaClient(httpClient *http.Client) *WikipediaClient {
 	return &WikipediaClient{
-		httpClient: &http.Client{Timeout: 10 * time.Second},
+		httpClient: httpClient,
 		languages:  []string{"pt", "en"}, // Search Portuguese first, then English
 	}
 }
@@ -57,15 +57,15 @@ type wikiExtractResponse struct {
 	} `json:"query"`
 }
 
-// Search searches Wikipedia for evidence in multiple languages.
-func (c *WikipediaClient) Search(ctx context.Context, query string, maxResults int) ([]models.Evidence, error) {
+// Search searches Wikipedia for evidence in multiple languages, starting
+// with the claim's language when known.
+func (c *WikipediaClient) Search(ctx context.Context, query string, maxResults int, opts SearchOptions) ([]models.Evidence, error) {
 	keywords := extractKeywords(query)
 	log.Debug().Str("original", query).Str("keywords", keywords).Msg("Wikipedia: Searching")
 
 	var allEvidences []models.Evidence
 
-	// Search in each configured language
-	for _, lang := range c.languages {
+	for _, lang := range c.searchLanguages(opts.ClaimLanguage) {
 		if len(allEvidences) >= maxResults {
 			break
 		}
@@ -83,6 +83,22 @@ func (c *WikipediaClient) Search(ctx context.Context, query string, maxResults i
 	return allEvidences, nil
 }
 
+// searchLanguages returns the Wikipedia languages to query in order: the
+// claim's language, if valid, followed by the configured languages.
+func (c *WikipediaClient) searchLanguages(claimLanguage string) []string {
+	claimLanguage = NormalizeLanguage(claimLanguage)
+	if claimLanguage == "" {
+		return c.languages
+	}
+	languages := []string{claimLanguage}
+	for _, lang := range c.languages {
+		if lang != claimLanguage {
+			languages = append(languages, lang)
+		}
+	}
+	return languages
+}
+
 // searchLanguage searches Wikipedia in a specific language.
 func (c *WikipediaClient) searchLanguage(ctx context.Context, lang, keywords string, maxResults int) ([]models.Evidence, error) {
 	baseURL := fmt.Sprintf("https://%s.wikipedia.org/w/api.php", lang)
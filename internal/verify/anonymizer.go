@@ -0,0 +1,148 @@
+// Package verify provides anonymization of verification results for sharing.
+package verify
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/factchecker/verity/internal/models"
+)
+
+// Anonymizer replaces entity names in verification results using a simple
+// capitalization heuristic. It is not a full NER model: sequences of two or
+// more capitalized words are treated as organizations when they contain an
+// organization keyword, as places when they are in the known place list, and
+// as person names otherwise.
+type Anonymizer struct {
+	namePattern *regexp.Regexp
+	places      map[string]bool
+	orgWords    map[string]bool
+	leadWords   map[string]bool
+}
+
+// NewAnonymizer creates a new anonymizer.
+func NewAnonymizer() *Anonymizer {
+	return &Anonymizer{
+		namePattern: regexp.MustCompile(`\p{Lu}[\p{Ll}'’-]+(?:\s+(?:(?:de|da|do|dos|das|of|van|von)\s+)?\p{Lu}[\p{Ll}'’-]+)+`),
+		places: toSet(
+			"New York", "Los Angeles", "San Francisco", "United States", "United Kingdom",
+			"South Africa", "North America", "South America", "Hong Kong", "New Zealand",
+			"Saudi Arabia", "Costa Rica", "Puerto Rico", "Sri Lanka", "European Union",
+			"Rio de Janeiro", "São Paulo", "Belo Horizonte", "Porto Alegre", "Buenos Aires",
+			"Cabo Verde", "Estados Unidos", "Reino Unido", "União Europeia", "Nova Iorque",
+			"Coreia do Sul", "Coreia do Norte", "África do Sul", "Castelo Branco", "Vila Real",
+			"Ponta Delgada", "Viana do Castelo", "Middle East", "Médio Oriente",
+		),
+		orgWords: toSet(
+			"Inc", "Corp", "Corporation", "Company", "Ltd", "Lda", "Group", "Bank", "Banco",
+			"University", "Universidade", "Institute", "Instituto", "Ministry", "Ministério",
+			"Agency", "Agência", "Association", "Associação", "Foundation", "Fundação",
+			"Organization", "Organisation", "Organização", "Council", "Conselho", "Commission",
+			"Comissão", "Department", "Departamento", "Party", "Partido", "Hospital", "Society",
+			"Sociedade", "Committee", "Comité", "Federation", "Federação", "Union", "Holdings",
+		),
+		leadWords: toSet(
+			"The", "A", "An", "In", "On", "At", "For", "By", "This", "That", "These", "Those",
+			"O", "Os", "As", "Em", "No", "Na", "Nos", "Nas", "Para", "Por", "Este", "Esta",
+			"Segundo", "According",
+		),
+	}
+}
+
+func toSet(items ...string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+	return set
+}
+
+// AnonymizeText replaces detected person and organization names in text.
+func (a *Anonymizer) AnonymizeText(text string) string {
+	return a.namePattern.ReplaceAllStringFunc(text, func(match string) string {
+		words := strings.Fields(match)
+
+		// Drop capitalized sentence openers like "The" before classifying.
+		prefix := ""
+		if a.leadWords[words[0]] {
+			prefix = words[0] + " "
+			words = words[1:]
+			if len(words) < 2 {
+				return match
+			}
+		}
+		phrase := strings.Join(words, " ")
+
+		for _, w := range words {
+			if a.orgWords[strings.TrimSuffix(w, ".")] {
+				return prefix + "[ORG]"
+			}
+		}
+		if a.places[phrase] {
+			return match
+		}
+		return prefix + "[PERSON]"
+	})
+}
+
+// Anonymize returns an anonymized copy of a verification response. Claim
+// text, reasoning, evidence snippets and contradiction explanations are
+// anonymized and evidence URLs are reduced to their domain. The original
+// response is not modified.
+func (a *Anonymizer) Anonymize(resp *models.VerificationResponse) *models.VerificationResponse {
+	out := *resp
+	out.Warnings = nil
+	out.Claims = make([]models.Claim, len(resp.Claims))
+	for i, claim := range resp.Claims {
+		out.Claims[i] = a.anonymizeClaim(claim)
+	}
+
+	out.Analysis.TopClaims = make([]models.Claim, len(resp.Analysis.TopClaims))
+	for i, claim := range resp.Analysis.TopClaims {
+		out.Analysis.TopClaims[i] = a.anonymizeClaim(claim)
+	}
+
+	out.Contradictions = make([]models.Contradiction, len(resp.Contradictions))
+	for i, c := range resp.Contradictions {
+		c.Explanation = a.AnonymizeText(c.Explanation)
+		out.Contradictions[i] = c
+	}
+
+	return &out
+}
+
+// anonymizeClaim returns a copy of claim with PII removed from its text
+// fields and evidence.
+func (a *Anonymizer) anonymizeClaim(claim models.Claim) models.Claim {
+	c := claim
+	c.Text = a.AnonymizeText(claim.Text)
+	c.OriginalSentence = a.AnonymizeText(claim.OriginalSentence)
+	c.Reasoning = a.AnonymizeText(claim.Reasoning)
+	c.ChainOfThought = a.AnonymizeText(claim.ChainOfThought)
+	c.TranslatedText = a.AnonymizeText(claim.TranslatedText)
+	if claim.SuggestedSearches != nil {
+		c.SuggestedSearches = make([]string, len(claim.SuggestedSearches))
+		for j, q := range claim.SuggestedSearches {
+			c.SuggestedSearches[j] = a.AnonymizeText(q)
+		}
+	}
+
+	c.Evidences = make([]models.Evidence, len(claim.Evidences))
+	for j, e := range claim.Evidences {
+		e.Snippet = a.AnonymizeText(e.Snippet)
+		e.Usefulness = a.AnonymizeText(e.Usefulness)
+		e.SourceURL = domainOnly(e.SourceURL)
+		c.Evidences[j] = e
+	}
+	return c
+}
+
+// domainOnly strips everything but the host from a URL.
+func domainOnly(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Hostname() == "" {
+		return ""
+	}
+	return parsed.Hostname()
+}
@@ -0,0 +1,66 @@
+// Package verify provides per-analysis LLM token budgets.
+package verify
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// budgetExhaustedReasoning is the reasoning of claims left unverified
+// because the analysis ran out of tokens.
+const budgetExhaustedReasoning = "Token budget exhausted"
+
+// tokenBudget tracks the estimated LLM tokens used by one analysis and
+// cancels its context once they exceed the limit.
+type tokenBudget struct {
+	limit     int64
+	used      atomic.Int64
+	exhausted atomic.Bool
+	cancel    context.CancelFunc
+}
+
+type tokenBudgetKey struct{}
+
+// withTokenBudget returns a context carrying a token budget of limit tokens,
+// cancelled when the budget is exhausted. The caller must call cancel. With
+// a limit of zero or less no budget is attached and budget is nil.
+func withTokenBudget(ctx context.Context, limit int) (_ context.Context, budget *tokenBudget, cancel context.CancelFunc) {
+	if limit <= 0 {
+		return ctx, nil, func() {}
+	}
+	ctx, cancel = context.WithCancel(ctx)
+	budget = &tokenBudget{limit: int64(limit), cancel: cancel}
+	return context.WithValue(ctx, tokenBudgetKey{}, budget), budget, cancel
+}
+
+// chargeTokens adds the estimated tokens of an LLM call, prompts and
+// response, to the budget in ctx, if any. Providers do not report usage, so
+// tokens are estimated from text length.
+func chargeTokens(ctx context.Context, texts ...string) {
+	b, _ := ctx.Value(tokenBudgetKey{}).(*tokenBudget)
+	if b == nil {
+		return
+	}
+	if b.used.Add(int64(estimatePromptTokens(texts...))) > b.limit && b.exhausted.CompareAndSwap(false, true) {
+		b.cancel()
+	}
+}
+
+// tokenBudgetExhausted reports whether the budget in ctx, if any, ran out.
+func tokenBudgetExhausted(ctx context.Context) bool {
+	b, _ := ctx.Value(tokenBudgetKey{}).(*tokenBudget)
+	return b != nil && b.exhausted.Load()
+}
+
+// Exhausted reports whether the budget ran out.
+func (b *tokenBudget) Exhausted() bool {
+	return b != nil && b.exhausted.Load()
+}
+
+// Used returns the estimated tokens consumed so far.
+func (b *tokenBudget) Used() int64 {
+	if b == nil {
+		return 0
+	}
+	return b.used.Load()
+}
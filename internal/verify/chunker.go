@@ -0,0 +1,177 @@
+// Package verify provides text chunking for claim extraction.
+package verify
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Chunking strategies.
+const (
+	ChunkSentence   = "sentence"
+	ChunkParagraph  = "paragraph"
+	ChunkTokenCount = "token_count"
+)
+
+// DefaultMaxChunkChars is used when no chunk size is configured.
+const DefaultMaxChunkChars = 12000
+
+// minChunkChars keeps token-count chunks usable when the system prompt is large.
+const minChunkChars = 1000
+
+var (
+	sentenceBoundary  = regexp.MustCompile(`[.!?]+["')\]]*\s+`)
+	paragraphBoundary = regexp.MustCompile(`\n\s*\n`)
+)
+
+// Chunker splits documents into pieces small enough for a single extraction
+// request.
+type Chunker struct {
+	strategy    string
+	maxChars    int
+	promptChars int
+}
+
+// NewChunker creates a chunker. systemPrompt is the prompt sent alongside
+// each chunk; the token-count strategy subtracts its cost from maxChars.
+func NewChunker(strategy string, maxChars int, systemPrompt string) *Chunker {
+	if maxChars <= 0 {
+		maxChars = DefaultMaxChunkChars
+	}
+	switch strategy {
+	case ChunkParagraph, ChunkTokenCount:
+	default:
+		strategy = ChunkSentence
+	}
+	return &Chunker{
+		strategy:    strategy,
+		maxChars:    maxChars,
+		promptChars: len(systemPrompt),
+	}
+}
+
+// Split divides text into chunks according to the configured strategy.
+func (c *Chunker) Split(text string) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+
+	switch c.strategy {
+	case ChunkParagraph:
+		return c.splitParagraphs(text)
+	case ChunkTokenCount:
+		return c.splitTokens(text)
+	default:
+		return pack(splitSentences(text), c.maxChars, " ")
+	}
+}
+
+// splitParagraphs packs whole paragraphs into chunks. Paragraphs longer than
+// the limit are broken at sentence boundaries.
+func (c *Chunker) splitParagraphs(text string) []string {
+	var units []string
+	for _, p := range paragraphBoundary.Split(text, -1) {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if len(p) > c.maxChars {
+			units = append(units, pack(splitSentences(p), c.maxChars, " ")...)
+			continue
+		}
+		units = append(units, p)
+	}
+	return pack(units, c.maxChars, "\n\n")
+}
+
+// splitTokens cuts text into chunks of an approximate token budget, estimated
+// at four characters per token, after reserving room for the system prompt.
+// Cuts are made at the last whitespace before the limit, or at the last rune
+// boundary for text without spaces such as Chinese or Japanese.
+func (c *Chunker) splitTokens(text string) []string {
+	budgetTokens := estimateTokens(c.maxChars) - estimateTokens(c.promptChars)
+	size := budgetTokens * 4
+	if size < minChunkChars {
+		size = minChunkChars
+	}
+
+	var chunks []string
+	for len(text) > size {
+		cut := strings.LastIndexAny(text[:size], " \n\t")
+		if cut <= 0 {
+			cut = size
+			for cut > 0 && !utf8.RuneStart(text[cut]) {
+				cut--
+			}
+		}
+		chunks = append(chunks, strings.TrimSpace(text[:cut]))
+		text = strings.TrimSpace(text[cut:])
+	}
+	if text != "" {
+		chunks = append(chunks, text)
+	}
+	return chunks
+}
+
+// estimateTokens approximates the token count of n characters of text.
+func estimateTokens(n int) int {
+	return (n + 3) / 4
+}
+
+// contextBudgetRatio is the share of the context window a prompt may use,
+// leaving a margin for the error of estimating tokens from characters.
+const contextBudgetRatio = 0.9
+
+// promptBudgetTokens returns how many prompt tokens fit in a context window
+// that must also hold a completion of up to completionTokens.
+func promptBudgetTokens(contextWindow, completionTokens int) int {
+	return max(0, int(float64(contextWindow-completionTokens)*contextBudgetRatio))
+}
+
+// estimatePromptTokens approximates the token count of a set of prompts.
+func estimatePromptTokens(prompts ...string) int {
+	n := 0
+	for _, p := range prompts {
+		n += len(p)
+	}
+	return estimateTokens(n)
+}
+
+// splitSentences splits text at sentence-ending punctuation.
+func splitSentences(text string) []string {
+	var sentences []string
+	last := 0
+	for _, loc := range sentenceBoundary.FindAllStringIndex(text, -1) {
+		if s := strings.TrimSpace(text[last:loc[1]]); s != "" {
+			sentences = append(sentences, s)
+		}
+		last = loc[1]
+	}
+	if s := strings.TrimSpace(text[last:]); s != "" {
+		sentences = append(sentences, s)
+	}
+	return sentences
+}
+
+// pack greedily joins units into chunks of at most maxChars. A single unit
+// longer than maxChars becomes its own chunk.
+func pack(units []string, maxChars int, sep string) []string {
+	var chunks []string
+	var current strings.Builder
+	for _, u := range units {
+		if current.Len() > 0 && current.Len()+len(sep)+len(u) > maxChars {
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		if current.Len() > 0 {
+			current.WriteString(sep)
+		}
+		current.WriteString(u)
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
@@ -0,0 +1,131 @@
+package verify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/factchecker/verity/internal/config"
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/rs/zerolog"
+)
+
+func TestSplitTokensRuneBoundary(t *testing.T) {
+	text := strings.Repeat("事实核查需要可靠的证据", 300)
+	chunks := NewChunker(ChunkTokenCount, 1000, "").Split(text)
+	if len(chunks) < 2 {
+		t.Fatalf("Split() returned %d chunks, want several", len(chunks))
+	}
+	for i, chunk := range chunks {
+		if !utf8.ValidString(chunk) {
+			t.Errorf("chunk %d is not valid UTF-8", i)
+		}
+	}
+	if got := strings.Join(chunks, ""); got != text {
+		t.Errorf("chunks do not rebuild the text: got %d bytes, want %d", len(got), len(text))
+	}
+}
+
+// sentenceProvider extracts every sentence of the text it is given as a
+// claim, flagging those starting with "I think" as opinions. It stands in
+// for a model so that chunking strategies can be compared on their own.
+type sentenceProvider struct {
+	*llm.MockProvider
+}
+
+func (p sentenceProvider) CompleteWithSystem(ctx context.Context, system, user string, opts llm.CompletionOptions) (string, error) {
+	text := strings.TrimPrefix(user, "Text to analyze:\n\n")
+	var result extractionResult
+	for i, s := range splitSentences(text) {
+		score := 1.0
+		opinion := strings.HasPrefix(s, "I think")
+		if opinion {
+			score = 0.1
+		}
+		result.Claims = append(result.Claims, extractedClaim{
+			Text:                s,
+			Type:                "factual",
+			SentenceIndex:       i,
+			ExtractabilityScore: &score,
+			IsOpinion:           opinion,
+			Language:            "en",
+		})
+	}
+	data, err := json.Marshal(result)
+	return string(data), err
+}
+
+// benchmarkDocument returns a fixed document of 2000 words in paragraphs of
+// five sentences, and the set of factual sentences a perfect extraction
+// would return.
+func benchmarkDocument() (string, map[string]bool) {
+	templates := []string{
+		"Station %d recorded %d millimetres of rain during the spring of 2021.",
+		"The northern reservoir held %d percent of its capacity in week %d.",
+		"I think the regional council handled the drought of year %d poorly in %d.",
+		"Farmers in district %d planted %d hectares of wheat after the rains returned.",
+		"The water authority reported %d burst pipes across zone %d in that period.",
+	}
+
+	facts := make(map[string]bool)
+	var doc strings.Builder
+	words := 0
+	for i := 0; words < 2000; i++ {
+		s := fmt.Sprintf(templates[i%len(templates)], i+1, (i*37)%500+10)
+		if i > 0 {
+			if i%5 == 0 {
+				doc.WriteString("\n\n")
+			} else {
+				doc.WriteString(" ")
+			}
+		}
+		doc.WriteString(s)
+		words += len(strings.Fields(s))
+		if !strings.HasPrefix(s, "I think") {
+			facts[s] = true
+		}
+	}
+	return doc.String(), facts
+}
+
+// BenchmarkChunkStrategies compares extraction across chunking strategies.
+// Claims that are not a whole factual sentence of the document, such as the
+// fragments left by cutting a sentence in two, count as false positives.
+func BenchmarkChunkStrategies(b *testing.B) {
+	// Dropped opinions are logged at debug level for every iteration
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+	zerolog.SetGlobalLevel(zerolog.InfoLevel)
+
+	doc, facts := benchmarkDocument()
+	provider := sentenceProvider{llm.NewMockProvider("")}
+
+	for _, strategy := range []string{ChunkSentence, ChunkParagraph, ChunkTokenCount} {
+		b.Run(strategy, func(b *testing.B) {
+			cfg := config.DefaultConfig()
+			cfg.LLM.ChunkStrategy = strategy
+			cfg.LLM.MaxChunkChars = 2000
+			extractor := NewClaimExtractor(provider, cfg)
+
+			var claimCount, falsePositives int
+			for i := 0; i < b.N; i++ {
+				claims, err := extractor.Extract(context.Background(), doc, ExtractOptions{})
+				if err != nil {
+					b.Fatal(err)
+				}
+				claimCount = len(claims)
+				falsePositives = 0
+				for _, c := range claims {
+					if !facts[c.Text] {
+						falsePositives++
+					}
+				}
+			}
+
+			b.ReportMetric(float64(claimCount), "claims")
+			b.ReportMetric(float64(falsePositives)/float64(max(claimCount, 1)), "false_positive_rate")
+		})
+	}
+}
@@ -0,0 +1,269 @@
+// Package verify provides two-step verification of citation claims.
+package verify
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/factchecker/verity/internal/search"
+	"github.com/rs/zerolog/log"
+)
+
+// CitedSource is the reference extracted from a citation claim.
+type CitedSource struct {
+	Author      string // First author's surname
+	Year        int
+	TitleHint   string // Quoted title, when the claim gives one
+	Substantive string // The claim with the attribution removed
+}
+
+// CitationResult combines both steps of citation verification: whether the
+// cited paper exists and says what is claimed, and whether the claim itself
+// holds up against general evidence.
+type CitationResult struct {
+	Source            CitedSource
+	SourceFound       bool
+	SourceSupports    bool
+	SubstantiveStatus models.VerificationStatus
+
+	Verdict   Verdict           // Combined verdict for the whole claim
+	Evidences []models.Evidence // Cited paper evidence first, then general evidence
+	Warnings  []models.Warning
+}
+
+const (
+	citationAuthor = `(\p{Lu}[\p{L}'’-]+)(?:\s+et\s+al\.?|\s+(?:and|&)\s+\p{Lu}[\p{L}'’-]+)?`
+	citationYear   = `((?:19|20)\d{2})[a-z]?`
+)
+
+var (
+	// "According to Smith et al. (2020), X" / "Smith and Jones (2020) found that X"
+	narrativeCitation = regexp.MustCompile(`(?:[Aa]ccording to\s+)?` + citationAuthor + `\s*\(` + citationYear + `\)`)
+	// "X (Smith et al., 2020)."
+	parentheticalCitation = regexp.MustCompile(`\s*\(` + citationAuthor + `,?\s+` + citationYear + `\)`)
+	// "A 2020 study by Smith found that X"
+	studyCitation = regexp.MustCompile(`\b(?:[Aa]|[Tt]he)\s+` + citationYear + `\s+(?:study|paper|report|review|meta-analysis|trial|survey)\s+(?:by|from)\s+` + citationAuthor)
+
+	quotedTitle = regexp.MustCompile(`["“]([^"”]{10,})["”]`)
+
+	// Reporting verbs between the attribution and the substantive claim
+	reportingPrefix = regexp.MustCompile(`(?i)^[\s,:]*(?:(?:has|have)\s+)?(?:found|showed|shown|shows|show|reported|reports|concluded|concludes|demonstrated|demonstrates|suggested|suggests|suggest|stated|states|state|argued|argues|argue|estimated|estimates)?\s*(?:that\s+)?`)
+
+	// Text before a narrative citation that only introduces it, as in
+	// "The study \"Title\" by Lee (2017)"
+	byPrefix = regexp.MustCompile(`(?i)\bby\s*$`)
+)
+
+// ParseCitation extracts the cited author, year and substantive claim from
+// a citation claim. It reports false when no author-year reference is found.
+func ParseCitation(text string) (CitedSource, bool) {
+	var src CitedSource
+	var rest string
+
+	if m := narrativeCitation.FindStringSubmatchIndex(text); m != nil {
+		src.Author, src.Year = text[m[2]:m[3]], atoiYear(text[m[4]:m[5]])
+		prefix := text[:m[0]]
+		if byPrefix.MatchString(prefix) {
+			prefix = ""
+		}
+		rest = prefix + " " + reportingPrefix.ReplaceAllString(text[m[1]:], "")
+	} else if m := studyCitation.FindStringSubmatchIndex(text); m != nil {
+		src.Year, src.Author = atoiYear(text[m[2]:m[3]]), text[m[4]:m[5]]
+		rest = text[:m[0]] + " " + reportingPrefix.ReplaceAllString(text[m[1]:], "")
+	} else if m := parentheticalCitation.FindStringSubmatchIndex(text); m != nil {
+		src.Author, src.Year = text[m[2]:m[3]], atoiYear(text[m[4]:m[5]])
+		rest = text[:m[0]] + text[m[1]:]
+	} else {
+		return src, false
+	}
+
+	if m := quotedTitle.FindStringSubmatch(text); m != nil {
+		src.TitleHint = strings.TrimSpace(m[1])
+	}
+
+	src.Substantive = strings.TrimSpace(strings.Join(strings.Fields(rest), " "))
+	if first, size := utf8.DecodeRuneInString(src.Substantive); size > 0 {
+		src.Substantive = string(unicode.ToUpper(first)) + src.Substantive[size:]
+	}
+	return src, src.Year > 0
+}
+
+func atoiYear(s string) int {
+	year, _ := strconv.Atoi(s)
+	return year
+}
+
+// keywords returns up to four search keywords for the cited paper, taken
+// from the title hint when present and otherwise from the substantive
+// claim, preferring longer (more specific) words.
+func (s CitedSource) keywords() []string {
+	text := s.TitleHint
+	if text == "" {
+		text = s.Substantive
+	}
+
+	seen := make(map[string]bool)
+	var words []string
+	for _, w := range significantWords(text) {
+		if !seen[w] && !strings.EqualFold(w, s.Author) {
+			seen[w] = true
+			words = append(words, w)
+		}
+	}
+	sort.SliceStable(words, func(i, j int) bool { return len(words[i]) > len(words[j]) })
+	if len(words) > 4 {
+		words = words[:4]
+	}
+	return words
+}
+
+// CitationVerifier verifies citation claims in two steps: it looks up the
+// cited paper in academic indexes and checks the claim against its abstract,
+// then verifies the substantive claim against general evidence.
+type CitationVerifier struct {
+	verifier     *ClaimVerifier
+	finders      []search.PaperFinder
+	searchClient *search.AggregatedSearchClient
+	ranking      *Formula
+	domains      *DomainScorer
+}
+
+// NewCitationVerifier creates a citation verifier. finders are consulted in
+// order until one returns the cited paper.
+func NewCitationVerifier(verifier *ClaimVerifier, finders []search.PaperFinder, searchClient *search.AggregatedSearchClient, ranking *Formula, domains *DomainScorer) *CitationVerifier {
+	return &CitationVerifier{
+		verifier:     verifier,
+		finders:      finders,
+		searchClient: searchClient,
+		ranking:      ranking,
+		domains:      domains,
+	}
+}
+
+// Verify runs both verification steps for a citation claim. It returns nil
+// when the claim has no recognizable author-year reference, in which case
+// the caller should verify it as a regular claim.
+func (v *CitationVerifier) Verify(ctx context.Context, claim models.Claim, languages []string, explain bool) (*CitationResult, error) {
+	src, ok := ParseCitation(claim.Text)
+	if !ok || src.Substantive == "" {
+		return nil, nil
+	}
+	result := &CitationResult{Source: src}
+	substantive := claim
+	substantive.Text = src.Substantive
+
+	// Step 1: find the cited paper and check it says what is claimed
+	var paperEvidence []models.Evidence
+	for _, f := range v.finders {
+		evidences, err := f.FindPaper(ctx, src.Author, src.Year, src.keywords(), 3)
+		if err != nil {
+			log.Debug().Err(err).Str("source", f.Name()).Msg("Cited paper lookup failed")
+			result.Warnings = append(result.Warnings, models.Warning{Source: f.Name(), Message: err.Error()})
+			continue
+		}
+		if len(evidences) > 0 {
+			for i := range evidences {
+				evidences[i].Fetcher = f.Name()
+			}
+			paperEvidence = evidences
+			break
+		}
+	}
+
+	var sourceVerdict Verdict
+	if len(paperEvidence) > 0 {
+		result.SourceFound = true
+
+		var err error
+		sourceVerdict, err = v.verifier.Verify(ctx, substantive, paperEvidence, explain, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify against cited source: %w", err)
+		}
+		applyUsefulness(paperEvidence, sourceVerdict.EvidenceUsefulness)
+		result.SourceSupports = sourceVerdict.Status == models.StatusVerified
+	}
+
+	// Step 2: verify the substantive claim independently
+	webEvidence, warnings := v.searchClient.Search(ctx, src.Substantive, 6, search.SearchOptions{
+		Languages:     languages,
+		ClaimLanguage: claim.DetectedLanguage,
+	})
+	result.Warnings = append(result.Warnings, warnings...)
+	rankEvidence(ctx, v.ranking, v.domains, src.Substantive, webEvidence)
+
+	// Without web evidence Verify can only answer unsupported; fall back to
+	// the model's own knowledge as in air-gapped mode
+	var generalVerdict Verdict
+	var err error
+	if len(webEvidence) == 0 {
+		generalVerdict, err = v.verifier.VerifyWithoutEvidence(ctx, substantive, explain, "")
+	} else {
+		generalVerdict, err = v.verifier.Verify(ctx, substantive, webEvidence, explain, "")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify substantive claim: %w", err)
+	}
+	applyUsefulness(webEvidence, generalVerdict.EvidenceUsefulness)
+	result.SubstantiveStatus = generalVerdict.Status
+
+	result.Evidences = append(paperEvidence, webEvidence...)
+	result.Verdict = combineCitationVerdicts(result, sourceVerdict, generalVerdict)
+	return result, nil
+}
+
+// applyUsefulness records the verifier's per-evidence usefulness assessment.
+func applyUsefulness(evidences []models.Evidence, usefulness []EvidenceUsefulness) {
+	for _, u := range usefulness {
+		if u.Index >= 0 && u.Index < len(evidences) {
+			evidences[u.Index].IsUseful = u.Useful
+			evidences[u.Index].Usefulness = u.Reason
+		}
+	}
+}
+
+// combineCitationVerdicts derives the claim's overall verdict. A claim is
+// verified only when the cited source supports it and it holds up on its
+// own; accurate attribution of a disputed claim, or a true claim attributed
+// to a source that does not support it, is mixed.
+func combineCitationVerdicts(r *CitationResult, source, general Verdict) Verdict {
+	substantiveOK := r.SubstantiveStatus == models.StatusVerified
+
+	var status models.VerificationStatus
+	switch {
+	case r.SourceSupports && substantiveOK:
+		status = models.StatusVerified
+	case r.SourceSupports || substantiveOK || r.SubstantiveStatus == models.StatusMixed:
+		status = models.StatusMixed
+	default:
+		status = models.StatusUnsupported
+	}
+
+	var sourceNote string
+	switch {
+	case !r.SourceFound:
+		sourceNote = fmt.Sprintf("The cited source (%s, %d) could not be found.", r.Source.Author, r.Source.Year)
+	case r.SourceSupports:
+		sourceNote = fmt.Sprintf("The cited source (%s, %d) supports the claim. %s", r.Source.Author, r.Source.Year, source.Reasoning)
+	default:
+		sourceNote = fmt.Sprintf("The cited source (%s, %d) does not clearly support the claim. %s", r.Source.Author, r.Source.Year, source.Reasoning)
+	}
+
+	confidence := general.Confidence
+	if r.SourceFound {
+		confidence = (source.Confidence + general.Confidence) / 2
+	}
+
+	return Verdict{
+		Status:         status,
+		Confidence:     confidence,
+		Reasoning:      strings.TrimSpace(sourceNote) + " Independent evidence: " + general.Reasoning,
+		ChainOfThought: strings.TrimSpace(source.ChainOfThought + "\n\n" + general.ChainOfThought),
+	}
+}
@@ -0,0 +1,98 @@
+// Package verify provides claim re-verification across LLM providers.
+package verify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/factchecker/verity/internal/config"
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/models"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// ErrNoConsensusProviders is returned by CompareProviders when no consensus
+// providers are configured.
+var ErrNoConsensusProviders = errors.New("no consensus providers configured")
+
+// consensusVerifier verifies claims with one of the configured consensus
+// providers.
+type consensusVerifier struct {
+	model    string
+	verifier *ClaimVerifier
+}
+
+// newConsensusVerifiers creates a verifier for each consensus provider.
+// Providers that cannot be created are logged and skipped.
+func newConsensusVerifiers(cfg *config.LLMConfig) []consensusVerifier {
+	var verifiers []consensusVerifier
+	for i := range cfg.ConsensusProviders {
+		pc := &cfg.ConsensusProviders[i]
+		provider, err := llm.NewProvider(pc)
+		if err != nil {
+			log.Error().Err(err).Str("provider", pc.Provider).Msg("Failed to create consensus provider")
+			continue
+		}
+		verifiers = append(verifiers, consensusVerifier{
+			model:    pc.Model,
+			verifier: NewClaimVerifier(llm.WithMetrics(provider), pc.FallbackModel, llm.ContextWindow(pc)),
+		})
+	}
+	return verifiers
+}
+
+// CompareProviders re-verifies a stored claim against its stored evidence
+// with every consensus provider, without searching again. Claims without
+// evidence are verified from model knowledge. A provider that fails is
+// reported with its error and counts as disagreeing.
+func (e *Engine) CompareProviders(ctx context.Context, claim models.Claim, jurisdiction string) (*models.ProviderComparison, error) {
+	if len(e.consensus) == 0 {
+		return nil, ErrNoConsensusProviders
+	}
+
+	results := make([]models.ProviderResult, len(e.consensus))
+	var wg sync.WaitGroup
+	for i, cv := range e.consensus {
+		wg.Add(1)
+		go func(i int, cv consensusVerifier) {
+			defer wg.Done()
+			var verdict Verdict
+			var err error
+			if len(claim.Evidences) == 0 {
+				verdict, err = cv.verifier.VerifyWithoutEvidence(ctx, claim, false, jurisdiction)
+			} else {
+				verdict, err = cv.verifier.Verify(ctx, claim, claim.Evidences, false, jurisdiction)
+			}
+
+			result := models.ProviderResult{Provider: cv.verifier.provider.Name(), Model: cv.model}
+			if err != nil {
+				log.Error().Err(err).Str("provider", result.Provider).Str("claim", claim.ID).Msg("Provider comparison failed")
+				result.Error = err.Error()
+			} else {
+				result.Status = verdict.Status
+				result.Confidence = verdict.Confidence
+				result.Reasoning = verdict.Reasoning
+			}
+			results[i] = result
+		}(i, cv)
+	}
+	wg.Wait()
+
+	agreement := true
+	for _, r := range results {
+		if r.Error != "" || r.Status != results[0].Status {
+			agreement = false
+		}
+	}
+
+	return &models.ProviderComparison{
+		ID:              uuid.New().String(),
+		ClaimID:         claim.ID,
+		ProviderResults: results,
+		Agreement:       agreement,
+		CreatedAt:       time.Now(),
+	}, nil
+}
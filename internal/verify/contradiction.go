@@ -0,0 +1,136 @@
+// Package verify provides detection of claims that contradict each other.
+package verify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/models"
+)
+
+const (
+	// contradictionBatchSize is how many claim pairs are checked per LLM call.
+	contradictionBatchSize = 20
+
+	// maxContradictionPairs bounds the pairs checked per document, since
+	// their number grows quadratically with the claims of a type.
+	maxContradictionPairs = 200
+)
+
+// ContradictionDetector finds claims of a document that cannot both be
+// true, such as two different figures for the same statistic.
+type ContradictionDetector struct {
+	provider llm.Provider
+}
+
+// NewContradictionDetector creates a new contradiction detector.
+func NewContradictionDetector(provider llm.Provider) *ContradictionDetector {
+	return &ContradictionDetector{provider: provider}
+}
+
+type contradictionResult struct {
+	Contradictions []struct {
+		Pair        int    `json:"pair"`
+		Explanation string `json:"explanation"`
+	} `json:"contradictions"`
+}
+
+// Detect asks the LLM whether each pair of claims of the same type
+// contradicts, batching pairs to keep calls few, and returns the pairs that
+// do. Contradictions found before a failed batch are returned with the error.
+func (d *ContradictionDetector) Detect(ctx context.Context, claims []models.Claim) ([]models.Contradiction, error) {
+	var pairs [][2]int
+	for i := range claims {
+		for j := i + 1; j < len(claims) && len(pairs) < maxContradictionPairs; j++ {
+			if claims[i].Type == claims[j].Type {
+				pairs = append(pairs, [2]int{i, j})
+			}
+		}
+	}
+
+	var found []models.Contradiction
+	for start := 0; start < len(pairs); start += contradictionBatchSize {
+		batch := pairs[start:min(start+contradictionBatchSize, len(pairs))]
+		contradictions, err := d.detectBatch(ctx, claims, batch)
+		if err != nil {
+			return found, err
+		}
+		found = append(found, contradictions...)
+	}
+	return found, nil
+}
+
+func (d *ContradictionDetector) detectBatch(ctx context.Context, claims []models.Claim, pairs [][2]int) ([]models.Contradiction, error) {
+	systemPrompt := `You are a fact-checker looking for inconsistencies within a single document.
+
+For each numbered pair of statements, answer: do these two statements contradict each other, so that they cannot both be true?
+Statements about different subjects, places or times do not contradict. Only report genuine contradictions, such as two different figures for the same statistic.
+
+Respond with a JSON object listing only the contradicting pairs:
+{
+  "contradictions": [
+    {"pair": 0, "explanation": "brief explanation of the inconsistency"}
+  ]
+}
+
+Only respond with the JSON object, no other text.`
+
+	var pairList strings.Builder
+	for n, p := range pairs {
+		pairList.WriteString(fmt.Sprintf("%d. A: %s\n   B: %s\n", n, claims[p[0]].Text, claims[p[1]].Text))
+	}
+
+	opts := llm.DefaultCompletionOptions()
+	opts.MaxTokens = 1024
+
+	userPrompt := "Pairs:\n" + pairList.String()
+	response, err := d.provider.CompleteWithSystem(ctx, systemPrompt, userPrompt, opts)
+	if err != nil {
+		return nil, fmt.Errorf("contradiction detection failed: %w", err)
+	}
+	chargeTokens(ctx, systemPrompt, userPrompt, response)
+
+	jsonText, err := extractJSONObject(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse contradiction response: %w", err)
+	}
+
+	var result contradictionResult
+	if err := json.Unmarshal([]byte(jsonText), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse contradiction response: %w", err)
+	}
+
+	var contradictions []models.Contradiction
+	seen := make(map[int]bool)
+	for _, c := range result.Contradictions {
+		if c.Pair < 0 || c.Pair >= len(pairs) || seen[c.Pair] {
+			continue
+		}
+		seen[c.Pair] = true
+		p := pairs[c.Pair]
+		contradictions = append(contradictions, models.Contradiction{
+			ClaimID:            claims[p[0]].ID,
+			ConflictingClaimID: claims[p[1]].ID,
+			Explanation:        c.Explanation,
+		})
+	}
+	return contradictions, nil
+}
+
+// markContradictions sets the status of every claim in a contradiction to
+// mixed: whatever the evidence says, the document is inconsistent about it.
+func markContradictions(claims []models.Claim, contradictions []models.Contradiction) {
+	involved := make(map[string]bool, 2*len(contradictions))
+	for _, c := range contradictions {
+		involved[c.ClaimID] = true
+		involved[c.ConflictingClaimID] = true
+	}
+	for i := range claims {
+		if involved[claims[i].ID] {
+			claims[i].Status = models.StatusMixed
+		}
+	}
+}
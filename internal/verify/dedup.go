@@ -0,0 +1,119 @@
+// Package verify provides deduplication of extracted claims.
+package verify
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/factchecker/verity/internal/models"
+)
+
+// dedupStopWords are left out when comparing claims, so that claims
+// differing only in function words still match.
+var dedupStopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "and": true, "or": true, "but": true,
+	"of": true, "in": true, "on": true, "at": true, "to": true, "for": true,
+	"by": true, "with": true, "from": true, "as": true, "that": true, "this": true,
+	"these": true, "those": true, "it": true, "its": true, "is": true, "are": true,
+	"was": true, "were": true, "be": true, "been": true, "has": true, "have": true,
+	"had": true, "which": true, "who": true, "than": true, "then": true,
+}
+
+// deduplicateClaims merges claims whose text has a Jaccard similarity above
+// threshold, ignoring case, punctuation and stop words. Of each group of
+// duplicates the claim with the lowest SentenceIndex is kept, with
+// SentenceIndices listing the sentences of every claim in the group. The
+// order of the kept claims is unchanged. A threshold <= 0 disables merging.
+func deduplicateClaims(claims []models.Claim, threshold float64) []models.Claim {
+	if threshold <= 0 || len(claims) < 2 {
+		return claims
+	}
+
+	// Visit claims by sentence, so that each group is led by its earliest claim
+	order := make([]int, len(claims))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return claims[order[a]].SentenceIndex < claims[order[b]].SentenceIndex
+	})
+
+	tokens := make([]map[string]bool, len(claims))
+	for i := range claims {
+		tokens[i] = claimTokens(claims[i].Text)
+	}
+
+	leader := make([]int, len(claims)) // index of the claim each one merges into
+	indices := make(map[int][]int)     // leader -> sentence indices of its group
+	var leaders []int
+	for _, i := range order {
+		leader[i] = i
+		for _, l := range leaders {
+			if jaccard(tokens[i], tokens[l]) > threshold {
+				leader[i] = l
+				break
+			}
+		}
+		if leader[i] == i {
+			leaders = append(leaders, i)
+		}
+		indices[leader[i]] = append(indices[leader[i]], claims[i].SentenceIndex)
+	}
+
+	if len(leaders) == len(claims) {
+		return claims
+	}
+	deduped := make([]models.Claim, 0, len(leaders))
+	for i, c := range claims {
+		if leader[i] != i {
+			continue
+		}
+		if group := indices[i]; len(group) > 1 {
+			c.SentenceIndices = uniqueSorted(group)
+		}
+		deduped = append(deduped, c)
+	}
+	return deduped
+}
+
+// claimTokens returns the set of lowercased words of text, without stop words.
+func claimTokens(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		if !dedupStopWords[w] {
+			set[w] = true
+		}
+	}
+	return set
+}
+
+// jaccard returns the size of the intersection of a and b over the size of
+// their union; two empty sets are not similar.
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range a {
+		if b[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// uniqueSorted returns the distinct values of s in ascending order.
+func uniqueSorted(s []int) []int {
+	sort.Ints(s)
+	out := s[:1]
+	for _, v := range s[1:] {
+		if v != out[len(out)-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
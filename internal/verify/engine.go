@@ -5,12 +5,18 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/factchecker/verity/internal/config"
 	"github.com/factchecker/verity/internal/database"
+	"github.com/factchecker/verity/internal/httpclient"
 	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/metrics"
 	"github.com/factchecker/verity/internal/models"
 	"github.com/factchecker/verity/internal/search"
 	"github.com/google/uuid"
@@ -19,95 +25,449 @@ import (
 
 // Engine orchestrates the complete fact-checking pipeline.
 type Engine struct {
+	provider     llm.Provider
 	extractor    *ClaimExtractor
+	translator   *ClaimTranslator
 	verifier     *ClaimVerifier
+	gaps         *EvidenceGapDetector
+	citations    *CitationVerifier
+	scorer       *SignificanceScorer
+	conflicts    *ContradictionDetector
+	ontology     *OntologyExpander
+	ranking      *Formula
+	domains      *DomainScorer
 	searchClient *search.AggregatedSearchClient
 	store        database.Store
+	settings     *RuntimeSettings
 	airGapped    bool
+	maxTokens    int     // per-analysis token budget, 0 for unlimited
+	dedup        float64 // similarity above which extracted claims are merged
+	batchLimit   int     // documents of a batch verified at once
+	consensus    []consensusVerifier
+	pricing      llm.PricingTable
+	model        string // priced model
+
+	// Stale-while-revalidate state
+	staleAfter time.Duration
+	onStale    func(text string, opts VerifyOptions)
+	refreshing sync.Map // document hash -> struct{}
 }
 
 // NewEngine creates a new verification engine.
 func NewEngine(cfg *config.Config, provider llm.Provider, store database.Store) *Engine {
+	provider = llm.WithMetrics(provider)
+	if cfg.LLM.ResponseCache {
+		// Outside the metrics wrapper, so that only real calls are counted
+		provider = llm.WithCache(provider, cfg.LLM.ResponseCacheSize)
+	}
+
 	// Create search clients based on configuration
 	var clients []search.SearchClient
+	var finders []search.PaperFinder
+	var reliability *search.WikidataReliabilityLookup
+
+	// All search clients share one connection pool
+	httpClient := httpclient.NewSharedHTTPClient()
 
-	if cfg.Search.DuckDuckGo {
-		clients = append(clients, search.NewDuckDuckGoClient())
+	if len(cfg.Search.EvidenceURLWhitelist) > 0 {
+		// Whitelist mode: only curated URLs are used as evidence sources
+		log.Info().Int("urls", len(cfg.Search.EvidenceURLWhitelist)).Msg("Evidence URL whitelist configured - external search disabled")
+		for _, u := range cfg.Search.EvidenceURLWhitelist {
+			clients = append(clients, search.NewStaticURLSearchClient(httpClient, u))
+		}
+	} else {
+		if cfg.Search.DuckDuckGo {
+			ddg := search.NewDuckDuckGoClient(
+				httpClient,
+				time.Duration(cfg.Search.MaxPageFetchSecs)*time.Second,
+				cfg.Search.DomainTimeouts,
+				cfg.Search.MinSnippetWordCount,
+			)
+			ddg.SetPreValidateURLs(cfg.Search.PreValidateURLs)
+			ddg.SetWaybackFallback(cfg.Search.WaybackFallback)
+			ddg.SetMinDomainFetchInterval(time.Duration(cfg.Search.MinDomainFetchIntervalMs) * time.Millisecond)
+			clients = append(clients, ddg)
+		}
+		if cfg.Search.Google.Enabled {
+			clients = append(clients, search.NewGoogleSearchClient(httpClient, cfg.Search.Google.APIKey, cfg.Search.Google.SearchEngineID))
+		}
+		if cfg.Search.NewsAPI.Enabled {
+			clients = append(clients, search.NewNewsAPIClient(httpClient, cfg.Search.NewsAPI.APIKey))
+		}
+		if cfg.Search.SearXNG.Enabled {
+			clients = append(clients, search.NewSearXNGClient(httpClient, cfg.Search.SearXNG.BaseURL))
+		}
+		// Wikipedia disabled - not considered a reliable source
+		// if cfg.Search.Wikipedia {
+		// 	clients = append(clients, search.NewWikipediaClient(httpClient))
+		// }
+		if cfg.Search.PubMed {
+			pubmed := search.NewPubMedClient(httpClient, cfg.Search.PubMedAPIKey, cfg.Search.MaxArticlesPerJournal)
+			clients = append(clients, pubmed)
+			finders = append(finders, pubmed)
+		}
+		if cfg.Search.ArXiv {
+			clients = append(clients, search.NewArXivClient(httpClient))
+		}
+		if cfg.Search.SemanticScholar {
+			clients = append(clients, search.NewSemanticScholarClient(httpClient))
+		}
+		if cfg.Search.CrossRef {
+			finders = append(finders, search.NewCrossRefClient(httpClient))
+		}
+		if cfg.Search.WikidataReliability {
+			reliability = search.NewWikidataReliabilityLookup(httpClient)
+		}
+		if cfg.Search.PluginDir != "" {
+			clients = append(clients, search.LoadPlugins(cfg.Search.PluginDir)...)
+		}
 	}
-	// Wikipedia disabled - not considered a reliable source
-	// if cfg.Search.Wikipedia {
-	// 	clients = append(clients, search.NewWikipediaClient())
-	// }
-	if cfg.Search.PubMed {
-		clients = append(clients, search.NewPubMedClient())
+
+	if ttl := time.Duration(cfg.Search.CacheTTLSeconds) * time.Second; ttl > 0 {
+		for i, c := range clients {
+			clients[i] = search.NewCachedSearchClient(c, ttl)
+		}
 	}
 
 	searchClient := search.NewAggregatedSearchClient(clients...)
+	searchClient.SetLanguageFilter(cfg.Search.EvidenceLanguageFilter)
+	searchClient.SetConcurrencyBudget(cfg.Engine.SearchConcurrencyBudget)
 	airGapped := !searchClient.HasClients()
 
 	if airGapped {
 		log.Warn().Msg("No search sources configured - running in air-gapped mode")
 	}
 
+	contextWindow := llm.ContextWindow(&cfg.LLM)
+
+	ranking, _ := ParseFormula(DefaultRankingFormula)
+	if cfg.Verify.EvidenceRankingFormula != "" {
+		f, err := ParseFormula(cfg.Verify.EvidenceRankingFormula)
+		if err != nil {
+			log.Warn().Err(err).Str("formula", cfg.Verify.EvidenceRankingFormula).Msg("Invalid evidence ranking formula, using default")
+		} else {
+			ranking = f
+		}
+	}
+
+	domains := NewDomainScorer(reliability)
+
+	verifier := NewClaimVerifier(provider, cfg.LLM.FallbackModel, contextWindow)
+	if cfg.Verify.RankedEvidence {
+		verifier.topEvidence = max(1, cfg.Verify.RankedEvidenceTopK)
+	}
+	var citations *CitationVerifier
+	if !airGapped {
+		citations = NewCitationVerifier(verifier, finders, searchClient, ranking, domains)
+	}
+
+	pricing, err := llm.LoadPricing(cfg.LLM.PricingFile)
+	if err != nil {
+		log.Warn().Err(err).Str("file", cfg.LLM.PricingFile).Msg("Invalid pricing file, using bundled prices")
+	}
+
+	settings := NewRuntimeSettings(store)
+	extractor := NewClaimExtractor(provider, cfg)
+	extractor.settings = settings
+
 	return &Engine{
-		extractor:    NewClaimExtractor(provider, cfg.CustomClaimTypes),
-		verifier:     NewClaimVerifier(provider),
+		provider:     provider,
+		extractor:    extractor,
+		translator:   NewClaimTranslator(provider, cfg.Verify.PrimaryLanguage),
+		verifier:     verifier,
+		gaps:         NewEvidenceGapDetector(provider),
+		citations:    citations,
+		scorer:       NewSignificanceScorer(provider),
+		conflicts:    NewContradictionDetector(provider),
+		ontology:     NewOntologyExpander(cfg.Ontology.Categories),
+		ranking:      ranking,
+		domains:      domains,
 		searchClient: searchClient,
 		store:        store,
+		settings:     settings,
 		airGapped:    airGapped,
+		maxTokens:    cfg.LLM.MaxTokensPerAnalysis,
+		dedup:        cfg.Verify.DedupThreshold,
+		batchLimit:   max(1, cfg.Engine.BatchConcurrency),
+		consensus:    newConsensusVerifiers(&cfg.LLM),
+		pricing:      pricing,
+		model:        pricedModel(cfg, provider),
+		staleAfter:   time.Duration(cfg.Engine.StalenessThresholdHours) * time.Hour,
+	}
+}
+
+// pricedModel returns the configured model, or the provider's default when
+// none is configured. An Azure deployment name is only used as a fallback,
+// since the configured model is what determines its price.
+func pricedModel(cfg *config.Config, provider llm.Provider) string {
+	if cfg.LLM.Model != "" {
+		return cfg.LLM.Model
+	}
+	return provider.Model()
+}
+
+// SetStaleHandler registers fn to enqueue background re-verification of stale
+// cached analyses. fn receives options with ForceRefresh set and must not
+// block. Without a handler, stale analyses are served as regular cache hits.
+func (e *Engine) SetStaleHandler(fn func(text string, opts VerifyOptions)) {
+	e.onStale = fn
+}
+
+// Provider returns the LLM provider used by the engine.
+func (e *Engine) Provider() llm.Provider {
+	return e.provider
+}
+
+// Close stops the background work of the engine's search sources.
+func (e *Engine) Close() error {
+	return e.searchClient.Close()
+}
+
+// VerifyOptions holds per-request overrides for the verification pipeline.
+type VerifyOptions struct {
+	// EvidenceLanguages overrides the configured evidence language filter.
+	// It is part of the analysis cache key.
+	EvidenceLanguages []string
+
+	// Explain asks the verifier for its full chain of thought. Analyses made
+	// without it have none, so it is part of the analysis cache key.
+	Explain bool
+
+	// OnClaimVerified, if set, is called as each claim finishes verification.
+	// It may be called concurrently from multiple goroutines.
+	OnClaimVerified func(claim models.Claim)
+
+	// OnClaimsExtracted, if set, is called with the number of claims found
+	// once extraction finishes, before any is verified. Cached analyses
+	// are returned without calling it.
+	OnClaimsExtracted func(count int)
+
+	// ForceRefresh bypasses the analysis cache and re-verifies the text.
+	ForceRefresh bool
+
+	// FocusHints and IgnoreHints steer claim extraction. Cached analyses are
+	// only reused for requests with the same hints.
+	FocusHints  []string
+	IgnoreHints []string
+
+	// Structured, when the text was flattened from HTML, marks each claim
+	// with the format (prose, table or list) it was extracted from.
+	Structured *StructuredContent
+
+	// Format selects a specialized extraction mode; FormatAbstract extracts
+	// with AbstractClaimExtractor. Empty uses the general extractor. Like
+	// hints, it is part of the analysis cache key.
+	Format string
+
+	// ModelSource names the LLM the text was reported to be generated by,
+	// e.g. "GPT-4". Claim confidence is reduced by modelSourcePenalty; it is
+	// part of the analysis cache key.
+	ModelSource string
+
+	// Jurisdiction, e.g. "US", "BR" or "EU", scopes evidence search and
+	// verification to where claims apply. Evidence for geographic claims
+	// must mention it. It is part of the analysis cache key.
+	Jurisdiction string
+}
+
+// cacheOptions are the verify options that change an analysis. Analyses
+// are cached per document and options, so that a request never gets an
+// analysis made with options it did not ask for.
+type cacheOptions struct {
+	FocusHints   []string `json:"focus_hints,omitempty"`
+	IgnoreHints  []string `json:"ignore_hints,omitempty"`
+	Format       string   `json:"format,omitempty"`
+	ModelSource  string   `json:"model_source,omitempty"`
+	Jurisdiction string   `json:"jurisdiction,omitempty"`
+
+	EvidenceLanguages []string `json:"evidence_languages,omitempty"`
+	Explain           bool     `json:"explain,omitempty"`
+}
+
+// analysisCacheKey returns the cache key of the options of a request:
+// empty for default options, otherwise a hash of them.
+func analysisCacheKey(opts VerifyOptions) string {
+	encoded := encodeOptions(opts)
+	if encoded == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(encoded))
+	return hex.EncodeToString(sum[:])
+}
+
+// encodeOptions returns the JSON of the options of a request that change
+// an analysis, or "" for default options.
+func encodeOptions(opts VerifyOptions) string {
+	data, _ := json.Marshal(cacheOptions{
+		FocusHints:   opts.FocusHints,
+		IgnoreHints:  opts.IgnoreHints,
+		Format:       opts.Format,
+		ModelSource:  opts.ModelSource,
+		Jurisdiction: opts.Jurisdiction,
+
+		EvidenceLanguages: opts.EvidenceLanguages,
+		Explain:           opts.Explain,
+	})
+	if string(data) == "{}" {
+		return ""
+	}
+	return string(data)
+}
+
+// AnalysisOptions returns the options a stored analysis was made with, so
+// that re-running it extracts and verifies claims the same way.
+func AnalysisOptions(analysis *models.AnalysisResult) VerifyOptions {
+	var o cacheOptions
+	if analysis.Options != "" {
+		if err := json.Unmarshal([]byte(analysis.Options), &o); err != nil {
+			log.Warn().Err(err).Str("id", analysis.ID).Msg("Invalid stored analysis options, using defaults")
+		}
+	}
+	return VerifyOptions{
+		FocusHints:        o.FocusHints,
+		IgnoreHints:       o.IgnoreHints,
+		Format:            o.Format,
+		EvidenceLanguages: o.EvidenceLanguages,
+		Explain:           o.Explain,
+		// Stored on their own since before the other options were, so
+		// they are also known for older analyses
+		ModelSource:  analysis.ModelSource,
+		Jurisdiction: analysis.Jurisdiction,
 	}
 }
 
+// modelSourcePenalty scales the confidence of claims in text disclosed as
+// LLM-generated, which often states fabricated details fluently.
+const modelSourcePenalty = 0.85
+
 // VerifyText processes text through the complete fact-checking pipeline.
-func (e *Engine) VerifyText(ctx context.Context, text string) (*models.VerificationResponse, error) {
+func (e *Engine) VerifyText(ctx context.Context, text string, opts VerifyOptions) (*models.VerificationResponse, error) {
 	startTime := time.Now()
 
-	// Calculate document hash
+	// Calculate document hash. Copies differing only in whitespace or line
+	// endings share the hash; the caller keeps the original text for display.
+	text = NormalizeDocument(text)
 	hash := sha256.Sum256([]byte(text))
 	docHash := hex.EncodeToString(hash[:])
 
-	// Check for existing analysis
-	existing, err := e.store.GetAnalysisByHash(ctx, docHash)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to check for existing analysis")
-	}
-	if existing != nil {
-		log.Info().Str("id", existing.ID).Msg("Returning cached analysis")
-		claims, _ := e.store.GetClaimsByAnalysis(ctx, existing.ID)
-		return &models.VerificationResponse{
-			ID:           existing.ID,
-			DocumentHash: docHash,
-			Analysis:     *existing,
-			Claims:       claims,
-		}, nil
+	cacheKey := analysisCacheKey(opts)
+	if opts.ForceRefresh {
+		defer e.refreshing.Delete(cacheKey + docHash)
+	} else {
+		// Check for an existing analysis made with the same options
+		existing, err := e.store.GetAnalysisByHash(ctx, docHash, cacheKey)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to check for existing analysis")
+		}
+		if existing != nil {
+			log.Info().Str("id", existing.ID).Msg("Returning cached analysis")
+			claims, _ := e.store.GetClaimsByAnalysis(ctx, existing.ID)
+			contradictions, _ := e.store.GetContradictionsByAnalysis(ctx, existing.ID)
+			resp := &models.VerificationResponse{
+				ID:             existing.ID,
+				DocumentHash:   docHash,
+				Analysis:       *existing,
+				Claims:         claims,
+				Contradictions: contradictions,
+			}
+			if e.isStale(existing) {
+				resp.Stale = true
+				resp.Refreshing = e.revalidate(cacheKey+docHash, text, opts)
+			}
+			return resp, nil
+		}
 	}
 
+	metrics.VerificationsInFlight.Inc()
+	defer metrics.VerificationsInFlight.Dec()
+
+	ctx, usage := llm.WithUsageRecorder(ctx)
+
+	// Extraction, verification and significance scoring share the token
+	// budget; once it runs out their context is cancelled. Persistence uses
+	// ctx.
+	budgetCtx, budget, cancelBudget := withTokenBudget(ctx, e.maxTokens)
+	defer cancelBudget()
+
 	// Step 1: Extract claims
 	log.Info().Msg("Step 1: Extracting claims")
-	claims, err := e.extractor.Extract(ctx, text)
+	claims, err := e.extractClaims(budgetCtx, text, opts)
 	if err != nil {
+		if budget.Exhausted() {
+			return nil, fmt.Errorf("token budget of %d exhausted during claim extraction: %w", e.maxTokens, err)
+		}
 		return nil, err
 	}
-	log.Info().Int("count", len(claims)).Msg("Claims extracted")
+	if opts.Structured != nil {
+		for i := range claims {
+			claims[i].SourceFormat = opts.Structured.FormatOf(claims[i].SentenceIndex)
+		}
+	}
+	if opts.OnClaimsExtracted != nil {
+		opts.OnClaimsExtracted(len(claims))
+	}
+
+	warnings := longClaimWarnings(claims)
+	contradictions, err := e.conflicts.Detect(budgetCtx, claims)
+	if err != nil {
+		log.Warn().Err(err).Msg("Contradiction detection failed")
+		warnings = append(warnings, models.Warning{Source: "contradictions", Message: err.Error()})
+	}
 
 	// Step 2: Verify claims (concurrently with limited parallelism)
 	log.Info().Msg("Step 2: Verifying claims")
-	var warnings []models.Warning
-	claims, claimWarnings := e.verifyClaims(ctx, claims)
+	claims, claimWarnings := e.verifyClaims(budgetCtx, claims, opts)
 	warnings = append(warnings, claimWarnings...)
+	markContradictions(claims, contradictions)
+	if budget.Exhausted() {
+		unverified := 0
+		for _, c := range claims {
+			if c.Reasoning == budgetExhaustedReasoning {
+				unverified++
+			}
+		}
+		log.Warn().Int64("tokens", budget.Used()).Int("unverified", unverified).Msg("Token budget exhausted")
+		warnings = append(warnings, models.Warning{
+			Source:  "budget",
+			Message: fmt.Sprintf("token budget of %d exhausted; %d claims were not verified", e.maxTokens, unverified),
+		})
+	}
+
+	if opts.ModelSource != "" {
+		warnings = append(warnings, models.Warning{
+			Source:  "model_source",
+			Message: "This text was reported as AI-generated; confidence scores are adjusted accordingly.",
+		})
+	}
+
+	// Scoring runs last, so an exhausted budget only leaves it out
+	if err := e.scorer.Score(budgetCtx, text, claims); err != nil {
+		log.Warn().Err(err).Msg("Significance scoring failed")
+		warnings = append(warnings, models.Warning{Source: "significance", Message: err.Error()})
+	}
 
 	// Step 3: Calculate scores
 	log.Info().Msg("Step 3: Calculating scores")
 	analysis := e.calculateAnalysis(docHash, claims, time.Since(startTime))
+	analysis.ModelSource = opts.ModelSource
+	analysis.Jurisdiction = opts.Jurisdiction
+	analysis.CacheKey = cacheKey
+	analysis.Options = encodeOptions(opts)
 
 	// Step 4: Persist results
 	log.Info().Msg("Step 4: Persisting results")
+	if err := e.store.SaveDocument(ctx, docHash, text); err != nil {
+		log.Error().Err(err).Msg("Failed to save document")
+	}
 	if err := e.store.SaveAnalysis(ctx, &analysis); err != nil {
 		log.Error().Err(err).Msg("Failed to save analysis")
 	}
 	if err := e.store.SaveClaims(ctx, analysis.ID, claims); err != nil {
 		log.Error().Err(err).Msg("Failed to save claims")
 	}
+	if err := e.store.SaveContradictions(ctx, analysis.ID, contradictions); err != nil {
+		log.Error().Err(err).Msg("Failed to save contradictions")
+	}
 
 	log.Info().
 		Str("id", analysis.ID).
@@ -116,22 +476,107 @@ func (e *Engine) VerifyText(ctx context.Context, text string) (*models.Verificat
 		Int64("duration_ms", analysis.ProcessingTimeMs).
 		Msg("Verification complete")
 
+	// Calls with the fallback model and embeddings are priced as the
+	// configured model
+	tokens := usage.Total()
 	return &models.VerificationResponse{
-		ID:           analysis.ID,
-		DocumentHash: docHash,
-		Analysis:     analysis,
-		Claims:       claims,
-		Warnings:     warnings,
+		ID:               analysis.ID,
+		DocumentHash:     docHash,
+		Analysis:         analysis,
+		Claims:           claims,
+		Warnings:         warnings,
+		Contradictions:   contradictions,
+		EstimatedCostUSD: e.pricing.EstimateCost(e.model, int(tokens.PromptTokens), int(tokens.CompletionTokens)),
 	}, nil
 }
 
-func (e *Engine) verifyClaims(ctx context.Context, claims []models.Claim) ([]models.Claim, []models.Warning) {
+// BatchItem is one document of a batch verification.
+type BatchItem struct {
+	Text    string
+	Options VerifyOptions
+}
+
+// VerifyBatch verifies documents concurrently, at most batchLimit at a
+// time, and returns a response for each in the same order. A document that
+// fails gets a response with only Error set, so the others still complete.
+func (e *Engine) VerifyBatch(ctx context.Context, items []BatchItem) []*models.VerificationResponse {
+	results := make([]*models.VerificationResponse, len(items))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, e.batchLimit)
+
+	for i := range items {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			result, err := e.VerifyText(ctx, items[idx].Text, items[idx].Options)
+			if err != nil {
+				result = &models.VerificationResponse{Error: err.Error()}
+			}
+			results[idx] = result
+		}(i)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// isStale reports whether a cached analysis is past the staleness threshold.
+func (e *Engine) isStale(analysis *models.AnalysisResult) bool {
+	return e.staleAfter > 0 && e.onStale != nil && time.Since(analysis.CreatedAt) > e.staleAfter
+}
+
+// revalidate enqueues a background re-verification of a stale document,
+// unless one is already running for the same options, identified by key.
+// It reports whether a refresh is in progress.
+func (e *Engine) revalidate(key, text string, opts VerifyOptions) bool {
+	if _, running := e.refreshing.LoadOrStore(key, struct{}{}); running {
+		return true
+	}
+	log.Info().Str("key", key).Msg("Cached analysis is stale, refreshing in background")
+	opts.ForceRefresh = true
+	opts.OnClaimVerified = nil
+	opts.OnClaimsExtracted = nil
+	e.onStale(text, opts)
+	return true
+}
+
+// extractClaims extracts the claims of text as requested by opts, merges
+// duplicates and expands them with the ontology.
+func (e *Engine) extractClaims(ctx context.Context, text string, opts VerifyOptions) ([]models.Claim, error) {
+	extractOpts := ExtractOptions{
+		FocusHints:  opts.FocusHints,
+		IgnoreHints: opts.IgnoreHints,
+	}
+	var claims []models.Claim
+	var err error
+	if opts.Format == FormatAbstract {
+		claims, err = NewAbstractClaimExtractor(e.extractor).Extract(ctx, text, extractOpts)
+	} else {
+		claims, err = e.extractor.Extract(ctx, text, extractOpts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Int("count", len(claims)).Msg("Claims extracted")
+	if deduped := deduplicateClaims(claims, e.dedup); len(deduped) < len(claims) {
+		log.Info().Int("merged", len(claims)-len(deduped)).Msg("Duplicate claims merged")
+		claims = deduped
+	}
+	e.ontology.Expand(claims)
+	return claims, nil
+}
+
+func (e *Engine) verifyClaims(ctx context.Context, claims []models.Claim, opts VerifyOptions) ([]models.Claim, []models.Warning) {
 	var warnings []models.Warning
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 
 	// Limit concurrent verifications
-	semaphore := make(chan struct{}, 5)
+	semaphore := make(chan struct{}, e.settings.Int(ctx, SettingMaxConcurrency, defaultMaxConcurrency))
+	maxEvidence := e.settings.Int(ctx, SettingMaxEvidence, defaultMaxEvidence)
 
 	for i := range claims {
 		wg.Add(1)
@@ -141,67 +586,180 @@ func (e *Engine) verifyClaims(ctx context.Context, claims []models.Claim) ([]mod
 			defer func() { <-semaphore }()
 
 			claim := &claims[idx]
+			claim.SourceDocument = opts.ModelSource
+			if tokenBudgetExhausted(ctx) {
+				claim.Status = models.StatusUnsupported
+				claim.Reasoning = budgetExhaustedReasoning
+				claim.CreatedAt = time.Now()
+				if opts.OnClaimVerified != nil {
+					opts.OnClaimVerified(*claim)
+				}
+				return
+			}
 
-			var status models.VerificationStatus
-			var confidence float64
-			var reasoning string
+			var verdict Verdict
 			var evidences []models.Evidence
 
 			if e.airGapped {
 				// Air-gapped mode: verify using LLM knowledge only
 				var err error
-				status, confidence, reasoning, err = e.verifier.VerifyWithoutEvidence(ctx, *claim)
+				verdict, err = e.verifier.VerifyWithoutEvidence(ctx, *claim, opts.Explain, opts.Jurisdiction)
 				if err != nil {
 					log.Error().Err(err).Str("claim", claim.Text[:min(50, len(claim.Text))]).Msg("Verification failed")
-					status = models.StatusUnsupported
-					reasoning = "Verification error"
+					verdict = failedVerdict(ctx, "Verification error")
 				}
 				claim.SourceType = models.SourceTypeModelBased
+			} else if citation := e.verifyCitation(ctx, *claim, opts); citation != nil {
+				// Citation claims: check the cited source, then the claim itself
+				mu.Lock()
+				warnings = append(warnings, citation.Warnings...)
+				mu.Unlock()
+
+				verdict = citation.Verdict
+				evidences = citation.Evidences
+				claim.SourceType = models.SourceTypeEvidenceBacked
 			} else {
 				// Normal mode: search for evidence and verify
-				searchResults, searchWarnings := e.searchClient.Search(ctx, claim.Text, 6)
+				query, queryLanguage := e.searchQuery(ctx, claim)
+				searchResults, searchWarnings := e.searchClient.Search(ctx, query, maxEvidence, search.SearchOptions{
+					Languages:     opts.EvidenceLanguages,
+					ClaimLanguage: queryLanguage,
+					Jurisdiction:  opts.Jurisdiction,
+				})
 
 				mu.Lock()
 				warnings = append(warnings, searchWarnings...)
 				mu.Unlock()
 
 				evidences = searchResults
+				if claim.Type == models.ClaimTypeGeographic {
+					evidences = filterByJurisdiction(evidences, opts.Jurisdiction)
+				}
+				rankEvidence(ctx, e.ranking, e.domains, claim.Text, evidences)
+				if len(evidences) > maxEvidence {
+					evidences = evidences[:maxEvidence]
+				}
 
 				// If no evidence found, fallback to LLM-based verification
 				if len(evidences) == 0 {
 					log.Info().Str("claim", claim.Text[:min(50, len(claim.Text))]).Msg("No evidence found, using LLM fallback")
 					var err error
-					status, confidence, reasoning, err = e.verifier.VerifyWithoutEvidence(ctx, *claim)
+					verdict, err = e.verifier.VerifyWithoutEvidence(ctx, *claim, opts.Explain, opts.Jurisdiction)
 					if err != nil {
 						log.Error().Err(err).Msg("LLM fallback verification failed")
-						status = models.StatusUnsupported
-						reasoning = "Verification error - no evidence found"
+						verdict = failedVerdict(ctx, "Verification error - no evidence found")
 					}
 					claim.SourceType = models.SourceTypeModelBased
 				} else {
 					var err error
-					status, confidence, reasoning, err = e.verifier.Verify(ctx, *claim, evidences)
+					verdict, err = e.verifier.Verify(ctx, *claim, evidences, opts.Explain, opts.Jurisdiction)
 					if err != nil {
 						log.Error().Err(err).Str("claim", claim.Text[:min(50, len(claim.Text))]).Msg("Verification failed")
-						status = models.StatusUnsupported
-						reasoning = "Verification error"
+						verdict = failedVerdict(ctx, "Verification error")
 					}
+					applyUsefulness(evidences, verdict.EvidenceUsefulness)
 					claim.SourceType = models.SourceTypeEvidenceBacked
 				}
 			}
 
-			claim.Status = status
-			claim.Confidence = confidence
-			claim.Reasoning = reasoning
+			if inconclusive(verdict) {
+				suggested, err := e.gaps.DetectGap(ctx, *claim, evidences)
+				if err != nil {
+					log.Warn().Err(err).Str("claim", claim.Text[:min(50, len(claim.Text))]).Msg("Evidence gap detection failed")
+				}
+				claim.SuggestedSearches = suggested
+			}
+
+			claim.Status = verdict.Status
+			claim.Confidence = verdict.Confidence
+			if opts.ModelSource != "" {
+				claim.Confidence *= modelSourcePenalty
+			}
+			claim.Reasoning = verdict.Reasoning
+			claim.ChainOfThought = verdict.ChainOfThought
 			claim.Evidences = evidences
 			claim.CreatedAt = time.Now()
+
+			if opts.OnClaimVerified != nil {
+				opts.OnClaimVerified(*claim)
+			}
 		}(i)
 	}
 
 	wg.Wait()
+	for _, c := range claims {
+		metrics.ClaimsVerified.WithLabelValues(string(c.Status)).Inc()
+	}
 	return claims, warnings
 }
 
+// failedVerdict is the verdict of a claim whose verification failed. Calls
+// cut short by an exhausted token budget say so instead of reasoning.
+func failedVerdict(ctx context.Context, reasoning string) Verdict {
+	if tokenBudgetExhausted(ctx) {
+		reasoning = budgetExhaustedReasoning
+	}
+	return Verdict{Status: models.StatusUnsupported, Reasoning: reasoning}
+}
+
+// searchQuery returns the text to search evidence for claim with and its
+// language. Claims not in the primary language are translated, setting
+// TranslatedText; the verification prompt keeps the original text. If
+// translation fails the original text is searched. The claim's ontology
+// sub-type, if any, is added to narrow the search to its domain.
+func (e *Engine) searchQuery(ctx context.Context, claim *models.Claim) (string, string) {
+	query, language := e.searchText(ctx, claim)
+	if claim.SubType != "" {
+		query += " " + strings.ReplaceAll(claim.SubType, "_", " ")
+	}
+	return query, language
+}
+
+// searchText returns the claim text to search and its language, translating
+// it when a translator is configured.
+func (e *Engine) searchText(ctx context.Context, claim *models.Claim) (string, string) {
+	if e.translator == nil {
+		return claim.Text, claim.DetectedLanguage
+	}
+	if claim.TranslatedText == "" {
+		translated, err := e.translator.Translate(ctx, *claim)
+		if err != nil {
+			log.Warn().Err(err).Str("claim", claim.Text[:min(50, len(claim.Text))]).Msg("Claim translation failed, searching original text")
+		}
+		claim.TranslatedText = translated
+	}
+	if claim.TranslatedText == "" {
+		return claim.Text, claim.DetectedLanguage
+	}
+	return claim.TranslatedText, e.translator.target
+}
+
+// verifyCitation verifies a citation claim against its cited source and
+// general evidence. It returns nil when the claim is not a citation, has no
+// recognizable reference, or no evidence was found, so that it is verified
+// as a regular claim instead.
+func (e *Engine) verifyCitation(ctx context.Context, claim models.Claim, opts VerifyOptions) *CitationResult {
+	if e.citations == nil || claim.Type != models.ClaimTypeCitation {
+		return nil
+	}
+	result, err := e.citations.Verify(ctx, claim, opts.EvidenceLanguages, opts.Explain)
+	if err != nil {
+		log.Error().Err(err).Str("claim", claim.Text[:min(50, len(claim.Text))]).Msg("Citation verification failed")
+		return nil
+	}
+	if result == nil || len(result.Evidences) == 0 {
+		return nil
+	}
+	log.Debug().
+		Str("author", result.Source.Author).
+		Int("year", result.Source.Year).
+		Bool("source_found", result.SourceFound).
+		Bool("source_supports", result.SourceSupports).
+		Str("substantive_status", string(result.SubstantiveStatus)).
+		Msg("Citation verified")
+	return result
+}
+
 func (e *Engine) calculateAnalysis(docHash string, claims []models.Claim, duration time.Duration) models.AnalysisResult {
 	var verified, mixed, unsupported int
 	for _, claim := range claims {
@@ -223,10 +781,28 @@ func (e *Engine) calculateAnalysis(docHash string, claims []models.Claim, durati
 		score = (scoreSum / float64(len(claims))) * 10
 	}
 
+	// Claim lengths, in words
+	var totalWords, maxWords, minWords int
+	for i, claim := range claims {
+		n := len(strings.Fields(claim.Text))
+		totalWords += n
+		if n > maxWords {
+			maxWords = n
+		}
+		if i == 0 || n < minWords {
+			minWords = n
+		}
+	}
+	var avgWords float64
+	if len(claims) > 0 {
+		avgWords = float64(totalWords) / float64(len(claims))
+	}
+
 	return models.AnalysisResult{
 		ID:                uuid.New().String(),
 		DocumentHash:      docHash,
 		OverallScore:      score,
+		ScoreInterval:     scoreInterval(claims),
 		TotalClaims:       len(claims),
 		VerifiedClaims:    verified,
 		MixedClaims:       mixed,
@@ -234,7 +810,49 @@ func (e *Engine) calculateAnalysis(docHash string, claims []models.Claim, durati
 		ProcessingTimeMs:  duration.Milliseconds(),
 		Status:            "completed",
 		CreatedAt:         time.Now(),
+		TopClaims:         topClaims(claims, topClaimsCount),
+		AvgClaimLength:    avgWords,
+		MaxClaimLength:    maxWords,
+		MinClaimLength:    minWords,
+	}
+}
+
+// longClaimWords is the length, in words, above which a claim is flagged
+// as too long to verify reliably.
+const longClaimWords = 40
+
+// longClaimWarnings flags claims longer than longClaimWords.
+func longClaimWarnings(claims []models.Claim) []models.Warning {
+	var warnings []models.Warning
+	for _, c := range claims {
+		if n := len(strings.Fields(c.Text)); n > longClaimWords {
+			warnings = append(warnings, models.Warning{
+				Source:  "extraction",
+				Message: fmt.Sprintf("Claim has %d words; consider breaking it into smaller claims: %s", n, c.Text),
+			})
+		}
+	}
+	return warnings
+}
+
+// topClaimsCount is how many claims are kept in an analysis digest.
+const topClaimsCount = 3
+
+// topClaims returns the n most significant claims without their evidence.
+func topClaims(claims []models.Claim, n int) []models.Claim {
+	ranked := make([]models.Claim, len(claims))
+	copy(ranked, claims)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Significance > ranked[j].Significance
+	})
+	if len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	for i := range ranked {
+		ranked[i].Evidences = nil
+		ranked[i].ChainOfThought = ""
 	}
+	return ranked
 }
 
 func min(a, b int) int {
@@ -0,0 +1,44 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/models"
+	"github.com/factchecker/verity/internal/search"
+)
+
+// fakeSource returns as many distinct results as it is asked for.
+type fakeSource struct{ name string }
+
+var fakeSnippetWords = []string{"budget", "harvest", "glacier", "parliament", "vaccine", "railway", "copper", "satellite", "drought", "tariff"}
+
+func (s fakeSource) Search(ctx context.Context, query string, maxResults int, opts search.SearchOptions) ([]models.Evidence, error) {
+	results := make([]models.Evidence, maxResults)
+	for i := range results {
+		results[i] = models.Evidence{
+			SourceName: s.name,
+			SourceURL:  fmt.Sprintf("https://%s.example/%d", s.name, i),
+			Snippet:    fmt.Sprintf("%s %s %s report number %d", s.name, fakeSnippetWords[i%len(fakeSnippetWords)], fakeSnippetWords[(i+3)%len(fakeSnippetWords)], i),
+		}
+	}
+	return results, nil
+}
+
+func (s fakeSource) Name() string    { return s.name }
+func (s fakeSource) Available() bool { return true }
+
+func TestVerifyClaimsCapsMergedEvidence(t *testing.T) {
+	ctx := context.Background()
+	provider := llm.NewMockProvider(`{"verification_status":"verified","confidence_score":1.0,"reasoning":"Known."}`)
+	e, _ := newTestEngine(t, provider)
+	e.searchClient = search.NewAggregatedSearchClient(fakeSource{"alpha"}, fakeSource{"beta"}, fakeSource{"gamma"})
+	e.airGapped = false
+
+	claims, _ := e.verifyClaims(ctx, []models.Claim{{ID: "a", Text: "Inflation was 4% in 2023", Type: models.ClaimTypeStatistical}}, VerifyOptions{})
+	if got := len(claims[0].Evidences); got != defaultMaxEvidence {
+		t.Errorf("claim has %d pieces of evidence, want %d", got, defaultMaxEvidence)
+	}
+}
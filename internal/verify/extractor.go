@@ -11,48 +11,195 @@ import (
 	"github.com/factchecker/verity/internal/config"
 	"github.com/factchecker/verity/internal/llm"
 	"github.com/factchecker/verity/internal/models"
+	"github.com/factchecker/verity/internal/search"
 	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
 )
 
 // ClaimExtractor extracts atomic factual claims from text.
 type ClaimExtractor struct {
 	provider         llm.Provider
 	customClaimTypes map[string]config.ClaimTypeConfig
+	chunker          *Chunker
+	contextWindow    int
+	minExtractable   float64
+	skipPatterns     []*regexp.Regexp
+	settings         *RuntimeSettings // optional; overrides minExtractable
 }
 
-// NewClaimExtractor creates a new claim extractor.
-func NewClaimExtractor(provider llm.Provider, customTypes map[string]config.ClaimTypeConfig) *ClaimExtractor {
-	return &ClaimExtractor{
+// NewClaimExtractor creates a new claim extractor. Long texts are split into
+// chunks using the configured strategy (see Chunker) and extracted
+// separately; chunks that would still overflow the model's context window
+// are split further. Claims scored below Verify.MinExtractabilityScore are
+// dropped.
+func NewClaimExtractor(provider llm.Provider, cfg *config.Config) *ClaimExtractor {
+	e := &ClaimExtractor{
 		provider:         provider,
-		customClaimTypes: customTypes,
+		customClaimTypes: cfg.CustomClaimTypes,
+		contextWindow:    llm.ContextWindow(&cfg.LLM),
+		minExtractable:   cfg.Verify.MinExtractabilityScore,
 	}
+	for _, p := range cfg.Verify.SkipPatterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			log.Warn().Err(err).Str("pattern", p).Msg("Ignoring invalid claim skip pattern")
+			continue
+		}
+		e.skipPatterns = append(e.skipPatterns, re)
+	}
+	e.chunker = NewChunker(cfg.LLM.ChunkStrategy, cfg.LLM.MaxChunkChars, e.buildSystemPrompt())
+	return e
+}
+
+// MaxHintChars caps the combined length of focus and ignore hints, which are
+// user input embedded in the system prompt.
+const MaxHintChars = 500
+
+// ExtractOptions steers claim extraction towards or away from topics.
+type ExtractOptions struct {
+	FocusHints  []string
+	IgnoreHints []string
+
+	abstract bool // set by AbstractClaimExtractor
+}
+
+// ValidateHints checks that hints fit within MaxHintChars.
+func ValidateHints(focus, ignore []string) error {
+	n := 0
+	for _, h := range append(append([]string(nil), focus...), ignore...) {
+		n += len(h)
+	}
+	if n > MaxHintChars {
+		return fmt.Errorf("focus and ignore hints exceed %d characters", MaxHintChars)
+	}
+	return nil
+}
+
+// formatInstructions renders instructions specific to the document format.
+func (o ExtractOptions) formatInstructions() string {
+	if o.abstract {
+		return "\n\nThis text is a scientific abstract. Focus on extracting quantitative results (effect sizes, p-values, sample sizes) from the Results section as statistical claims, and conclusions as factual claims. Ignore the Methods section."
+	}
+	return ""
+}
+
+// hintInstructions renders extraction hints as additional system prompt lines.
+func (o ExtractOptions) hintInstructions() string {
+	var sb strings.Builder
+	if len(o.FocusHints) > 0 {
+		sb.WriteString("\n\nPrioritize extracting claims related to: ")
+		sb.WriteString(strings.Join(o.FocusHints, ", "))
+		sb.WriteString(". Deprioritize general descriptive sentences.")
+	}
+	if len(o.IgnoreHints) > 0 {
+		sb.WriteString("\n\nDo not extract claims related to: ")
+		sb.WriteString(strings.Join(o.IgnoreHints, ", "))
+		sb.WriteString(".")
+	}
+	return sb.String()
 }
 
 type extractedClaim struct {
-	Text          string `json:"text"`
-	Type          string `json:"type"`
-	SentenceIndex int    `json:"sentence_index"`
+	Text                string   `json:"text"`
+	Type                string   `json:"type"`
+	SentenceIndex       int      `json:"sentence_index"`
+	ExtractabilityScore *float64 `json:"extractability_score"`
+	IsOpinion           bool     `json:"is_opinion"`
+	Language            string   `json:"language"`
 }
 
 type extractionResult struct {
 	Claims []extractedClaim `json:"claims"`
 }
 
-// Extract extracts atomic factual claims from text.
-func (e *ClaimExtractor) Extract(ctx context.Context, text string) ([]models.Claim, error) {
-	systemPrompt := e.buildSystemPrompt()
+// Extract extracts atomic factual claims from text. Each chunk is extracted
+// in turn and sentence indexes are offset to refer to the whole text.
+func (e *ClaimExtractor) Extract(ctx context.Context, text string, opts ExtractOptions) ([]models.Claim, error) {
+	var claims []models.Claim
+	var err error
+	chunks := e.chunker.Split(text)
+	if len(chunks) <= 1 {
+		claims, err = e.extractChunk(ctx, text, opts)
+	} else {
+		claims, err = e.extractChunks(ctx, chunks, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e.filterExtractable(ctx, claims), nil
+}
+
+// filterExtractable drops claims the model judged not verifiable enough.
+func (e *ClaimExtractor) filterExtractable(ctx context.Context, claims []models.Claim) []models.Claim {
+	threshold := e.minExtractable
+	if e.settings != nil {
+		threshold = e.settings.Float(ctx, SettingMinExtractability, threshold)
+	}
+	kept := claims[:0]
+	for _, c := range claims {
+		if c.ExtractabilityScore < threshold {
+			log.Debug().
+				Str("claim", c.Text).
+				Float64("extractability_score", c.ExtractabilityScore).
+				Bool("is_opinion", c.IsOpinion).
+				Msg("Dropping claim below extractability threshold")
+			continue
+		}
+		kept = append(kept, c)
+	}
+	return kept
+}
+
+// extractChunks extracts each chunk in turn, offsetting sentence indexes so
+// they refer to the chunks' combined text.
+func (e *ClaimExtractor) extractChunks(ctx context.Context, chunks []string, opts ExtractOptions) ([]models.Claim, error) {
+	var claims []models.Claim
+	sentenceOffset := 0
+	for _, chunk := range chunks {
+		chunkClaims, err := e.extractChunk(ctx, chunk, opts)
+		if err != nil {
+			return nil, err
+		}
+		for i := range chunkClaims {
+			chunkClaims[i].SentenceIndex += sentenceOffset
+		}
+		claims = append(claims, chunkClaims...)
+		sentenceOffset += len(splitSentences(chunk))
+	}
+	return claims, nil
+}
+
+func (e *ClaimExtractor) extractChunk(ctx context.Context, text string, hints ExtractOptions) ([]models.Claim, error) {
+	systemPrompt := e.buildSystemPrompt() + hints.formatInstructions() + hints.hintInstructions()
 	userPrompt := fmt.Sprintf("Text to analyze:\n\n%s", text)
 
 	opts := llm.DefaultCompletionOptions()
 	opts.MaxTokens = 4096
 
+	// Re-chunk rather than send a request the model cannot accept
+	if e.contextWindow > 0 {
+		budget := promptBudgetTokens(e.contextWindow, opts.MaxTokens)
+		if tokens := estimatePromptTokens(systemPrompt, userPrompt); tokens > budget {
+			chunks := NewChunker(ChunkTokenCount, budget*4, systemPrompt).Split(text)
+			if len(chunks) > 1 {
+				log.Warn().
+					Int("estimated_tokens", tokens).
+					Int("context_window", e.contextWindow).
+					Int("chunks", len(chunks)).
+					Msg("Extraction prompt exceeds context window, re-chunking input")
+				return e.extractChunks(ctx, chunks, hints)
+			}
+		}
+	}
+
 	response, err := e.provider.CompleteWithSystem(ctx, systemPrompt, userPrompt, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract claims: %w", err)
 	}
+	chargeTokens(ctx, systemPrompt, userPrompt, response)
 
 	// Parse JSON response
-	claims, err := e.parseResponse(response)
+	claims, err := e.parseResponse(response, text)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse extraction response: %w", err)
 	}
@@ -77,6 +224,8 @@ Your task:
 3. Classify each claim by type
 4. Preserve the original meaning and context
 5. Number each claim by its position in the original text (0-indexed)
+6. Score how verifiable each claim is (extractability_score, 0.0-1.0) and flag opinions
+7. Identify the language of each claim as an ISO 639-1 code (language)
 
 Claim types:
 - statistical: Claims involving numbers, percentages, quantities
@@ -87,24 +236,30 @@ Claim types:
 - comparative: Claims comparing entities (X is larger/better than Y)
 - causal: Claims about cause and effect relationships%s
 
+extractability_score: 1.0 for a concrete, falsifiable statement; 0.0 for an
+opinion or a statement that cannot be checked against evidence.
+
 Rules:
 - Ignore opinions, questions, and subjective statements
 - Focus only on objective, verifiable facts
 - Each claim must be a complete, standalone statement
 - Do not merge multiple facts into one claim
+- Each claim should be 5-25 words long
 
 Respond with a JSON object containing an array of claims:
 {
   "claims": [
-    {"text": "The claim text", "type": "statistical", "sentence_index": 0},
-    {"text": "Another claim", "type": "factual", "sentence_index": 1}
+    {"text": "The claim text", "type": "statistical", "sentence_index": 0, "extractability_score": 0.9, "is_opinion": false, "language": "en"},
+    {"text": "Another claim", "type": "factual", "sentence_index": 1, "extractability_score": 0.6, "is_opinion": false, "language": "en"}
   ]
 }
 
 Only respond with the JSON object, no other text.`, customTypesDesc)
 }
 
-func (e *ClaimExtractor) parseResponse(response string) ([]models.Claim, error) {
+// parseResponse converts the model's JSON into claims, attaching the
+// sentence of text each claim was drawn from.
+func (e *ClaimExtractor) parseResponse(response, text string) ([]models.Claim, error) {
 	// Try to extract JSON from the response
 	response = strings.TrimSpace(response)
 
@@ -132,16 +287,111 @@ func (e *ClaimExtractor) parseResponse(response string) ([]models.Claim, error)
 		}
 	}
 
-	claims := make([]models.Claim, len(result.Claims))
-	for i, ec := range result.Claims {
-		claims[i] = models.Claim{
+	sentences := splitSentences(text)
+	claims := make([]models.Claim, 0, len(result.Claims))
+	for _, ec := range result.Claims {
+		if pattern := e.matchSkipPattern(ec.Text); pattern != "" {
+			log.Debug().Str("claim", ec.Text).Str("pattern", pattern).Msg("Skipping claim matching skip pattern")
+			continue
+		}
+
+		claim := models.Claim{
 			ID:            uuid.New().String(),
 			Text:          ec.Text,
 			Type:          models.ClaimType(ec.Type),
 			SentenceIndex: ec.SentenceIndex,
 			Status:        models.StatusPending,
+			IsOpinion:     ec.IsOpinion,
+		}
+		// Models that omit the score are not penalized
+		claim.ExtractabilityScore = 1
+		if ec.ExtractabilityScore != nil {
+			claim.ExtractabilityScore = *ec.ExtractabilityScore
+		}
+		if ec.SentenceIndex >= 0 && ec.SentenceIndex < len(sentences) {
+			claim.OriginalSentence = sentences[ec.SentenceIndex]
+		}
+		// Fall back to detecting the language when the model omits it
+		claim.DetectedLanguage = search.NormalizeLanguage(ec.Language)
+		if claim.DetectedLanguage == "" {
+			claim.DetectedLanguage = search.DetectLanguage(ec.Text)
 		}
+		claims = append(claims, claim)
+	}
+
+	return claims, nil
+}
+
+// matchSkipPattern returns the first skip pattern matching text, or "".
+func (e *ClaimExtractor) matchSkipPattern(text string) string {
+	for _, re := range e.skipPatterns {
+		if re.MatchString(text) {
+			return re.String()
+		}
+	}
+	return ""
+}
+
+// FormatAbstract marks a document as a structured scientific abstract.
+const FormatAbstract = "abstract"
+
+// abstractHeading matches a section heading of a structured abstract at the
+// start of a line, such as "Results:" or "METHODS".
+var abstractHeading = regexp.MustCompile(`(?im)^[ \t]*(background|introduction|objectives?|aims?|purpose|context|methods?|materials and methods|design|setting|participants|interventions?|measurements|results?|findings|conclusions?|discussion|interpretation)[ \t]*(:|$)`)
+
+// AbstractClaimExtractor extracts claims from scientific abstracts. It asks
+// for quantitative results and conclusions rather than methodology, and sets
+// each claim's SentenceIndex to the position of its section (0 for the
+// first) instead of its sentence number.
+type AbstractClaimExtractor struct {
+	*ClaimExtractor
+}
+
+// NewAbstractClaimExtractor creates an abstract extractor around e.
+func NewAbstractClaimExtractor(e *ClaimExtractor) *AbstractClaimExtractor {
+	return &AbstractClaimExtractor{ClaimExtractor: e}
+}
+
+// Extract extracts claims from an abstract. Texts without recognizable
+// section headings keep sentence numbers as SentenceIndex.
+func (a *AbstractClaimExtractor) Extract(ctx context.Context, text string, opts ExtractOptions) ([]models.Claim, error) {
+	opts.abstract = true
+	claims, err := a.ClaimExtractor.Extract(ctx, text, opts)
+	if err != nil {
+		return nil, err
 	}
 
+	sectionStarts := abstractSectionStarts(text)
+	if len(sectionStarts) == 0 {
+		return claims, nil
+	}
+	for i := range claims {
+		section := 0
+		for j, start := range sectionStarts {
+			if claims[i].SentenceIndex >= start {
+				section = j
+			}
+		}
+		claims[i].SentenceIndex = section
+	}
 	return claims, nil
 }
+
+// abstractSectionStarts returns the index of the first sentence of each
+// section of an abstract, in order. Text before the first heading, such as
+// a title, counts as a section of its own. It returns nil when the text has
+// no section headings.
+func abstractSectionStarts(text string) []int {
+	locs := abstractHeading.FindAllStringIndex(text, -1)
+	if len(locs) == 0 {
+		return nil
+	}
+	var starts []int
+	if strings.TrimSpace(text[:locs[0][0]]) != "" {
+		starts = append(starts, 0)
+	}
+	for _, loc := range locs {
+		starts = append(starts, len(splitSentences(text[:loc[0]])))
+	}
+	return starts
+}
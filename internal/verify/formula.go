@@ -0,0 +1,174 @@
+// Package verify provides a small expression language for evidence ranking.
+package verify
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DefaultRankingFormula is used when no formula is configured or the
+// configured one is invalid.
+const DefaultRankingFormula = "0.6*relevance + 0.4*freshness"
+
+// rankingVariables are the names a ranking formula may reference.
+var rankingVariables = map[string]bool{
+	"relevance":    true,
+	"freshness":    true,
+	"domain_score": true,
+}
+
+// Formula is a parsed ranking expression supporting numbers, the variables
+// relevance, freshness and domain_score, +, * and parentheses. It is parsed
+// once and safe for concurrent evaluation.
+type Formula struct {
+	src  string
+	root formulaNode
+}
+
+type formulaNode interface {
+	eval(vars map[string]float64) float64
+}
+
+type numberNode float64
+
+func (n numberNode) eval(map[string]float64) float64 { return float64(n) }
+
+type variableNode string
+
+func (n variableNode) eval(vars map[string]float64) float64 { return vars[string(n)] }
+
+type binaryNode struct {
+	op          byte
+	left, right formulaNode
+}
+
+func (n binaryNode) eval(vars map[string]float64) float64 {
+	l, r := n.left.eval(vars), n.right.eval(vars)
+	if n.op == '*' {
+		return l * r
+	}
+	return l + r
+}
+
+// ParseFormula parses and validates a ranking expression.
+func ParseFormula(src string) (*Formula, error) {
+	p := &formulaParser{src: src}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.src) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.src[p.pos], p.pos)
+	}
+	return &Formula{src: src, root: root}, nil
+}
+
+// Eval evaluates the formula with the given variable values.
+func (f *Formula) Eval(vars map[string]float64) float64 {
+	return f.root.eval(vars)
+}
+
+// String returns the source expression.
+func (f *Formula) String() string {
+	return f.src
+}
+
+// formulaParser is a recursive descent parser for:
+//
+//	expr   = term { "+" term }
+//	term   = factor { "*" factor }
+//	factor = number | variable | "(" expr ")"
+type formulaParser struct {
+	src string
+	pos int
+}
+
+func (p *formulaParser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *formulaParser) peek() byte {
+	p.skipSpace()
+	if p.pos >= len(p.src) {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *formulaParser) parseExpr() (formulaNode, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == '+' {
+		p.pos++
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: '+', left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *formulaParser) parseTerm() (formulaNode, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == '*' {
+		p.pos++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = binaryNode{op: '*', left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *formulaParser) parseFactor() (formulaNode, error) {
+	c := p.peek()
+	switch {
+	case c == 0:
+		return nil, fmt.Errorf("unexpected end of formula")
+	case c == '(':
+		p.pos++
+		node, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, fmt.Errorf("missing ')' at position %d", p.pos)
+		}
+		p.pos++
+		return node, nil
+	case c == '.' || (c >= '0' && c <= '9'):
+		start := p.pos
+		for p.pos < len(p.src) && (p.src[p.pos] == '.' || (p.src[p.pos] >= '0' && p.src[p.pos] <= '9')) {
+			p.pos++
+		}
+		v, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", p.src[start:p.pos])
+		}
+		return numberNode(v), nil
+	case c == '_' || unicode.IsLetter(rune(c)):
+		start := p.pos
+		for p.pos < len(p.src) && (p.src[p.pos] == '_' || unicode.IsLetter(rune(p.src[p.pos])) || unicode.IsDigit(rune(p.src[p.pos]))) {
+			p.pos++
+		}
+		name := strings.ToLower(p.src[start:p.pos])
+		if !rankingVariables[name] {
+			return nil, fmt.Errorf("unknown variable %q", name)
+		}
+		return variableNode(name), nil
+	default:
+		return nil, fmt.Errorf("unexpected %q at position %d", c, p.pos)
+	}
+}
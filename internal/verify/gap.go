@@ -0,0 +1,90 @@
+// Package verify provides evidence gap detection for inconclusive claims.
+package verify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/models"
+)
+
+// gapConfidenceThreshold is the confidence below which a mixed verdict is
+// considered inconclusive and evidence gaps are looked for.
+const gapConfidenceThreshold = 0.5
+
+// maxSuggestedSearches bounds the search queries kept per claim.
+const maxSuggestedSearches = 3
+
+// EvidenceGapDetector suggests searches that could settle claims the
+// gathered evidence left inconclusive, for a human reviewer to run.
+type EvidenceGapDetector struct {
+	provider llm.Provider
+}
+
+// NewEvidenceGapDetector creates a new evidence gap detector.
+func NewEvidenceGapDetector(provider llm.Provider) *EvidenceGapDetector {
+	return &EvidenceGapDetector{provider: provider}
+}
+
+// inconclusive reports whether a verdict is mixed with low confidence, the
+// usual sign that the evidence missed the angle needed to decide the claim.
+func inconclusive(verdict Verdict) bool {
+	return verdict.Status == models.StatusMixed && verdict.Confidence < gapConfidenceThreshold
+}
+
+// DetectGap asks the LLM which evidence is missing to resolve claim and
+// returns up to three specific search queries.
+func (d *EvidenceGapDetector) DetectGap(ctx context.Context, claim models.Claim, evidences []models.Evidence) ([]string, error) {
+	systemPrompt := `You are a fact-checking researcher. The evidence gathered for a claim was inconclusive.
+
+What additional types of evidence would help resolve this claim? List 3 specific search queries
+a reviewer could run to find it. Each query should target a concrete source, statistic or record
+that the existing evidence does not cover.
+
+Respond with a JSON object:
+{
+  "queries": ["first query", "second query", "third query"]
+}
+
+Only respond with the JSON object, no other text.`
+
+	var evidenceText strings.Builder
+	for i, e := range evidences {
+		evidenceText.WriteString(fmt.Sprintf("\n%d. %s: %s", i+1, e.SourceName, e.Snippet))
+	}
+	if len(evidences) == 0 {
+		evidenceText.WriteString(" none")
+	}
+	userPrompt := fmt.Sprintf("Claim: %s\n\nEvidence found:%s", claim.Text, evidenceText.String())
+
+	opts := llm.DefaultCompletionOptions()
+	opts.MaxTokens = 512
+
+	response, err := d.provider.CompleteWithSystem(ctx, systemPrompt, userPrompt, opts)
+	if err != nil {
+		return nil, fmt.Errorf("evidence gap detection failed: %w", err)
+	}
+	chargeTokens(ctx, systemPrompt, userPrompt, response)
+
+	jsonText, err := extractJSONObject(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse evidence gap response: %w", err)
+	}
+	var result struct {
+		Queries []string `json:"queries"`
+	}
+	if err := json.Unmarshal([]byte(jsonText), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse evidence gap response: %w", err)
+	}
+
+	var queries []string
+	for _, q := range result.Queries {
+		if q = strings.TrimSpace(q); q != "" && len(queries) < maxSuggestedSearches {
+			queries = append(queries, q)
+		}
+	}
+	return queries, nil
+}
@@ -0,0 +1,75 @@
+// Package verify provides highlighting of claim sentences in the original document.
+package verify
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"github.com/factchecker/verity/internal/models"
+)
+
+// HighlightResult is an HTML rendering of a document with claim sentences marked.
+type HighlightResult struct {
+	HTML      string   `json:"html"`
+	Matched   int      `json:"matched"`
+	Unmatched []string `json:"unmatched,omitempty"` // IDs of claims whose sentence was not found
+}
+
+type highlightSpan struct {
+	start, end int
+	claim      models.Claim
+}
+
+// HighlightHTML returns the HTML-escaped text with the original sentence of
+// each claim wrapped in <span class="claim claim-{status}"
+// data-claim-id="{id}">. Sentences are located by their first occurrence in
+// the text; a claim whose sentence overlaps one already highlighted, such as
+// a second claim from the same sentence, is left to the first claim.
+func HighlightHTML(text string, claims []models.Claim) HighlightResult {
+	var result HighlightResult
+	var spans []highlightSpan
+
+	for _, c := range claims {
+		sentence := strings.TrimSpace(c.OriginalSentence)
+		start := -1
+		if sentence != "" {
+			start = strings.Index(text, sentence)
+		}
+		if start < 0 {
+			result.Unmatched = append(result.Unmatched, c.ID)
+			continue
+		}
+		span := highlightSpan{start: start, end: start + len(sentence), claim: c}
+
+		overlaps := false
+		for _, s := range spans {
+			if span.start < s.end && s.start < span.end {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			spans = append(spans, span)
+		}
+		result.Matched++
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+
+	var b strings.Builder
+	pos := 0
+	for _, s := range spans {
+		b.WriteString(html.EscapeString(text[pos:s.start]))
+		fmt.Fprintf(&b, `<span class="claim claim-%s" data-claim-id="%s">`,
+			html.EscapeString(string(s.claim.Status)), html.EscapeString(s.claim.ID))
+		b.WriteString(html.EscapeString(text[s.start:s.end]))
+		b.WriteString("</span>")
+		pos = s.end
+	}
+	b.WriteString(html.EscapeString(text[pos:]))
+
+	result.HTML = b.String()
+	return result
+}
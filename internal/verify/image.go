@@ -0,0 +1,72 @@
+// Package verify provides text extraction from images for image-based claims.
+package verify
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/models"
+)
+
+// maxImageBytes is the largest decoded image accepted, matching the OpenAI
+// vision limit.
+const maxImageBytes = 20 << 20
+
+const imageTextPrompt = "Describe all text and data visible in this image. Transcribe text verbatim and state the values shown in any charts or tables. Do not add commentary."
+
+// ImageURL returns the image of a verify request as a URL the LLM provider
+// can read: ImageURL as given, or ImageBase64 as a data URL. It returns ""
+// when the request has no image.
+func ImageURL(req *models.VerifyRequest) (string, error) {
+	switch {
+	case req.ImageBase64 != "" && req.ImageURL != "":
+		return "", fmt.Errorf("only one of image_base64 and image_url may be set")
+	case req.ImageURL != "":
+		u, err := url.Parse(req.ImageURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", fmt.Errorf("image_url must be an http or https URL")
+		}
+		return req.ImageURL, nil
+	case req.ImageBase64 != "":
+		data, err := base64.StdEncoding.DecodeString(req.ImageBase64)
+		if err != nil {
+			return "", fmt.Errorf("image_base64 is not valid base64")
+		}
+		if len(data) > maxImageBytes {
+			return "", fmt.Errorf("image exceeds %d MB", maxImageBytes>>20)
+		}
+		contentType := http.DetectContentType(data)
+		if !strings.HasPrefix(contentType, "image/") {
+			return "", fmt.Errorf("image_base64 is not an image")
+		}
+		return "data:" + contentType + ";base64," + req.ImageBase64, nil
+	}
+	return "", nil
+}
+
+// SupportsImages reports whether the LLM provider can read images.
+func (e *Engine) SupportsImages() bool {
+	_, ok := e.provider.(llm.VisionProvider)
+	return ok && e.provider.SupportsVision()
+}
+
+// ExtractImageText transcribes the text and data visible in an image, such
+// as a screenshot of a post or a chart, so claims can be extracted from it.
+func (e *Engine) ExtractImageText(ctx context.Context, imageURL string) (string, error) {
+	vision, ok := e.provider.(llm.VisionProvider)
+	if !ok || !e.provider.SupportsVision() {
+		return "", fmt.Errorf("LLM provider %s does not support images", e.provider.Name())
+	}
+
+	opts := llm.DefaultCompletionOptions()
+	text, err := vision.CompleteWithImage(ctx, "", imageTextPrompt, imageURL, opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to extract image text: %w", err)
+	}
+	return strings.TrimSpace(text), nil
+}
@@ -0,0 +1,49 @@
+// Package verify provides confidence intervals for analysis scores.
+package verify
+
+import (
+	"math"
+
+	"github.com/factchecker/verity/internal/models"
+)
+
+// scoreIntervalZ is the normal quantile for a 95% confidence interval.
+const scoreIntervalZ = 1.96
+
+// scoreInterval computes a Wilson score interval around the overall score
+// of claims. Each claim is a Bernoulli trial (verified=1, mixed=0.5,
+// unsupported=0) that counts as confidence of a full trial, so an analysis
+// of a few low-confidence verdicts gets a wide interval. With no confident
+// verdicts at all the interval spans the whole 0-10 range.
+func scoreInterval(claims []models.Claim) models.ScoreConfidenceInterval {
+	var successes, n float64
+	for _, c := range claims {
+		switch c.Status {
+		case models.StatusVerified:
+			successes++
+		case models.StatusMixed:
+			successes += 0.5
+		}
+		n += c.Confidence
+	}
+	if len(claims) == 0 {
+		return wilsonInterval(0, 0)
+	}
+	return wilsonInterval(successes/float64(len(claims)), n)
+}
+
+// wilsonInterval returns the Wilson score interval for proportion p over n
+// trials, scaled to 0-10.
+func wilsonInterval(p, n float64) models.ScoreConfidenceInterval {
+	if n <= 0 {
+		return models.ScoreConfidenceInterval{Lower: 0, Upper: 10}
+	}
+	z2 := scoreIntervalZ * scoreIntervalZ
+	denom := 1 + z2/n
+	center := (p + z2/(2*n)) / denom
+	half := scoreIntervalZ / denom * math.Sqrt(p*(1-p)/n+z2/(4*n*n))
+	return models.ScoreConfidenceInterval{
+		Lower: math.Max(0, center-half) * 10,
+		Upper: math.Min(1, center+half) * 10,
+	}
+}
@@ -0,0 +1,74 @@
+package verify
+
+import (
+	"math"
+	"testing"
+
+	"github.com/factchecker/verity/internal/models"
+)
+
+func claimsWith(confidence float64, statuses ...models.VerificationStatus) []models.Claim {
+	claims := make([]models.Claim, len(statuses))
+	for i, s := range statuses {
+		claims[i] = models.Claim{Status: s, Confidence: confidence}
+	}
+	return claims
+}
+
+func TestScoreInterval(t *testing.T) {
+	verified, mixed, unsupported := models.StatusVerified, models.StatusMixed, models.StatusUnsupported
+
+	tests := []struct {
+		name   string
+		claims []models.Claim
+		want   models.ScoreConfidenceInterval
+	}{
+		{
+			name: "no claims",
+			want: models.ScoreConfidenceInterval{Lower: 0, Upper: 10},
+		},
+		{
+			name:   "no confident verdicts",
+			claims: claimsWith(0, verified, verified),
+			want:   models.ScoreConfidenceInterval{Lower: 0, Upper: 10},
+		},
+		{
+			name:   "all verified",
+			claims: claimsWith(1, verified, verified, verified),
+			want:   models.ScoreConfidenceInterval{Lower: 4.385, Upper: 10},
+		},
+		{
+			name:   "all unsupported",
+			claims: claimsWith(1, unsupported, unsupported, unsupported),
+			want:   models.ScoreConfidenceInterval{Lower: 0, Upper: 5.615},
+		},
+		{
+			name: "mixed with varying confidences",
+			claims: []models.Claim{
+				{Status: verified, Confidence: 0.9},
+				{Status: mixed, Confidence: 0.6},
+				{Status: unsupported, Confidence: 0.3},
+				{Status: verified, Confidence: 0.8},
+			},
+			want: models.ScoreConfidenceInterval{Lower: 1.692, Upper: 9.317},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scoreInterval(tt.claims)
+			if math.Abs(got.Lower-tt.want.Lower) > 0.001 || math.Abs(got.Upper-tt.want.Upper) > 0.001 {
+				t.Errorf("scoreInterval() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreIntervalNarrowsWithConfidence(t *testing.T) {
+	statuses := []models.VerificationStatus{models.StatusVerified, models.StatusMixed, models.StatusUnsupported}
+	low := scoreInterval(claimsWith(0.2, statuses...))
+	high := scoreInterval(claimsWith(1, statuses...))
+	if high.Upper-high.Lower >= low.Upper-low.Lower {
+		t.Errorf("interval at full confidence %+v is not narrower than at low confidence %+v", high, low)
+	}
+}
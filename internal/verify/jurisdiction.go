@@ -0,0 +1,46 @@
+// Package verify provides jurisdiction scoping of evidence.
+package verify
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/factchecker/verity/internal/models"
+)
+
+// MaxJurisdictionLength bounds the jurisdiction of a request, which is
+// included in search queries and prompts.
+const MaxJurisdictionLength = 64
+
+// filterByJurisdiction keeps the evidence that concerns jurisdiction: its
+// source name or snippet mentions it as a word, or, for two-letter country
+// codes, its URL is on that country's top-level domain. It is used for
+// geographic claims, whose truth depends on the place; with no jurisdiction
+// all evidence is kept.
+func filterByJurisdiction(evidences []models.Evidence, jurisdiction string) []models.Evidence {
+	if jurisdiction == "" {
+		return evidences
+	}
+	mention := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(jurisdiction) + `\b`)
+	if len(jurisdiction) == 2 {
+		// Two-letter codes such as "US" also spell common words; only
+		// match them in capitals
+		mention = regexp.MustCompile(`\b` + regexp.QuoteMeta(strings.ToUpper(jurisdiction)) + `\b`)
+	}
+	tld := "." + strings.ToLower(jurisdiction)
+
+	var kept []models.Evidence
+	for _, e := range evidences {
+		onTLD := false
+		if len(jurisdiction) == 2 {
+			if u, err := url.Parse(e.SourceURL); err == nil {
+				onTLD = strings.HasSuffix(strings.ToLower(u.Hostname()), tld)
+			}
+		}
+		if onTLD || mention.MatchString(e.SourceName) || mention.MatchString(e.Snippet) {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
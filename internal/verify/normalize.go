@@ -0,0 +1,31 @@
+// Package verify provides document normalization before hashing.
+package verify
+
+import (
+	"regexp"
+	"strings"
+)
+
+// excessBlankLines matches three or more consecutive blank lines.
+var excessBlankLines = regexp.MustCompile(`\n{4,}`)
+
+// NormalizeDocument removes differences that do not change a document's
+// content, so that copies differing only in encoding details share a hash
+// and hit the analysis cache. It strips byte order marks, converts CRLF and
+// CR line endings to LF, trims trailing whitespace from each line and from
+// the document as a whole, and collapses runs of three or more blank lines
+// to two.
+func NormalizeDocument(text string) string {
+	text = strings.ReplaceAll(text, "\uFEFF", "")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\f\v")
+	}
+	text = strings.Join(lines, "\n")
+
+	text = excessBlankLines.ReplaceAllString(text, "\n\n\n")
+	return strings.TrimSpace(text)
+}
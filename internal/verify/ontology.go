@@ -0,0 +1,75 @@
+// Package verify provides claim sub-type classification from a keyword ontology.
+package verify
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/factchecker/verity/internal/models"
+)
+
+// OntologyExpander refines a claim's type into a more specific sub-type by
+// matching its text against keywords configured for each sub-type.
+type OntologyExpander struct {
+	// parent type -> sub-types, in name order for deterministic tie-breaking
+	categories map[string][]ontologySubType
+}
+
+type ontologySubType struct {
+	name     string
+	keywords []*regexp.Regexp
+}
+
+// NewOntologyExpander builds an expander from parent type -> sub-type ->
+// keywords. Keywords match case-insensitively on word boundaries.
+func NewOntologyExpander(categories map[string]map[string][]string) *OntologyExpander {
+	o := &OntologyExpander{categories: make(map[string][]ontologySubType)}
+	for parent, subTypes := range categories {
+		names := make([]string, 0, len(subTypes))
+		for name := range subTypes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			st := ontologySubType{name: name}
+			for _, kw := range subTypes[name] {
+				kw = strings.TrimSpace(kw)
+				if kw == "" {
+					continue
+				}
+				st.keywords = append(st.keywords, regexp.MustCompile(`(?i)\b`+regexp.QuoteMeta(kw)+`\b`))
+			}
+			o.categories[strings.ToLower(parent)] = append(o.categories[strings.ToLower(parent)], st)
+		}
+	}
+	return o
+}
+
+// SubType returns the sub-type of a claim whose keywords match its text most
+// often, or "" when none match.
+func (o *OntologyExpander) SubType(claim models.Claim) string {
+	best, bestHits := "", 0
+	for _, st := range o.categories[strings.ToLower(string(claim.Type))] {
+		hits := 0
+		for _, kw := range st.keywords {
+			if kw.MatchString(claim.Text) {
+				hits++
+			}
+		}
+		if hits > bestHits {
+			best, bestHits = st.name, hits
+		}
+	}
+	return best
+}
+
+// Expand sets SubType on each claim that matches the ontology.
+func (o *OntologyExpander) Expand(claims []models.Claim) {
+	for i := range claims {
+		if sub := o.SubType(claims[i]); sub != "" {
+			claims[i].SubType = sub
+		}
+	}
+}
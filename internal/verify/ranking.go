@@ -0,0 +1,131 @@
+// Package verify provides evidence ranking.
+package verify
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/factchecker/verity/internal/search"
+	"github.com/rs/zerolog/log"
+)
+
+// domainScores rates source types by authority.
+var domainScores = map[string]float64{
+	"academic":      1.0,
+	"curated":       1.0,
+	"preprint":      0.8, // not yet peer reviewed
+	"encyclopedia":  0.8,
+	"web_page":      0.5,
+	"news":          0.5,
+	"search_engine": 0.4,
+}
+
+// reliabilityLookupTimeout bounds a single publisher reliability lookup so
+// that a slow lookup does not hold up ranking.
+const reliabilityLookupTimeout = 3 * time.Second
+
+// DomainScorer rates evidence sources by authority for the domain_score
+// ranking input.
+type DomainScorer struct {
+	reliability *search.WikidataReliabilityLookup // Optional
+}
+
+// NewDomainScorer creates a domain scorer. When reliability is set, web
+// pages and news articles are rated by their publisher's Wikidata standing
+// instead of a flat score.
+func NewDomainScorer(reliability *search.WikidataReliabilityLookup) *DomainScorer {
+	return &DomainScorer{reliability: reliability}
+}
+
+// Score returns the domain score of a piece of evidence. Sources whose type
+// already determines their authority, such as academic or curated sources,
+// keep their type score.
+func (s *DomainScorer) Score(ctx context.Context, e models.Evidence) float64 {
+	score := domainScores[e.SourceType]
+	if s == nil || s.reliability == nil || e.SourceType != "web_page" && e.SourceType != "news" {
+		return score
+	}
+
+	u, err := url.Parse(e.SourceURL)
+	if err != nil || u.Hostname() == "" {
+		return score
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, reliabilityLookupTimeout)
+	defer cancel()
+	reliability, err := s.reliability.Reliability(ctx, u.Hostname())
+	if err != nil {
+		log.Debug().Err(err).Str("domain", u.Hostname()).Msg("Publisher reliability lookup failed")
+		return score
+	}
+	return reliability
+}
+
+// rankEvidence scores each piece of evidence with the ranking formula and
+// sorts them best first. Relevance falls back to keyword overlap with the
+// claim when the source did not provide a score.
+func rankEvidence(ctx context.Context, formula *Formula, domains *DomainScorer, claimText string, evidences []models.Evidence) {
+	terms := significantWords(claimText)
+	for i := range evidences {
+		e := &evidences[i]
+		relevance := e.RelevanceScore
+		if relevance == 0 {
+			relevance = keywordOverlap(terms, e.Snippet)
+		}
+		e.TrustScore = formula.Eval(map[string]float64{
+			"relevance":    relevance,
+			"freshness":    freshness(e.PublishedAt),
+			"domain_score": domains.Score(ctx, *e),
+		})
+	}
+	sort.SliceStable(evidences, func(i, j int) bool {
+		return evidences[i].TrustScore > evidences[j].TrustScore
+	})
+}
+
+// neutralFreshness is the freshness of evidence whose source reports no
+// publication date, so undated evidence is neither favoured nor penalised.
+const neutralFreshness = 0.5
+
+// freshness decays from 1 towards 0 over roughly a year from publication.
+func freshness(publishedAt *time.Time) float64 {
+	if publishedAt == nil || publishedAt.IsZero() {
+		return neutralFreshness
+	}
+	ageDays := time.Since(*publishedAt).Hours() / 24
+	if ageDays < 0 {
+		ageDays = 0
+	}
+	return 1 / (1 + ageDays/365)
+}
+
+// significantWords returns the lowercased words of text longer than three letters.
+func significantWords(text string) []string {
+	var words []string
+	for _, w := range strings.Fields(strings.ToLower(text)) {
+		w = strings.Trim(w, ".,;:!?\"'()[]")
+		if len(w) > 3 {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// keywordOverlap returns the fraction of terms that appear in text.
+func keywordOverlap(terms []string, text string) float64 {
+	if len(terms) == 0 {
+		return 0
+	}
+	lower := strings.ToLower(text)
+	hits := 0
+	for _, t := range terms {
+		if strings.Contains(lower, t) {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(terms))
+}
@@ -0,0 +1,25 @@
+package verify
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFreshness(t *testing.T) {
+	now := time.Now()
+	lastYear := now.AddDate(-1, 0, 0)
+	decade := now.AddDate(-10, 0, 0)
+
+	if got := freshness(nil); got != neutralFreshness {
+		t.Errorf("freshness(nil) = %v, want neutral %v", got, neutralFreshness)
+	}
+	if got := freshness(&now); got < 0.99 {
+		t.Errorf("freshness(now) = %v, want about 1", got)
+	}
+	if got := freshness(&lastYear); got < 0.49 || got > 0.51 {
+		t.Errorf("freshness(a year ago) = %v, want about 0.5", got)
+	}
+	if fresh, old := freshness(&lastYear), freshness(&decade); old >= fresh {
+		t.Errorf("freshness(a decade ago) = %v, want less than a year ago (%v)", old, fresh)
+	}
+}
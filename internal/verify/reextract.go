@@ -0,0 +1,167 @@
+// Package verify provides re-extraction of claims for stored analyses.
+package verify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/factchecker/verity/internal/compare"
+	"github.com/factchecker/verity/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// ReExtractSummary describes how an analysis changed after re-extraction.
+type ReExtractSummary struct {
+	AnalysisID string                `json:"analysis_id"`
+	Unchanged  int                   `json:"unchanged"`
+	Changed    []compare.ClaimDiff   `json:"changed,omitempty"` // Rephrased claims, re-verified
+	Added      []models.Claim        `json:"added,omitempty"`
+	Removed    []models.Claim        `json:"removed,omitempty"` // Archived
+	Analysis   models.AnalysisResult `json:"analysis"`
+	Warnings   []models.Warning      `json:"warnings,omitempty"`
+}
+
+// ReExtract runs claim extraction again on the original text of an analysis
+// with the current prompts and the analysis' options, and diffs the result
+// against the stored claims. Claims whose text is unchanged are kept as they
+// are. New and rephrased claims are checked for contradictions with the
+// kept ones, verified and stored, and claims no longer extracted, including
+// the old form of rephrased ones, are archived.
+func (e *Engine) ReExtract(ctx context.Context, analysis *models.AnalysisResult, text string) (*ReExtractSummary, error) {
+	opts := AnalysisOptions(analysis)
+
+	// Extraction, verification and scoring share the token budget;
+	// persistence uses ctx
+	budgetCtx, budget, cancelBudget := withTokenBudget(ctx, e.maxTokens)
+	defer cancelBudget()
+
+	extracted, err := e.extractClaims(budgetCtx, text, opts)
+	if err != nil {
+		if budget.Exhausted() {
+			return nil, fmt.Errorf("token budget of %d exhausted during claim extraction: %w", e.maxTokens, err)
+		}
+		return nil, err
+	}
+
+	existing, err := e.store.GetClaimsByAnalysis(ctx, analysis.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claims: %w", err)
+	}
+
+	diff := compare.Claims(existing, extracted)
+	summary := &ReExtractSummary{AnalysisID: analysis.ID}
+
+	var toVerify []models.Claim
+	var archive []string
+	changed := make(map[string]int) // new claim ID -> index in summary.Changed
+	for _, d := range diff.Diffs {
+		if d.BaseText == d.Text {
+			summary.Unchanged++
+			continue
+		}
+		changed[d.ClaimID] = len(summary.Changed)
+		summary.Changed = append(summary.Changed, d)
+		archive = append(archive, d.BaseClaimID)
+	}
+	for _, c := range extracted {
+		if _, ok := changed[c.ID]; ok {
+			toVerify = append(toVerify, c)
+		}
+	}
+	toVerify = append(toVerify, diff.Added...)
+	for _, c := range diff.Removed {
+		archive = append(archive, c.ID)
+	}
+	summary.Removed = diff.Removed
+
+	var contradictions []models.Contradiction
+	if len(toVerify) > 0 {
+		summary.Warnings = append(summary.Warnings, longClaimWarnings(toVerify)...)
+		contradictions, err = e.newContradictions(budgetCtx, existing, archive, toVerify)
+		if err != nil {
+			log.Warn().Err(err).Msg("Contradiction detection failed")
+			summary.Warnings = append(summary.Warnings, models.Warning{Source: "contradictions", Message: err.Error()})
+		}
+
+		verified, warnings := e.verifyClaims(budgetCtx, toVerify, opts)
+		summary.Warnings = append(summary.Warnings, warnings...)
+		markContradictions(verified, contradictions)
+		if budget.Exhausted() {
+			summary.Warnings = append(summary.Warnings, models.Warning{
+				Source:  "budget",
+				Message: fmt.Sprintf("token budget of %d exhausted; some claims were not verified", e.maxTokens),
+			})
+		}
+		if err := e.scorer.Score(budgetCtx, text, verified); err != nil {
+			log.Warn().Err(err).Msg("Significance scoring failed")
+			summary.Warnings = append(summary.Warnings, models.Warning{Source: "significance", Message: err.Error()})
+		}
+		for _, c := range verified {
+			if i, ok := changed[c.ID]; ok {
+				summary.Changed[i].Status = c.Status
+			} else {
+				summary.Added = append(summary.Added, c)
+			}
+		}
+		toVerify = verified
+	}
+
+	if len(toVerify) > 0 || len(archive) > 0 {
+		if err := e.store.ReplaceClaims(ctx, analysis.ID, archive, toVerify, contradictions); err != nil {
+			return nil, fmt.Errorf("failed to save claims: %w", err)
+		}
+	}
+
+	updated, err := e.store.GetAnalysis(ctx, analysis.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload analysis: %w", err)
+	}
+	if updated == nil {
+		return nil, fmt.Errorf("analysis %s disappeared during re-extraction", analysis.ID)
+	}
+	summary.Analysis = *updated
+
+	log.Info().
+		Str("id", analysis.ID).
+		Int("unchanged", summary.Unchanged).
+		Int("changed", len(summary.Changed)).
+		Int("added", len(summary.Added)).
+		Int("removed", len(summary.Removed)).
+		Msg("Claims re-extracted")
+
+	return summary, nil
+}
+
+// newContradictions detects contradictions among the claims an analysis
+// will have after re-extraction, the kept existing claims plus added, and
+// returns those involving an added claim. Contradictions between kept
+// claims are already stored.
+func (e *Engine) newContradictions(ctx context.Context, existing []models.Claim, archive []string, added []models.Claim) ([]models.Contradiction, error) {
+	archived := make(map[string]bool, len(archive))
+	for _, id := range archive {
+		archived[id] = true
+	}
+	isAdded := make(map[string]bool, len(added))
+	claims := make([]models.Claim, 0, len(existing)+len(added))
+	for _, c := range existing {
+		if !archived[c.ID] {
+			claims = append(claims, c)
+		}
+	}
+	for _, c := range added {
+		isAdded[c.ID] = true
+		claims = append(claims, c)
+	}
+
+	found, err := e.conflicts.Detect(ctx, claims)
+	if err != nil {
+		return nil, err
+	}
+	var contradictions []models.Contradiction
+	for _, c := range found {
+		if isAdded[c.ClaimID] || isAdded[c.ConflictingClaimID] {
+			contradictions = append(contradictions, c)
+		}
+	}
+	return contradictions, nil
+}
@@ -0,0 +1,77 @@
+package verify
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/models"
+)
+
+func TestReExtractKeepsAnalysisOptions(t *testing.T) {
+	ctx := context.Background()
+	provider := llm.NewMockProvider(`{"verification_status":"verified","confidence_score":1.0,"reasoning":"Known."}`).
+		On("Text to analyze:", `{"claims":[
+			{"text":"Inflation was 4% in 2023","type":"statistical","extractability_score":0.9},
+			{"text":"Inflation was 9% in 2023","type":"statistical","extractability_score":0.9},
+			{"text":"Inflation was 9% in 2023","type":"statistical","extractability_score":0.9}
+		]}`).
+		On("Pairs:", `{"contradictions":[{"pair":0,"explanation":"Different rates"}]}`)
+	e, store := newTestEngine(t, provider)
+
+	text := "Inflation was 4% in 2023. Inflation was 9% in 2023."
+	analysis := &models.AnalysisResult{
+		ID:           "analysis",
+		DocumentHash: "hash",
+		Status:       "completed",
+		CreatedAt:    time.Now(),
+		Jurisdiction: "BR",
+		Options:      encodeOptions(VerifyOptions{Format: FormatAbstract, Jurisdiction: "BR"}),
+	}
+	kept := models.Claim{ID: "a", Text: "Inflation was 4% in 2023", Type: models.ClaimTypeStatistical, Status: models.StatusVerified}
+	if err := store.SaveAnalysis(ctx, analysis); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveClaims(ctx, analysis.ID, []models.Claim{kept}); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := e.ReExtract(ctx, analysis, text)
+	if err != nil {
+		t.Fatalf("ReExtract() error = %v", err)
+	}
+	if summary.Unchanged != 1 || len(summary.Added) != 1 {
+		t.Fatalf("ReExtract() kept %d and added %d claims, want 1 and 1 (duplicates merged)", summary.Unchanged, len(summary.Added))
+	}
+
+	got, err := store.GetClaimsByAnalysis(ctx, analysis.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range got {
+		if c.Status != models.StatusMixed {
+			t.Errorf("claim %q status = %s, want %s (contradiction)", c.Text, c.Status, models.StatusMixed)
+		}
+	}
+	contradictions, err := store.GetContradictionsByAnalysis(ctx, analysis.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(contradictions) != 1 {
+		t.Errorf("stored %d contradictions, want 1", len(contradictions))
+	}
+
+	var abstract, jurisdiction bool
+	for _, call := range provider.Calls() {
+		abstract = abstract || strings.Contains(call.System+call.User, "scientific abstract")
+		jurisdiction = jurisdiction || strings.Contains(call.User, "jurisdiction: BR")
+	}
+	if !abstract {
+		t.Error("claims were not extracted in the analysis' abstract format")
+	}
+	if !jurisdiction {
+		t.Error("claims were not verified with the analysis' jurisdiction")
+	}
+}
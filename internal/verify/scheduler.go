@@ -0,0 +1,160 @@
+// Package verify provides scheduled re-verification of analyses.
+package verify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/factchecker/verity/internal/database"
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/models"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// schedulerInterval is how often the scheduler looks for due work.
+const schedulerInterval = time.Hour
+
+// AuditAutoReVerified is the audit log event recorded after a scheduled
+// re-verification.
+const AuditAutoReVerified = "verification.auto_reverified"
+
+// ReVerify verifies the current claims of an analysis again with the
+// options it was made with, keeping the claims themselves and their
+// contradictions, and stores the new verdicts. Claims left unverified when
+// the token budget runs out keep their previous verdict. It returns how many
+// claims changed status.
+func (e *Engine) ReVerify(ctx context.Context, analysis *models.AnalysisResult) (int, error) {
+	claims, err := e.store.GetClaimsByAnalysis(ctx, analysis.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get claims: %w", err)
+	}
+	if len(claims) == 0 {
+		return 0, nil
+	}
+
+	contradictions, err := e.store.GetContradictionsByAnalysis(ctx, analysis.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get contradictions: %w", err)
+	}
+
+	previous := make(map[string]models.Claim, len(claims))
+	for _, c := range claims {
+		previous[c.ID] = c
+	}
+
+	budgetCtx, budget, cancelBudget := withTokenBudget(ctx, e.maxTokens)
+	defer cancelBudget()
+
+	verified, _ := e.verifyClaims(budgetCtx, claims, AnalysisOptions(analysis))
+	markContradictions(verified, contradictions)
+	if budget.Exhausted() {
+		for i, c := range verified {
+			if c.Reasoning == budgetExhaustedReasoning {
+				verified[i] = previous[c.ID]
+			}
+		}
+	}
+	if err := e.store.SaveReVerification(ctx, analysis.ID, verified, time.Now()); err != nil {
+		return 0, fmt.Errorf("failed to save claims: %w", err)
+	}
+
+	changed := 0
+	for _, c := range verified {
+		if c.Status != previous[c.ID].Status {
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// Scheduler runs periodic background work, such as re-verifying analyses
+// scheduled for it.
+type Scheduler struct {
+	engine *Engine
+	store  database.Store
+
+	onReVerified func()
+}
+
+// NewScheduler creates a scheduler.
+func NewScheduler(engine *Engine, store database.Store) *Scheduler {
+	return &Scheduler{engine: engine, store: store}
+}
+
+// SetReVerifiedHandler registers fn to be called after each analysis the
+// scheduler re-verifies, as its scores may have changed.
+func (s *Scheduler) SetReVerifiedHandler(fn func()) {
+	s.onReVerified = fn
+}
+
+// Run checks for due work immediately and then every hour until ctx is
+// cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		s.reVerifyDue(ctx)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reVerifyDue re-verifies, one at a time, every analysis whose interval has
+// elapsed since it was last verified.
+func (s *Scheduler) reVerifyDue(ctx context.Context) {
+	analyses, err := s.store.ListScheduledReVerifications(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to list scheduled re-verifications")
+		return
+	}
+
+	now := time.Now()
+	for _, a := range analyses {
+		if ctx.Err() != nil {
+			return
+		}
+		last := a.CreatedAt
+		if a.LastReVerifiedAt != nil {
+			last = *a.LastReVerifiedAt
+		}
+		if a.ScheduleReVerifyEvery == nil || now.Before(last.Add(*a.ScheduleReVerifyEvery)) {
+			continue
+		}
+
+		start := time.Now()
+		usageCtx, usage := llm.WithUsageRecorder(ctx)
+		changed, err := s.engine.ReVerify(usageCtx, a)
+		if err != nil {
+			log.Error().Err(err).Str("id", a.ID).Msg("Scheduled re-verification failed")
+			continue
+		}
+		log.Info().Str("id", a.ID).Int("changed", changed).Msg("Analysis re-verified")
+		if s.onReVerified != nil {
+			s.onReVerified()
+		}
+
+		entry := &models.AuditLog{
+			ID:         uuid.New().String(),
+			Endpoint:   AuditAutoReVerified,
+			DurationMs: time.Since(start).Milliseconds(),
+			Timestamp:  start,
+			Details: map[string]interface{}{
+				"analysis_id":    a.ID,
+				"changed_claims": changed,
+				"interval_hours": int(a.ScheduleReVerifyEvery.Hours()),
+			},
+		}
+		tokens := usage.Total()
+		entry.PromptTokens = tokens.PromptTokens
+		entry.CompletionTokens = tokens.CompletionTokens
+		if err := s.store.LogRequest(ctx, entry); err != nil {
+			log.Error().Err(err).Msg("Failed to log audit entry")
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package verify
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/factchecker/verity/internal/config"
+	"github.com/factchecker/verity/internal/database"
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/models"
+)
+
+// newTestEngine returns an air-gapped engine, backed by a temporary SQLite
+// store, whose verifications return the mock's default response.
+func newTestEngine(t *testing.T, provider llm.Provider) (*Engine, database.Store) {
+	t.Helper()
+	store, err := database.NewSQLiteStore(filepath.Join(t.TempDir(), "verity.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	cfg := config.DefaultConfig()
+	cfg.Search = config.SearchConfig{}
+	return NewEngine(cfg, provider, store), store
+}
+
+func TestReVerifyKeepsAnalysisOptions(t *testing.T) {
+	ctx := context.Background()
+	provider := llm.NewMockProvider(`{"verification_status":"verified","confidence_score":1.0,"reasoning":"Known."}`)
+	e, store := newTestEngine(t, provider)
+
+	analysis := &models.AnalysisResult{
+		ID:           "analysis",
+		DocumentHash: "hash",
+		Status:       "completed",
+		CreatedAt:    time.Now(),
+		ModelSource:  "GPT-4",
+		Jurisdiction: "BR",
+	}
+	claims := []models.Claim{
+		{ID: "a", Text: "Inflation was 4% in 2023", Type: models.ClaimTypeStatistical, Status: models.StatusUnsupported},
+		{ID: "b", Text: "Inflation was 9% in 2023", Type: models.ClaimTypeStatistical, Status: models.StatusMixed},
+		{ID: "c", Text: "Brasília is the capital", Type: models.ClaimTypeGeographic, Status: models.StatusUnsupported},
+	}
+	if err := store.SaveAnalysis(ctx, analysis); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveClaims(ctx, analysis.ID, claims); err != nil {
+		t.Fatal(err)
+	}
+	contradictions := []models.Contradiction{{ClaimID: "a", ConflictingClaimID: "b", Explanation: "Different rates"}}
+	if err := store.SaveContradictions(ctx, analysis.ID, contradictions); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := e.ReVerify(ctx, analysis)
+	if err != nil {
+		t.Fatalf("ReVerify() error = %v", err)
+	}
+	if changed != 2 {
+		t.Errorf("ReVerify() changed %d claims, want 2", changed)
+	}
+
+	got, err := store.GetClaimsByAnalysis(ctx, analysis.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range got {
+		wantStatus := models.StatusVerified
+		if c.ID == "a" || c.ID == "b" {
+			wantStatus = models.StatusMixed // contradictions still apply
+		}
+		if c.Status != wantStatus {
+			t.Errorf("claim %s status = %s, want %s", c.ID, c.Status, wantStatus)
+		}
+		if math.Abs(c.Confidence-modelSourcePenalty) > 1e-9 {
+			t.Errorf("claim %s confidence = %v, want the model source penalty %v", c.ID, c.Confidence, modelSourcePenalty)
+		}
+	}
+
+	for _, call := range provider.Calls() {
+		if !strings.Contains(call.User, "jurisdiction: BR") {
+			t.Errorf("verification prompt without the analysis' jurisdiction:\n%s", call.User)
+		}
+	}
+}
+
+func TestAnalysisOptionsRoundTrip(t *testing.T) {
+	opts := VerifyOptions{
+		FocusHints:        []string{"economy"},
+		Format:            FormatAbstract,
+		EvidenceLanguages: []string{"pt"},
+		Explain:           true,
+		ModelSource:       "GPT-4",
+		Jurisdiction:      "BR",
+	}
+	analysis := &models.AnalysisResult{Options: encodeOptions(opts), ModelSource: opts.ModelSource, Jurisdiction: opts.Jurisdiction}
+
+	got := AnalysisOptions(analysis)
+	if analysisCacheKey(got) != analysisCacheKey(opts) {
+		t.Errorf("AnalysisOptions() = %+v, want %+v", got, opts)
+	}
+	if AnalysisOptions(&models.AnalysisResult{}).Format != "" || encodeOptions(VerifyOptions{}) != "" {
+		t.Error("default options are not empty")
+	}
+}
@@ -0,0 +1,70 @@
+// Package verify provides embedding-based selection of evidence.
+package verify
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/factchecker/verity/internal/models"
+	"github.com/rs/zerolog/log"
+)
+
+// selectEvidence keeps the topK evidences whose snippets are most similar
+// to the claim, by cosine similarity of their embeddings, best first. It
+// also returns the position in evidences of each kept one. Evidence is
+// returned unchanged when selection is disabled, the provider has no
+// embeddings, or embedding fails.
+func (v *ClaimVerifier) selectEvidence(ctx context.Context, claim models.Claim, evidences []models.Evidence) ([]models.Evidence, []int) {
+	if v.topEvidence <= 0 || len(evidences) <= 1 || !v.provider.SupportsEmbeddings() {
+		return evidences, nil
+	}
+
+	claimEmbedding, err := v.provider.Embed(ctx, claim.Text)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to embed claim, keeping all evidence")
+		return evidences, nil
+	}
+	similarity := make([]float64, len(evidences))
+	for i, e := range evidences {
+		embedding, err := v.provider.Embed(ctx, e.Snippet)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to embed evidence, keeping all evidence")
+			return evidences, nil
+		}
+		similarity[i] = cosineSimilarity(claimEmbedding, embedding)
+	}
+
+	order := make([]int, len(evidences))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return similarity[order[a]] > similarity[order[b]]
+	})
+	order = order[:min(v.topEvidence, len(order))]
+
+	selected := make([]models.Evidence, len(order))
+	for i, idx := range order {
+		selected[i] = evidences[idx]
+	}
+	return selected, order
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b, or 0
+// when their lengths differ or either is zero.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
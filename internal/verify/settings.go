@@ -0,0 +1,144 @@
+// Package verify provides runtime-adjustable settings backed by the database.
+package verify
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/factchecker/verity/internal/database"
+	"github.com/rs/zerolog/log"
+)
+
+// Settings that can be overridden at runtime through the admin API.
+// SettingMaxEvidence caps the evidence kept for a claim: each source is
+// asked for that many results and only the best ranked of the merged
+// results are kept.
+const (
+	SettingMaxConcurrency    = "verify.max_concurrency"
+	SettingMinExtractability = "verify.min_extractability_score"
+	SettingMaxEvidence       = "search.max_evidence_per_claim"
+)
+
+// Defaults for settings that have no configuration file counterpart.
+const (
+	defaultMaxConcurrency = 5
+	defaultMaxEvidence    = 6
+)
+
+// Upper bounds for overrides, so that a single change cannot flood the LLM
+// and search APIs with parallel calls or oversized prompts.
+const (
+	maxConcurrencyLimit = 50
+	maxEvidenceLimit    = 20
+)
+
+// overrideTTL is how long an override is cached before the database is read
+// again, so changes reach every instance within this time.
+const overrideTTL = 30 * time.Second
+
+// overridableSettings maps each overridable key to its value validation.
+var overridableSettings = map[string]func(string) error{
+	SettingMaxConcurrency: intBetween(1, maxConcurrencyLimit),
+	SettingMaxEvidence:    intBetween(1, maxEvidenceLimit),
+	SettingMinExtractability: func(v string) error {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 || f > 1 {
+			return fmt.Errorf("must be a number between 0 and 1")
+		}
+		return nil
+	},
+}
+
+// intBetween returns a validation accepting integers from min to max.
+func intBetween(min, max int) func(string) error {
+	return func(v string) error {
+		if n, err := strconv.Atoi(v); err != nil || n < min || n > max {
+			return fmt.Errorf("must be an integer between %d and %d", min, max)
+		}
+		return nil
+	}
+}
+
+// ValidateOverride checks that key can be overridden and value is valid for it.
+func ValidateOverride(key, value string) error {
+	validate, ok := overridableSettings[key]
+	if !ok {
+		keys := make([]string, 0, len(overridableSettings))
+		for k := range overridableSettings {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		return fmt.Errorf("unknown setting %q; supported settings: %s", key, strings.Join(keys, ", "))
+	}
+	if err := validate(value); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	return nil
+}
+
+// RuntimeSettings reads setting overrides from the database, caching each
+// for overrideTTL.
+type RuntimeSettings struct {
+	store database.Store
+
+	mu    sync.Mutex
+	cache map[string]cachedOverride
+}
+
+type cachedOverride struct {
+	value   string
+	ok      bool
+	expires time.Time
+}
+
+// NewRuntimeSettings creates a runtime settings reader.
+func NewRuntimeSettings(store database.Store) *RuntimeSettings {
+	return &RuntimeSettings{store: store, cache: make(map[string]cachedOverride)}
+}
+
+// lookup returns the override for key, if any. Database errors are logged
+// and treated as no override, so verification never fails on them.
+func (s *RuntimeSettings) lookup(ctx context.Context, key string) (string, bool) {
+	s.mu.Lock()
+	cached, hit := s.cache[key]
+	s.mu.Unlock()
+	if hit && time.Now().Before(cached.expires) {
+		return cached.value, cached.ok
+	}
+
+	value, ok, err := s.store.GetConfigOverride(ctx, key)
+	if err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("Failed to read config override")
+		return "", false
+	}
+
+	s.mu.Lock()
+	s.cache[key] = cachedOverride{value: value, ok: ok, expires: time.Now().Add(overrideTTL)}
+	s.mu.Unlock()
+	return value, ok
+}
+
+// Int returns the override for key, or def when there is none.
+func (s *RuntimeSettings) Int(ctx context.Context, key string, def int) int {
+	if v, ok := s.lookup(ctx, key); ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return def
+}
+
+// Float returns the override for key, or def when there is none.
+func (s *RuntimeSettings) Float(ctx context.Context, key string, def float64) float64 {
+	if v, ok := s.lookup(ctx, key); ok {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return def
+}
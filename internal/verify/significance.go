@@ -0,0 +1,103 @@
+// Package verify provides claim significance scoring.
+package verify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/models"
+)
+
+// maxSignificanceContext bounds how much of the document is sent for scoring.
+const maxSignificanceContext = 6000
+
+// SignificanceScorer rates how much each claim matters to a document's message.
+type SignificanceScorer struct {
+	provider llm.Provider
+}
+
+// NewSignificanceScorer creates a new significance scorer.
+func NewSignificanceScorer(provider llm.Provider) *SignificanceScorer {
+	return &SignificanceScorer{provider: provider}
+}
+
+type significanceResult struct {
+	Scores []struct {
+		Index        int     `json:"index"`
+		Significance float64 `json:"significance"`
+	} `json:"scores"`
+}
+
+// Score sets Significance on each claim using a single LLM call.
+func (s *SignificanceScorer) Score(ctx context.Context, text string, claims []models.Claim) error {
+	if len(claims) == 0 {
+		return nil
+	}
+
+	systemPrompt := `You are an editor assessing which claims in a document matter most.
+
+For each numbered claim, answer: On a scale of 0-1, how significant is this claim's truth value to the overall message of the document?
+- 1.0: the document's main point depends on this claim
+- 0.5: supporting detail
+- 0.0: incidental detail
+
+Respond with a JSON object:
+{
+  "scores": [
+    {"index": 0, "significance": 0.8}
+  ]
+}
+
+Only respond with the JSON object, no other text.`
+
+	if len(text) > maxSignificanceContext {
+		text = text[:maxSignificanceContext] + "..."
+	}
+
+	var claimList strings.Builder
+	for i, c := range claims {
+		claimList.WriteString(fmt.Sprintf("%d. %s\n", i, c.Text))
+	}
+	userPrompt := fmt.Sprintf("Document:\n%s\n\nClaims:\n%s", text, claimList.String())
+
+	opts := llm.DefaultCompletionOptions()
+	opts.MaxTokens = 1024
+
+	response, err := s.provider.CompleteWithSystem(ctx, systemPrompt, userPrompt, opts)
+	if err != nil {
+		return fmt.Errorf("significance scoring failed: %w", err)
+	}
+	chargeTokens(ctx, systemPrompt, userPrompt, response)
+
+	jsonText, err := extractJSONObject(response)
+	if err != nil {
+		return fmt.Errorf("failed to parse significance response: %w", err)
+	}
+
+	var result significanceResult
+	if err := json.Unmarshal([]byte(jsonText), &result); err != nil {
+		return fmt.Errorf("failed to parse significance response: %w", err)
+	}
+
+	for _, sc := range result.Scores {
+		if sc.Index >= 0 && sc.Index < len(claims) {
+			claims[sc.Index].Significance = math.Max(0, math.Min(1, sc.Significance))
+		}
+	}
+	return nil
+}
+
+// extractJSONObject strips markdown fences and surrounding text from an LLM
+// response, returning the outermost JSON object.
+func extractJSONObject(response string) (string, error) {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end <= start {
+		return "", fmt.Errorf("no JSON found in response")
+	}
+	return response[start : end+1], nil
+}
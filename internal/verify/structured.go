@@ -0,0 +1,263 @@
+// Package verify provides claim extraction from HTML tables and lists.
+package verify
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/factchecker/verity/internal/models"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// StructuredContent is an HTML document flattened to text for extraction:
+// the prose first, followed by one sentence per table row and per list.
+type StructuredContent struct {
+	Text string
+
+	// segments maps sentence indexes of Text to their source format; each
+	// segment runs until the next one starts.
+	segments []formatSegment
+}
+
+type formatSegment struct {
+	start  int
+	format string
+}
+
+// FormatOf returns the source format of the sentence at index i of Text.
+func (c *StructuredContent) FormatOf(i int) string {
+	format := models.SourceFormatProse
+	for _, s := range c.segments {
+		if s.start > i {
+			break
+		}
+		format = s.format
+	}
+	return format
+}
+
+// StructuredContentExtractor converts HTML documents into text that keeps
+// the facts held in tables and lists, which plain text extraction loses.
+type StructuredContentExtractor struct{}
+
+// NewStructuredContentExtractor creates a structured content extractor.
+func NewStructuredContentExtractor() *StructuredContentExtractor {
+	return &StructuredContentExtractor{}
+}
+
+// Extract parses an HTML document. Tables become one sentence per row,
+// such as "Row 3: Country=USA, GDP=$25T.", using the header row for column
+// names when present. Lists become one sentence, such as
+// "Item 1: X; Item 2: Y.".
+func (e *StructuredContentExtractor) Extract(document string) (*StructuredContent, error) {
+	root, err := html.Parse(strings.NewReader(document))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	var prose strings.Builder
+	var tables, lists []string
+	walkStructured(root, &prose, &tables, &lists)
+
+	content := &StructuredContent{}
+	var parts []string
+	sentences := 0
+	if text := strings.TrimSpace(collapseBlankLines(prose.String())); text != "" {
+		text = terminate(text)
+		parts = append(parts, text)
+		sentences += len(splitSentences(text))
+	}
+	for _, group := range []struct {
+		format    string
+		sentences []string
+	}{
+		{models.SourceFormatTable, tables},
+		{models.SourceFormatList, lists},
+	} {
+		if len(group.sentences) == 0 {
+			continue
+		}
+		content.segments = append(content.segments, formatSegment{start: sentences, format: group.format})
+		for _, s := range group.sentences {
+			parts = append(parts, s)
+			sentences += len(splitSentences(s))
+		}
+	}
+	content.Text = strings.Join(parts, "\n\n")
+	return content, nil
+}
+
+// skippedElements hold no document text.
+var skippedElements = map[atom.Atom]bool{
+	atom.Head: true, atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Template: true,
+}
+
+// blockElements start a new paragraph in the prose text.
+var blockElements = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Br: true, atom.Section: true, atom.Article: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Blockquote: true, atom.Pre: true, atom.Header: true, atom.Footer: true, atom.Li: true,
+}
+
+// walkStructured appends text outside tables and lists to prose and
+// converts tables and top-level lists to sentences.
+func walkStructured(n *html.Node, prose *strings.Builder, tables, lists *[]string) {
+	switch {
+	case n.Type == html.TextNode:
+		prose.WriteString(n.Data)
+		return
+	case n.Type == html.ElementNode && skippedElements[n.DataAtom]:
+		return
+	case n.Type == html.ElementNode && n.DataAtom == atom.Table:
+		*tables = append(*tables, tableSentences(n)...)
+		return
+	case n.Type == html.ElementNode && (n.DataAtom == atom.Ul || n.DataAtom == atom.Ol):
+		if s := listSentence(n); s != "" {
+			*lists = append(*lists, s)
+		}
+		return
+	}
+
+	block := n.Type == html.ElementNode && blockElements[n.DataAtom]
+	if block {
+		prose.WriteString("\n\n")
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walkStructured(c, prose, tables, lists)
+	}
+	if block {
+		prose.WriteString("\n\n")
+	}
+}
+
+// tableSentences converts each data row of a table to a sentence. The
+// first row names the columns when it consists of header cells.
+func tableSentences(table *html.Node) []string {
+	var rows [][]*html.Node
+	var collect func(*html.Node)
+	collect = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			switch c.DataAtom {
+			case atom.Tr:
+				rows = append(rows, cells(c))
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				collect(c)
+			}
+		}
+	}
+	collect(table)
+
+	var headers []string
+	if len(rows) > 0 && allHeaderCells(rows[0]) {
+		for _, c := range rows[0] {
+			headers = append(headers, nodeText(c))
+		}
+		rows = rows[1:]
+	}
+
+	var sentences []string
+	for i, row := range rows {
+		var fields []string
+		for j, c := range row {
+			value := nodeText(c)
+			if value == "" {
+				continue
+			}
+			if j < len(headers) && headers[j] != "" {
+				value = headers[j] + "=" + value
+			}
+			fields = append(fields, value)
+		}
+		if len(fields) > 0 {
+			sentences = append(sentences, terminate(fmt.Sprintf("Row %d: %s", i+1, strings.Join(fields, ", "))))
+		}
+	}
+	return sentences
+}
+
+// cells returns the th and td children of a table row.
+func cells(tr *html.Node) []*html.Node {
+	var cs []*html.Node
+	for c := tr.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && (c.DataAtom == atom.Th || c.DataAtom == atom.Td) {
+			cs = append(cs, c)
+		}
+	}
+	return cs
+}
+
+func allHeaderCells(row []*html.Node) bool {
+	if len(row) == 0 {
+		return false
+	}
+	for _, c := range row {
+		if c.DataAtom != atom.Th {
+			return false
+		}
+	}
+	return true
+}
+
+// listSentence converts a list to a single sentence. Nested lists are
+// flattened into the text of their parent item.
+func listSentence(list *html.Node) string {
+	var items []string
+	for c := list.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || c.DataAtom != atom.Li {
+			continue
+		}
+		if text := strings.TrimRight(nodeText(c), ".;"); text != "" {
+			items = append(items, fmt.Sprintf("Item %d: %s", len(items)+1, text))
+		}
+	}
+	if len(items) == 0 {
+		return ""
+	}
+	return terminate(strings.Join(items, "; "))
+}
+
+// nodeText returns the whitespace-normalized text content of n.
+func nodeText(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+			sb.WriteString(" ")
+			return
+		}
+		if n.Type == html.ElementNode && skippedElements[n.DataAtom] {
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// collapseBlankLines normalizes whitespace within lines and reduces runs
+// of blank lines to a single paragraph break.
+func collapseBlankLines(text string) string {
+	var paragraphs []string
+	for _, p := range paragraphBoundary.Split(text, -1) {
+		if p = strings.Join(strings.Fields(p), " "); p != "" {
+			paragraphs = append(paragraphs, p)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// terminate ends text with a period unless it already ends a sentence, so
+// that it is not merged with the text that follows.
+func terminate(text string) string {
+	if strings.HasSuffix(text, ".") || strings.HasSuffix(text, "!") || strings.HasSuffix(text, "?") {
+		return text
+	}
+	return text + "."
+}
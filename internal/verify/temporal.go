@@ -0,0 +1,189 @@
+// Package verify provides date extraction for temporal claims.
+package verify
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/factchecker/verity/internal/models"
+)
+
+// ExtractedDate is a date found in text. Month and Day are zero when the
+// source only specified a coarser precision (e.g. a bare year).
+type ExtractedDate struct {
+	Text  string `json:"text"`
+	Year  int    `json:"year"`
+	Month int    `json:"month,omitempty"`
+	Day   int    `json:"day,omitempty"`
+}
+
+// TemporalExtractor finds dates in free text.
+type TemporalExtractor struct {
+	iso        *regexp.Regexp
+	fullSlash  *regexp.Regexp
+	monthSlash *regexp.Regexp
+	writtenMDY *regexp.Regexp
+	writtenDMY *regexp.Regexp
+	monthYear  *regexp.Regexp
+	year       *regexp.Regexp
+}
+
+const monthNames = `(January|February|March|April|May|June|July|August|September|October|November|December|` +
+	`Jan|Feb|Mar|Apr|Jun|Jul|Aug|Sep|Sept|Oct|Nov|Dec)\.?`
+
+// NewTemporalExtractor creates a new temporal extractor.
+func NewTemporalExtractor() *TemporalExtractor {
+	return &TemporalExtractor{
+		iso:        regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})(?:T[0-9:.]+(?:Z|[+-]\d{2}:?\d{2})?)?\b`),
+		fullSlash:  regexp.MustCompile(`\b(\d{1,2})/(\d{1,2})/(\d{4})\b`),
+		monthSlash: regexp.MustCompile(`\b(\d{1,2})/(\d{4})\b`),
+		writtenMDY: regexp.MustCompile(`(?i)\b` + monthNames + `\s+(\d{1,2})(?:st|nd|rd|th)?,?\s+(\d{4})\b`),
+		writtenDMY: regexp.MustCompile(`(?i)\b(\d{1,2})(?:st|nd|rd|th)?\s+` + monthNames + `,?\s+(\d{4})\b`),
+		monthYear:  regexp.MustCompile(`(?i)\b` + monthNames + `,?\s+(\d{4})\b`),
+		// A bare number is only a year after a preposition that introduces a
+		// date, so that "1500 patients" is not read as the year 1500. Only
+		// the date group is reported and blanked out.
+		year: regexp.MustCompile(`(?i)\b(?:in|since|by|until|till|during|from|before|after|through|around|circa|between)\s+` +
+			`(?:(?:early|late|mid)[\s-]+)?(?:the\s+year\s+)?(?P<date>1[0-9]{3}|20[0-9]{2})\b`),
+	}
+}
+
+type dateMatch struct {
+	pos  int
+	date ExtractedDate
+}
+
+// ExtractDates returns all dates found in text in order of appearance.
+// More specific formats are matched first so that e.g. "2023-03-15" is not
+// also reported as the bare year "2023". Bare years are only reported in a
+// date context, such as "in 2023" or "March 2023".
+func (t *TemporalExtractor) ExtractDates(text string) []ExtractedDate {
+	// Matched spans are blanked out so coarser patterns don't re-match them.
+	work := []byte(text)
+	var matches []dateMatch
+
+	collect := func(re *regexp.Regexp, build func(groups []string) (ExtractedDate, bool)) {
+		span := 0
+		if i := re.SubexpIndex("date"); i > 0 {
+			span = i
+		}
+		for _, loc := range re.FindAllSubmatchIndex(work, -1) {
+			groups := make([]string, len(loc)/2)
+			for i := range groups {
+				if loc[2*i] >= 0 {
+					groups[i] = string(work[loc[2*i]:loc[2*i+1]])
+				}
+			}
+			d, ok := build(groups)
+			if !ok {
+				continue
+			}
+			start, end := loc[2*span], loc[2*span+1]
+			d.Text = text[start:end]
+			matches = append(matches, dateMatch{pos: start, date: d})
+			for i := start; i < end; i++ {
+				work[i] = ' '
+			}
+		}
+	}
+
+	collect(t.iso, func(g []string) (ExtractedDate, bool) {
+		return newDate(atoi(g[1]), atoi(g[2]), atoi(g[3]))
+	})
+	collect(t.fullSlash, func(g []string) (ExtractedDate, bool) {
+		return newDate(atoi(g[3]), atoi(g[1]), atoi(g[2]))
+	})
+	collect(t.writtenMDY, func(g []string) (ExtractedDate, bool) {
+		return newDate(atoi(g[3]), monthNumber(g[1]), atoi(g[2]))
+	})
+	collect(t.writtenDMY, func(g []string) (ExtractedDate, bool) {
+		return newDate(atoi(g[3]), monthNumber(g[2]), atoi(g[1]))
+	})
+	collect(t.monthYear, func(g []string) (ExtractedDate, bool) {
+		return newDate(atoi(g[2]), monthNumber(g[1]), 0)
+	})
+	collect(t.monthSlash, func(g []string) (ExtractedDate, bool) {
+		return newDate(atoi(g[2]), atoi(g[1]), 0)
+	})
+	collect(t.year, func(g []string) (ExtractedDate, bool) {
+		return newDate(atoi(g[1]), 0, 0)
+	})
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].pos < matches[j].pos })
+
+	dates := make([]ExtractedDate, len(matches))
+	for i, m := range matches {
+		dates[i] = m.date
+	}
+	return dates
+}
+
+// DatesSupported reports whether every date mentioned in the claim appears
+// in at least one evidence snippet. Claims without dates are always supported.
+func (t *TemporalExtractor) DatesSupported(claimText string, evidences []models.Evidence) bool {
+	claimDates := t.ExtractDates(claimText)
+	if len(claimDates) == 0 {
+		return true
+	}
+
+	var evidenceDates []ExtractedDate
+	for _, e := range evidences {
+		evidenceDates = append(evidenceDates, t.ExtractDates(e.Snippet)...)
+	}
+
+	for _, cd := range claimDates {
+		found := false
+		for _, ed := range evidenceDates {
+			if cd.matches(ed) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether other agrees with d at every precision d specifies.
+func (d ExtractedDate) matches(other ExtractedDate) bool {
+	if d.Year != other.Year {
+		return false
+	}
+	if d.Month != 0 && d.Month != other.Month {
+		return false
+	}
+	if d.Day != 0 && d.Day != other.Day {
+		return false
+	}
+	return true
+}
+
+func newDate(year, month, day int) (ExtractedDate, bool) {
+	if month < 0 || month > 12 || day < 0 || day > 31 {
+		return ExtractedDate{}, false
+	}
+	if day != 0 && month == 0 {
+		return ExtractedDate{}, false
+	}
+	return ExtractedDate{Year: year, Month: month, Day: day}, true
+}
+
+func monthNumber(name string) int {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	months := []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	for i, m := range months {
+		if strings.HasPrefix(name, m) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
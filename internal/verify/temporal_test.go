@@ -0,0 +1,79 @@
+package verify
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/factchecker/verity/internal/models"
+)
+
+func TestTemporalExtractorExtractDates(t *testing.T) {
+	tests := []struct {
+		text string
+		want []ExtractedDate
+	}{
+		{"Released on 2023-03-15", []ExtractedDate{{Text: "2023-03-15", Year: 2023, Month: 3, Day: 15}}},
+		{"Signed 03/15/2023", []ExtractedDate{{Text: "03/15/2023", Year: 2023, Month: 3, Day: 15}}},
+		{"Founded on March 15, 2023", []ExtractedDate{{Text: "March 15, 2023", Year: 2023, Month: 3, Day: 15}}},
+		{"Founded on 15th March 2023", []ExtractedDate{{Text: "15th March 2023", Year: 2023, Month: 3, Day: 15}}},
+		{"Launched in Sept. 2019", []ExtractedDate{{Text: "Sept. 2019", Year: 2019, Month: 9}}},
+		{"Rates rose in 03/2021", []ExtractedDate{{Text: "03/2021", Year: 2021, Month: 3}}},
+		{"Unemployment peaked in 2015", []ExtractedDate{{Text: "2015", Year: 2015}}},
+		{"The law has applied since 1988", []ExtractedDate{{Text: "1988", Year: 1988}}},
+		{"Emissions will halve by 2030", []ExtractedDate{{Text: "2030", Year: 2030}}},
+		{"Prices fell in early 2009", []ExtractedDate{{Text: "2009", Year: 2009}}},
+		{"GDP grew between 2010 and 2015", []ExtractedDate{{Text: "2010", Year: 2010}}},
+		{"From 1914 until 1918", []ExtractedDate{{Text: "1914", Year: 1914}, {Text: "1918", Year: 1918}}},
+
+		// Numbers in the year range without a date context
+		{"The trial enrolled 1500 patients", nil},
+		{"The bridge is 2048 meters long", nil},
+		{"Turnout was 1999 votes higher", nil},
+		{"It costs 1200 dollars", nil},
+	}
+
+	te := NewTemporalExtractor()
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := te.ExtractDates(tt.text)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractDates(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemporalExtractorDatesSupported(t *testing.T) {
+	tests := []struct {
+		claim    string
+		snippets []string
+		want     bool
+	}{
+		{"Unemployment peaked in 2015", []string{"Unemployment reached its peak on 2015-06-01."}, true},
+		{"Unemployment peaked in 2015", []string{"Unemployment peaked in 2016."}, false},
+		{"Founded in March 2010", []string{"It was founded on 3 March 2010."}, true},
+		{"Founded in March 2010", []string{"It was founded in May 2010."}, false},
+		{"Founded on March 3, 2010", []string{"It was founded in March 2010."}, false},
+		{"Vaccinations started in 2021", nil, false},
+
+		// Claims without dates, or whose numbers are not dates
+		{"The trial enrolled 1500 patients", []string{"The trial enrolled 1,500 patients."}, true},
+		{"Water boils at 100 degrees", nil, true},
+	}
+
+	te := NewTemporalExtractor()
+	for _, tt := range tests {
+		t.Run(tt.claim, func(t *testing.T) {
+			var evidences []models.Evidence
+			for _, s := range tt.snippets {
+				evidences = append(evidences, models.Evidence{Snippet: s})
+			}
+			if got := te.DatesSupported(tt.claim, evidences); got != tt.want {
+				t.Errorf("DatesSupported(%q, %q) = %v, want %v", tt.claim, tt.snippets, got, tt.want)
+			}
+		})
+	}
+}
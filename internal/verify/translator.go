@@ -0,0 +1,53 @@
+// Package verify provides claim translation for cross-language evidence search.
+package verify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/models"
+	"github.com/factchecker/verity/internal/search"
+)
+
+// ClaimTranslator translates claims into the primary language so that
+// evidence can be searched for in it, keeping translation out of the
+// verification prompt.
+type ClaimTranslator struct {
+	provider llm.Provider
+	target   string
+}
+
+// NewClaimTranslator creates a translator into the given ISO 639-1
+// language. It returns nil when target is empty, disabling translation.
+func NewClaimTranslator(provider llm.Provider, target string) *ClaimTranslator {
+	target = search.NormalizeLanguage(target)
+	if target == "" {
+		return nil
+	}
+	return &ClaimTranslator{provider: provider, target: target}
+}
+
+// Translate returns claim's text in the target language, or "" when the
+// claim is already in it or its language is unknown.
+func (t *ClaimTranslator) Translate(ctx context.Context, claim models.Claim) (string, error) {
+	lang := search.NormalizeLanguage(claim.DetectedLanguage)
+	if lang == "" || lang == t.target {
+		return "", nil
+	}
+
+	systemPrompt := fmt.Sprintf(`Translate the user's text from language %q to language %q (ISO 639-1 codes).
+Keep names, numbers, units and dates exactly as written. Do not add explanations.
+Only respond with the translation.`, lang, t.target)
+
+	opts := llm.DefaultCompletionOptions()
+	opts.MaxTokens = 512
+
+	response, err := t.provider.CompleteWithSystem(ctx, systemPrompt, claim.Text, opts)
+	if err != nil {
+		return "", fmt.Errorf("claim translation failed: %w", err)
+	}
+	chargeTokens(ctx, systemPrompt, claim.Text, response)
+	return strings.Trim(strings.TrimSpace(response), `"`), nil
+}
@@ -0,0 +1,231 @@
+// Package verify provides unit normalization for statistical claims.
+package verify
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/factchecker/verity/internal/models"
+)
+
+// quantityTolerance is the relative difference under which two normalized
+// quantities are considered equal, absorbing rounding in reported figures.
+const quantityTolerance = 0.005
+
+// Quantity is a number found in text, converted to a canonical base unit:
+// a currency code for money, "m" for length, "g" for mass and "fraction"
+// for percentages. Unit is empty for plain counts.
+type Quantity struct {
+	Text  string  `json:"text"`
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit,omitempty"`
+
+	// Bare is true for numbers with no unit, currency or magnitude word,
+	// such as years or list numbers.
+	Bare bool `json:"-"`
+}
+
+// unitFactor converts a unit spelling to its canonical unit and scale.
+type unitFactor struct {
+	unit  string
+	scale float64
+}
+
+// magnitudes are multipliers written after a number, in English and
+// Brazilian Portuguese.
+var magnitudes = map[string]float64{
+	"thousand": 1e3, "k": 1e3, "mil": 1e3,
+	"million": 1e6, "mn": 1e6, "milhão": 1e6, "milhões": 1e6, "milhao": 1e6, "milhoes": 1e6,
+	"billion": 1e9, "bn": 1e9, "bilhão": 1e9, "bilhões": 1e9, "bilhao": 1e9, "bilhoes": 1e9,
+	"trillion": 1e12, "tn": 1e12, "trilhão": 1e12, "trilhões": 1e12, "trilhao": 1e12, "trilhoes": 1e12,
+}
+
+// currencySymbols are currency markers written before a number.
+var currencySymbols = map[string]string{
+	"$": "USD", "us$": "USD", "€": "EUR", "£": "GBP", "r$": "BRL",
+}
+
+// units maps unit spellings written after a number.
+var units = map[string]unitFactor{
+	"%": {"fraction", 0.01}, "percent": {"fraction", 0.01}, "per cent": {"fraction", 0.01}, "por cento": {"fraction", 0.01},
+
+	"dollar": {"USD", 1}, "dollars": {"USD", 1}, "usd": {"USD", 1},
+	"euro": {"EUR", 1}, "euros": {"EUR", 1}, "eur": {"EUR", 1},
+	"pound": {"GBP", 1}, "pounds": {"GBP", 1}, "gbp": {"GBP", 1},
+	"real": {"BRL", 1}, "reais": {"BRL", 1}, "brl": {"BRL", 1},
+
+	"km": {"m", 1e3}, "kilometer": {"m", 1e3}, "kilometers": {"m", 1e3}, "kilometre": {"m", 1e3}, "kilometres": {"m", 1e3},
+	"m": {"m", 1}, "meter": {"m", 1}, "meters": {"m", 1}, "metre": {"m", 1}, "metres": {"m", 1},
+	"cm": {"m", 1e-2}, "centimeter": {"m", 1e-2}, "centimeters": {"m", 1e-2}, "centimetre": {"m", 1e-2}, "centimetres": {"m", 1e-2},
+	"mm": {"m", 1e-3}, "millimeter": {"m", 1e-3}, "millimeters": {"m", 1e-3}, "millimetre": {"m", 1e-3}, "millimetres": {"m", 1e-3},
+	"µm": {"m", 1e-6}, "micrometer": {"m", 1e-6}, "micrometers": {"m", 1e-6}, "micrometre": {"m", 1e-6}, "micrometres": {"m", 1e-6},
+	"mi": {"m", 1609.344}, "mile": {"m", 1609.344}, "miles": {"m", 1609.344},
+
+	"t": {"g", 1e6}, "tonne": {"g", 1e6}, "tonnes": {"g", 1e6}, "ton": {"g", 1e6}, "tons": {"g", 1e6},
+	"kg": {"g", 1e3}, "kilogram": {"g", 1e3}, "kilograms": {"g", 1e3},
+	"g": {"g", 1}, "gram": {"g", 1}, "grams": {"g", 1},
+	"mg": {"g", 1e-3}, "milligram": {"g", 1e-3}, "milligrams": {"g", 1e-3},
+	"µg": {"g", 1e-6}, "mcg": {"g", 1e-6}, "microgram": {"g", 1e-6}, "micrograms": {"g", 1e-6},
+	"lb": {"g", 453.59237}, "lbs": {"g", 453.59237},
+}
+
+// UnitNormalizer finds quantities in text and converts them to canonical
+// units, so that "$25 trillion" and "25,000 billion dollars" compare equal.
+type UnitNormalizer struct {
+	quantity *regexp.Regexp
+}
+
+// NewUnitNormalizer creates a new unit normalizer. Numbers may use English
+// ("1,234.5") or Portuguese ("1.234,5", "4,5") separators; a comma followed
+// by exactly three digits is read as a thousands separator.
+func NewUnitNormalizer() *UnitNormalizer {
+	var magnitudeWords, unitWords []string
+	for w := range magnitudes {
+		magnitudeWords = append(magnitudeWords, w)
+	}
+	for w := range units {
+		if w != "%" {
+			unitWords = append(unitWords, w)
+		}
+	}
+	return &UnitNormalizer{
+		quantity: regexp.MustCompile(`(?i)(us\$|r\$|\$|€|£)?\s?` +
+			`(\d{1,3}(?:\.\d{3})+,\d+|\d+(?:,\d{3})+(?:\.\d+)?|\d+,\d+|\d*\.\d+|\d+)` +
+			`(?:\s?(` + longestFirst(magnitudeWords) + `)(?:[^\pL\pN]|$))?` +
+			`(?:\s?(%|(?:` + longestFirst(unitWords) + `)(?:[^\pL\pN]|$)))?`),
+	}
+}
+
+// longestFirst returns words as regexp alternatives, longest first so that
+// e.g. "kilometers" is not matched as "kilometer".
+func longestFirst(words []string) string {
+	for i, w := range words {
+		words[i] = regexp.QuoteMeta(w)
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if len(words[i]) != len(words[j]) {
+			return len(words[i]) > len(words[j])
+		}
+		return words[i] < words[j]
+	})
+	return strings.Join(words, "|")
+}
+
+// trimUnitSeparator removes the separator the unit pattern consumes after a
+// word unit, e.g. the comma in "12 km, then".
+func trimUnitSeparator(s string) string {
+	return strings.TrimRightFunc(s, func(r rune) bool {
+		return r != '%' && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Parse returns all quantities in text in order of appearance.
+func (n *UnitNormalizer) Parse(text string) []Quantity {
+	var quantities []Quantity
+	for _, m := range n.quantity.FindAllStringSubmatch(text, -1) {
+		value, err := parseNumber(m[2])
+		if err != nil {
+			continue
+		}
+		q := Quantity{Text: trimUnitSeparator(m[0]), Value: value, Bare: true}
+
+		if m[1] != "" {
+			q.Unit = currencySymbols[strings.ToLower(m[1])]
+			q.Bare = false
+		}
+		if m[3] != "" {
+			q.Value *= magnitudes[strings.ToLower(m[3])]
+			q.Bare = false
+		}
+		if m[4] != "" {
+			word := trimUnitSeparator(strings.ToLower(m[4]))
+			if u, ok := units[word]; ok && (q.Unit == "" || q.Unit == u.unit) {
+				q.Value *= u.scale
+				q.Unit = u.unit
+				q.Bare = false
+			}
+		}
+		quantities = append(quantities, q)
+	}
+	return quantities
+}
+
+// parseNumber parses a number written with English or Portuguese digit
+// grouping. A comma is a decimal separator when it follows a dot ("1.234,5")
+// or, without a dot, when it is not followed by exactly three digits ("4,5").
+func parseNumber(s string) (float64, error) {
+	if i := strings.LastIndexByte(s, ','); i >= 0 {
+		dot := strings.IndexByte(s, '.')
+		if dot >= 0 && dot < i || dot < 0 && len(s)-i-1 != 3 {
+			s = strings.ReplaceAll(s[:i], ".", "") + "." + s[i+1:]
+		} else {
+			s = strings.ReplaceAll(s, ",", "")
+		}
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// AreEquivalent reports whether the first quantities in val1 and val2
+// express the same amount. A percentage matches the equivalent plain
+// fraction, so "25%" is equivalent to "0.25".
+func (n *UnitNormalizer) AreEquivalent(val1, val2 string) bool {
+	q1, q2 := n.Parse(val1), n.Parse(val2)
+	if len(q1) == 0 || len(q2) == 0 {
+		return false
+	}
+	return q1[0].equals(q2[0])
+}
+
+// QuantitiesSupported reports whether every quantity with a unit or
+// magnitude in the claim appears, in any equivalent form, in at least one
+// evidence snippet. Claims without such quantities are always supported.
+func (n *UnitNormalizer) QuantitiesSupported(claimText string, evidences []models.Evidence) bool {
+	var claimQuantities []Quantity
+	for _, q := range n.Parse(claimText) {
+		if !q.Bare {
+			claimQuantities = append(claimQuantities, q)
+		}
+	}
+	if len(claimQuantities) == 0 {
+		return true
+	}
+
+	var evidenceQuantities []Quantity
+	for _, e := range evidences {
+		evidenceQuantities = append(evidenceQuantities, n.Parse(e.Snippet)...)
+	}
+
+	for _, cq := range claimQuantities {
+		found := false
+		for _, eq := range evidenceQuantities {
+			if cq.equals(eq) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// equals reports whether q and other are the same amount of the same unit,
+// within quantityTolerance. Plain numbers match fractions.
+func (q Quantity) equals(other Quantity) bool {
+	if q.Unit != other.Unit {
+		fraction := q.Unit == "fraction" && other.Unit == "" || q.Unit == "" && other.Unit == "fraction"
+		if !fraction {
+			return false
+		}
+	}
+	if q.Value == other.Value {
+		return true
+	}
+	scale := math.Max(math.Abs(q.Value), math.Abs(other.Value))
+	return math.Abs(q.Value-other.Value) <= quantityTolerance*scale
+}
@@ -0,0 +1,146 @@
+package verify
+
+import (
+	"math"
+	"testing"
+
+	"github.com/factchecker/verity/internal/models"
+)
+
+func TestUnitNormalizerParse(t *testing.T) {
+	tests := []struct {
+		text  string
+		value float64
+		unit  string
+		bare  bool
+	}{
+		{"in 2023", 2023, "", true},
+		{"1,234,567 people", 1234567, "", true},
+		{"3.75", 3.75, "", true},
+		{"12%", 0.12, "fraction", false},
+		{"12.5 percent", 0.125, "fraction", false},
+		{"40 per cent", 0.4, "fraction", false},
+		{"$25 trillion", 25e12, "USD", false},
+		{"US$ 3.5bn", 3.5e9, "USD", false},
+		{"€40 million", 40e6, "EUR", false},
+		{"£1.2k", 1200, "GBP", false},
+		{"25,000 billion dollars", 25e12, "USD", false},
+		{"300 euros", 300, "EUR", false},
+		{"5 km", 5000, "m", false},
+		{"5 kilometres", 5000, "m", false},
+		{"120 cm", 1.2, "m", false},
+		{"3 mm", 0.003, "m", false},
+		{"8 µm", 8e-6, "m", false},
+		{"26.2 miles", 26.2 * 1609.344, "m", false},
+		{"2 t", 2e6, "g", false},
+		{"70 kg", 70000, "g", false},
+		{"500 mg", 0.5, "g", false},
+		{"50 µg", 50e-6, "g", false},
+
+		// Portuguese separators and magnitudes
+		{"A inflação foi de 4,5%", 0.045, "fraction", false},
+		{"12,5 por cento", 0.125, "fraction", false},
+		{"2,5 milhões de pessoas", 2.5e6, "", false},
+		{"R$ 10 mil", 10000, "BRL", false},
+		{"R$ 1,2 bilhão", 1.2e9, "BRL", false},
+		{"3 trilhões de reais", 3e12, "", false},
+		{"1.234,56 reais", 1234.56, "BRL", false},
+		{"1.234.567,8", 1234567.8, "", true},
+	}
+
+	n := NewUnitNormalizer()
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			quantities := n.Parse(tt.text)
+			if len(quantities) != 1 {
+				t.Fatalf("Parse(%q) = %+v, want one quantity", tt.text, quantities)
+			}
+			q := quantities[0]
+			if math.Abs(q.Value-tt.value) > 1e-9*math.Max(1, math.Abs(tt.value)) || q.Unit != tt.unit || q.Bare != tt.bare {
+				t.Errorf("Parse(%q) = %+v, want value %g, unit %q, bare %v", tt.text, q, tt.value, tt.unit, tt.bare)
+			}
+		})
+	}
+}
+
+func TestUnitNormalizerAreEquivalent(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		// SI prefixes
+		{"5 km", "5,000 m", true},
+		{"1.5 kilometers", "150,000 cm", true},
+		{"2 mm", "2,000 µm", true},
+		{"3 kg", "3,000 grams", true},
+		{"250 mg", "0.25 g", true},
+		{"1 tonne", "1,000 kg", true},
+		{"5 km", "5 kg", false},
+		{"5 km", "5 m", false},
+
+		// Currency multipliers
+		{"$25 trillion", "25,000 billion dollars", true},
+		{"$3.5bn", "3,500 million dollars", true},
+		{"€2 million", "2,000 thousand euros", true},
+		{"£1.2k", "1,200 pounds", true},
+		{"$5 million", "€5 million", false},
+		{"$5 million", "$5 billion", false},
+		{"$100.4 million", "$100 million", true}, // within rounding tolerance
+
+		// Percentages and fractions
+		{"25%", "0.25", true},
+		{"25 percent", "25%", true},
+		{"12.5 per cent", "0.125", true},
+		{"10%", "11%", false},
+
+		// Portuguese
+		{"4,5%", "4.5%", true},
+		{"4,5%", "0.045", true},
+		{"2,5 milhões", "2,500,000", true},
+		{"R$ 10 mil", "10,000 reais", true},
+		{"R$ 1,2 bilhão", "R$ 1.200 milhões", false}, // "1.200" is read as 1.2
+		{"R$ 1,2 bilhão", "R$ 1.200,0 milhões", true},
+	}
+
+	n := NewUnitNormalizer()
+	for _, tt := range tests {
+		if got := n.AreEquivalent(tt.a, tt.b); got != tt.want {
+			t.Errorf("AreEquivalent(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestUnitNormalizerQuantitiesSupported(t *testing.T) {
+	evidence := func(snippets ...string) []models.Evidence {
+		evidences := make([]models.Evidence, len(snippets))
+		for i, s := range snippets {
+			evidences[i] = models.Evidence{Snippet: s}
+		}
+		return evidences
+	}
+
+	tests := []struct {
+		name      string
+		claim     string
+		evidences []models.Evidence
+		want      bool
+	}{
+		{"no quantities", "The law was repealed", evidence("Nothing relevant"), true},
+		{"bare numbers ignored", "In 2023 the law was repealed", evidence("Nothing relevant"), true},
+		{"converted units", "US GDP was $25 trillion", evidence("GDP reached 25,000 billion dollars"), true},
+		{"figure missing", "Unemployment fell to 12%", evidence("Unemployment fell sharply"), false},
+		{"one of several missing", "Sales rose 5% to $2 million", evidence("Sales rose 5%", "Revenue was $3 million"), false},
+		{"spread across evidence", "Sales rose 5% to $2 million", evidence("Sales rose 5%", "Revenue was $2 million"), true},
+		{"decimal comma", "A inflação foi de 4,5%", evidence("Inflation was 4.5% last year"), true},
+		{"portuguese magnitude", "O programa custou R$ 10 mil", evidence("The programme cost R$10,000"), true},
+	}
+
+	n := NewUnitNormalizer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := n.QuantitiesSupported(tt.claim, tt.evidences); got != tt.want {
+				t.Errorf("QuantitiesSupported(%q) = %v, want %v", tt.claim, got, tt.want)
+			}
+		})
+	}
+}
@@ -7,31 +7,73 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/factchecker/verity/internal/llm"
+	"github.com/factchecker/verity/internal/metrics"
 	"github.com/factchecker/verity/internal/models"
+	"github.com/rs/zerolog/log"
 )
 
 // ClaimVerifier verifies claims against evidence.
 type ClaimVerifier struct {
-	provider llm.Provider
+	provider      llm.Provider
+	fallbackModel string
+	contextWindow int
+	temporal      *TemporalExtractor
+	units         *UnitNormalizer
+	topEvidence   int // evidence sent per claim when ranking by embeddings, 0 sends all
 }
 
-// NewClaimVerifier creates a new claim verifier.
-func NewClaimVerifier(provider llm.Provider) *ClaimVerifier {
-	return &ClaimVerifier{provider: provider}
+// NewClaimVerifier creates a new claim verifier. If fallbackModel is set,
+// responses that cannot be parsed are retried once with that model. Evidence
+// is trimmed so prompts stay within contextWindow tokens.
+func NewClaimVerifier(provider llm.Provider, fallbackModel string, contextWindow int) *ClaimVerifier {
+	return &ClaimVerifier{
+		provider:      provider,
+		fallbackModel: fallbackModel,
+		contextWindow: contextWindow,
+		temporal:      NewTemporalExtractor(),
+		units:         NewUnitNormalizer(),
+	}
 }
 
 type verificationResult struct {
-	Status     string  `json:"verification_status"`
-	Confidence float64 `json:"confidence_score"`
-	Reasoning  string  `json:"reasoning"`
+	Status             string               `json:"verification_status"`
+	Confidence         float64              `json:"confidence_score"`
+	Reasoning          string               `json:"reasoning"`
+	ChainOfThought     string               `json:"chain_of_thought,omitempty"`
+	EvidenceUsefulness []EvidenceUsefulness `json:"evidence_usefulness,omitempty"`
+}
+
+// EvidenceUsefulness is the model's judgement of one piece of evidence.
+type EvidenceUsefulness struct {
+	Index  int    `json:"index"`
+	Useful bool   `json:"useful"`
+	Reason string `json:"reason"`
+}
+
+// Verdict is the outcome of verifying a single claim.
+type Verdict struct {
+	Status             models.VerificationStatus
+	Confidence         float64
+	Reasoning          string
+	ChainOfThought     string // Only populated in explain mode
+	EvidenceUsefulness []EvidenceUsefulness
 }
 
-// Verify verifies a claim against provided evidence.
-func (v *ClaimVerifier) Verify(ctx context.Context, claim models.Claim, evidences []models.Evidence) (models.VerificationStatus, float64, string, error) {
+// explainInstruction is appended to system prompts in explain mode.
+const explainInstruction = `
+
+Think step by step: first assess each piece of evidence, then synthesize, then conclude.
+Include this full step-by-step reasoning as an additional "chain_of_thought" string field in the JSON object.`
+
+// Verify verifies a claim against provided evidence. When explain is true
+// the model is asked for its full chain of thought as well. A non-empty
+// jurisdiction asks the model to judge the claim as it applies there.
+func (v *ClaimVerifier) Verify(ctx context.Context, claim models.Claim, evidences []models.Evidence, explain bool, jurisdiction string) (Verdict, error) {
 	if len(evidences) == 0 {
-		return models.StatusUnsupported, 0.0, "No evidence found to support this claim", nil
+		return Verdict{Status: models.StatusUnsupported, Reasoning: "No evidence found to support this claim"}, nil
 	}
 
 	systemPrompt := `You are a fact-checking expert. Analyze the claim against the provided evidence.
@@ -49,17 +91,72 @@ Respond with a JSON object:
 {
   "verification_status": "verified|mixed|unsupported",
   "confidence_score": 0.0-1.0,
-  "reasoning": "Brief explanation of your decision"
+  "reasoning": "Brief explanation of your decision",
+  "evidence_usefulness": [
+    {"index": 0, "useful": true, "reason": "Short explanation of why this evidence was or was not useful"}
+  ]
 }
 
+Include one evidence_usefulness entry per piece of evidence, where index is
+the 0-based position of the evidence (Evidence 1 has index 0).
+
 Status meanings:
 - verified: Evidence strongly supports the claim
 - mixed: Evidence is conflicting or partially supports
 - unsupported: No evidence supports the claim or evidence contradicts it
 
 Only respond with the JSON object, no other text.`
+	if explain {
+		systemPrompt += explainInstruction
+	}
+
+	evidences, positions := v.selectEvidence(ctx, claim, evidences)
+	evidences = v.fitEvidence(systemPrompt, claim, evidences)
+	userPrompt := verifyUserPrompt(claim, evidences) + jurisdictionNote(jurisdiction)
+
+	result, err := v.complete(ctx, systemPrompt, userPrompt)
+	if err != nil {
+		return Verdict{Status: models.StatusUnsupported}, err
+	}
+	if positions != nil {
+		// Usefulness refers to the selected evidence; map it back to the caller's
+		for i, u := range result.EvidenceUsefulness {
+			if u.Index >= 0 && u.Index < len(positions) {
+				result.EvidenceUsefulness[i].Index = positions[u.Index]
+			} else {
+				result.EvidenceUsefulness[i].Index = -1
+			}
+		}
+	}
 
-	// Format evidence for the prompt
+	verdict := Verdict{
+		Status:             parseStatus(result.Status),
+		Confidence:         result.Confidence,
+		Reasoning:          result.Reasoning,
+		EvidenceUsefulness: result.EvidenceUsefulness,
+	}
+	if explain {
+		verdict.ChainOfThought = result.ChainOfThought
+	}
+
+	// Temporal claims hinge on a specific date; penalize when no evidence mentions it.
+	if claim.Type == models.ClaimTypeTemporal && !v.temporal.DatesSupported(claim.Text, evidences) {
+		verdict.Confidence = max(0, verdict.Confidence-0.15)
+		verdict.Reasoning = strings.TrimSpace(verdict.Reasoning + " Specific date not found in evidence.")
+	}
+
+	// Statistical claims hinge on their figures; units are normalized so
+	// "$25 trillion" matches "25,000 billion dollars".
+	if claim.Type == models.ClaimTypeStatistical && !v.units.QuantitiesSupported(claim.Text, evidences) {
+		verdict.Confidence = max(0, verdict.Confidence-0.15)
+		verdict.Reasoning = strings.TrimSpace(verdict.Reasoning + " Specific figures not found in evidence.")
+	}
+
+	return verdict, nil
+}
+
+// verifyUserPrompt formats the claim and its evidence for the model.
+func verifyUserPrompt(claim models.Claim, evidences []models.Evidence) string {
 	var evidenceText strings.Builder
 	for i, e := range evidences {
 		evidenceText.WriteString(fmt.Sprintf("\nEvidence %d:\n", i+1))
@@ -68,34 +165,60 @@ Only respond with the JSON object, no other text.`
 		evidenceText.WriteString(fmt.Sprintf("Text: %s\n", e.Snippet))
 	}
 
-	userPrompt := fmt.Sprintf("Claim: %s\n\nEvidence found:%s\n\nAnalyze and provide verification result.", claim.Text, evidenceText.String())
+	return fmt.Sprintf("Claim: %s\n\nEvidence found:%s\n\nAnalyze and provide verification result.", claim.Text, evidenceText.String())
+}
 
-	opts := llm.DefaultCompletionOptions()
-	response, err := v.provider.CompleteWithSystem(ctx, systemPrompt, userPrompt, opts)
-	if err != nil {
-		return models.StatusUnsupported, 0.0, "", fmt.Errorf("verification failed: %w", err)
+// jurisdictionNote is appended to verification prompts for claims checked
+// in the context of a jurisdiction.
+func jurisdictionNote(jurisdiction string) string {
+	if jurisdiction == "" {
+		return ""
 	}
+	return "\n\nNote: Verify this claim in the context of jurisdiction: " + jurisdiction
+}
 
-	result, err := v.parseResponse(response)
-	if err != nil {
-		return models.StatusUnsupported, 0.0, "", fmt.Errorf("failed to parse verification response: %w", err)
+// fitEvidence drops the lowest-ranked evidence, and truncates the last
+// remaining snippet if needed, so the prompt fits the context window.
+// Evidence is assumed to be ordered best first.
+func (v *ClaimVerifier) fitEvidence(systemPrompt string, claim models.Claim, evidences []models.Evidence) []models.Evidence {
+	if v.contextWindow <= 0 {
+		return evidences
+	}
+	// complete sends the prompt with the default completion options
+	budget := promptBudgetTokens(v.contextWindow, llm.DefaultCompletionOptions().MaxTokens)
+	tokens := estimatePromptTokens(systemPrompt, verifyUserPrompt(claim, evidences))
+	if tokens <= budget {
+		return evidences
 	}
 
-	status := models.StatusUnsupported
-	switch result.Status {
-	case "verified":
-		status = models.StatusVerified
-	case "mixed":
-		status = models.StatusMixed
-	case "unsupported":
-		status = models.StatusUnsupported
+	kept := evidences
+	for len(kept) > 1 && estimatePromptTokens(systemPrompt, verifyUserPrompt(claim, kept)) > budget {
+		kept = kept[:len(kept)-1]
+	}
+
+	truncated := false
+	if over := estimatePromptTokens(systemPrompt, verifyUserPrompt(claim, kept)) - budget; over > 0 {
+		last := kept[len(kept)-1]
+		cut := max(0, len(last.Snippet)-over*4)
+		for cut > 0 && !utf8.RuneStart(last.Snippet[cut]) {
+			cut--
+		}
+		last.Snippet = last.Snippet[:cut] + "..."
+		kept = append(kept[:len(kept)-1:len(kept)-1], last)
+		truncated = true
 	}
 
-	return status, result.Confidence, result.Reasoning, nil
+	log.Warn().
+		Int("estimated_tokens", tokens).
+		Int("context_window", v.contextWindow).
+		Int("evidences_dropped", len(evidences)-len(kept)).
+		Bool("snippet_truncated", truncated).
+		Msg("Verification prompt exceeds context window, trimming evidence")
+	return kept
 }
 
 // VerifyWithoutEvidence uses LLM knowledge to verify a claim (air-gapped mode).
-func (v *ClaimVerifier) VerifyWithoutEvidence(ctx context.Context, claim models.Claim) (models.VerificationStatus, float64, string, error) {
+func (v *ClaimVerifier) VerifyWithoutEvidence(ctx context.Context, claim models.Claim, explain bool, jurisdiction string) (Verdict, error) {
 	systemPrompt := `You are a fact-checking expert. Analyze the claim using your training knowledge.
 
 IMPORTANT: You are operating without external evidence sources. Base your assessment only on your training data.
@@ -118,34 +241,79 @@ Status meanings:
 - unsupported: You cannot verify the claim or believe it may be incorrect
 
 Only respond with the JSON object, no other text.`
+	if explain {
+		systemPrompt += explainInstruction
+	}
 
-	userPrompt := fmt.Sprintf("Claim to verify: %s", claim.Text)
+	userPrompt := fmt.Sprintf("Claim to verify: %s", claim.Text) + jurisdictionNote(jurisdiction)
 
-	opts := llm.DefaultCompletionOptions()
-	response, err := v.provider.CompleteWithSystem(ctx, systemPrompt, userPrompt, opts)
+	result, err := v.complete(ctx, systemPrompt, userPrompt)
 	if err != nil {
-		return models.StatusUnsupported, 0.0, "", fmt.Errorf("verification failed: %w", err)
+		return Verdict{Status: models.StatusUnsupported}, err
 	}
 
-	result, err := v.parseResponse(response)
-	if err != nil {
-		return models.StatusUnsupported, 0.0, "", fmt.Errorf("failed to parse verification response: %w", err)
+	verdict := Verdict{
+		Status:     parseStatus(result.Status),
+		Confidence: result.Confidence,
+		Reasoning:  result.Reasoning,
 	}
+	if explain {
+		verdict.ChainOfThought = result.ChainOfThought
+	}
+
+	// Add disclaimer to reasoning
+	verdict.Reasoning += " [Note: Verified using model knowledge only, without external evidence sources]"
+
+	return verdict, nil
+}
 
-	status := models.StatusUnsupported
-	switch result.Status {
+// parseStatus maps the model's status string to a VerificationStatus,
+// treating anything unrecognized as unsupported.
+func parseStatus(s string) models.VerificationStatus {
+	switch s {
 	case "verified":
-		status = models.StatusVerified
+		return models.StatusVerified
 	case "mixed":
-		status = models.StatusMixed
-	case "unsupported":
-		status = models.StatusUnsupported
+		return models.StatusMixed
+	default:
+		return models.StatusUnsupported
 	}
+}
 
-	// Add disclaimer to reasoning
-	reasoning := result.Reasoning + " [Note: Verified using model knowledge only, without external evidence sources]"
+// complete sends a verification prompt and parses the JSON result. On a parse
+// failure it escalates once to the fallback model with a stricter prompt.
+func (v *ClaimVerifier) complete(ctx context.Context, systemPrompt, userPrompt string) (*verificationResult, error) {
+	opts := llm.DefaultCompletionOptions()
+	response, err := v.provider.CompleteWithSystem(ctx, systemPrompt, userPrompt, opts)
+	if err != nil {
+		return nil, fmt.Errorf("verification failed: %w", err)
+	}
+	chargeTokens(ctx, systemPrompt, userPrompt, response)
 
-	return status, result.Confidence, reasoning, nil
+	result, parseErr := v.parseResponse(response)
+	if parseErr == nil {
+		return result, nil
+	}
+	if v.fallbackModel == "" {
+		return nil, fmt.Errorf("failed to parse verification response: %w", parseErr)
+	}
+
+	log.Warn().Err(parseErr).Str("model", v.fallbackModel).Msg("Unparseable verification response, retrying with fallback model")
+	metrics.LLMFallbacks.Inc()
+
+	opts.Model = v.fallbackModel
+	retryPrompt := userPrompt + "\n\nYour previous response was not valid JSON. Please respond ONLY with the JSON object, nothing else."
+	response, err = v.provider.CompleteWithSystem(ctx, systemPrompt, retryPrompt, opts)
+	if err != nil {
+		return nil, fmt.Errorf("fallback verification failed: %w", err)
+	}
+	chargeTokens(ctx, systemPrompt, retryPrompt, response)
+
+	result, err = v.parseResponse(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fallback verification response: %w", err)
+	}
+	return result, nil
 }
 
 func (v *ClaimVerifier) parseResponse(response string) (*verificationResult, error) {
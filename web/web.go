@@ -0,0 +1,9 @@
+// Package web embeds the static files of the web interface.
+package web
+
+import "embed"
+
+// Static holds the web interface under static/.
+//
+//go:embed static
+var Static embed.FS